@@ -0,0 +1,109 @@
+package main
+
+import "time"
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// Animation — short eased transitions for toggles and bars
+// Widgets ask the animator for the value to draw this frame. When the target
+// changes, the drawn value eases from where it was to the new target; the main
+// loop keeps rendering frames until every transition has finished.
+// ═══════════════════════════════════════════════════════════════════════════════
+
+const (
+	animDuration  = 150 * time.Millisecond
+	frameInterval = 16 * time.Millisecond
+)
+
+// animKey identifies a widget by kind and screen position.
+type animKey struct {
+	kind byte
+	x, y int
+}
+
+type tween struct {
+	from, to float64
+	start    time.Time
+	seen     uint64 // frame the widget was last drawn in
+}
+
+type Animator struct {
+	tweens map[animKey]*tween
+	frame  uint64
+	now    func() time.Time
+}
+
+func NewAnimator() *Animator {
+	return &Animator{tweens: make(map[animKey]*tween), now: time.Now}
+}
+
+// beginFrame and endFrame bracket one render. Widgets not drawn in a frame
+// are forgotten, so switching tabs or resizing snaps instead of animating.
+func (an *Animator) beginFrame() {
+	if an != nil {
+		an.frame++
+	}
+}
+
+func (an *Animator) endFrame() {
+	if an == nil {
+		return
+	}
+	for k, tw := range an.tweens {
+		if tw.seen != an.frame {
+			delete(an.tweens, k)
+		}
+	}
+}
+
+// value returns what to draw for the widget at k this frame. The first time
+// a widget is seen it is drawn at its target straight away.
+func (an *Animator) value(k animKey, target float64) float64 {
+	if an == nil {
+		return target
+	}
+	now := an.now()
+	tw, ok := an.tweens[k]
+	if !ok {
+		an.tweens[k] = &tween{from: target, to: target, seen: an.frame}
+		return target
+	}
+	tw.seen = an.frame
+	if target != tw.to {
+		// restart from wherever the old transition had got to
+		tw.from, tw.to, tw.start = tw.progress(now), target, now
+	}
+	return tw.progress(now)
+}
+
+func (tw *tween) progress(now time.Time) float64 {
+	p := float64(now.Sub(tw.start)) / float64(animDuration)
+	if p >= 1 {
+		return tw.to
+	}
+	return tw.from + (tw.to-tw.from)*easeOutCubic(p)
+}
+
+// Active reports whether any transition still needs frames.
+func (an *Animator) Active() bool {
+	if an == nil {
+		return false
+	}
+	now := an.now()
+	for _, tw := range an.tweens {
+		if tw.from != tw.to && now.Sub(tw.start) < animDuration {
+			return true
+		}
+	}
+	return false
+}
+
+func easeOutCubic(p float64) float64 {
+	p = 1 - p
+	return 1 - p*p*p
+}
+
+// lerpColor mixes a towards b by p (0 = a, 1 = b).
+func lerpColor(a, b Color, p float64) Color {
+	mix := func(x, y int) int { return x + int(float64(y-x)*p+0.5) }
+	return Color{mix(a.R, b.R), mix(a.G, b.G), mix(a.B, b.B)}
+}
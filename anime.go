@@ -0,0 +1,180 @@
+package main
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// AniMe battery — with [anime] battery = true, --daemon keeps the battery
+// charge on the AniMe lid display: the percentage, a gauge and a bolt while
+// charging. Frames are drawn into a PNG with one pixel per LED and pushed
+// with `asusctl anime pixel-image`; a new one goes out when the percentage
+// or charging state changes, checked every minute and on plug or unplug.
+// The display is cleared when the daemon stops.
+// ═══════════════════════════════════════════════════════════════════════════════
+
+const (
+	animeW, animeH    = 33, 55 // LED grid of the AniMe panel
+	animeBatteryEvery = time.Minute
+)
+
+type animeBattery struct {
+	stop  chan struct{}
+	shown string // percentage and state of the frame on the display
+}
+
+// animeGlyphs is a 3×5 font for the percentage, one string per row.
+var animeGlyphs = map[rune][5]string{
+	'0': {"###", "#.#", "#.#", "#.#", "###"},
+	'1': {".#.", "##.", ".#.", ".#.", "###"},
+	'2': {"###", "..#", "###", "#..", "###"},
+	'3': {"###", "..#", "###", "..#", "###"},
+	'4': {"#.#", "#.#", "###", "..#", "..#"},
+	'5': {"###", "#..", "###", "..#", "###"},
+	'6': {"###", "#..", "###", "#.#", "###"},
+	'7': {"###", "..#", "..#", "..#", "..#"},
+	'8': {"###", "#.#", "###", "#.#", "###"},
+	'9': {"###", "#.#", "###", "..#", "###"},
+	'%': {"#.#", "..#", ".#.", "#..", "#.#"},
+}
+
+var animeBolt = [5]string{"..#", ".#.", "###", ".#.", "#.."}
+
+// drawGlyph draws g at twice its size with its top left corner at x, y.
+func drawGlyph(img *image.Gray, x, y int, g [5]string) {
+	for row, line := range g {
+		for col, c := range line {
+			if c != '#' {
+				continue
+			}
+			for dy := 0; dy < 2; dy++ {
+				for dx := 0; dx < 2; dx++ {
+					img.SetGray(x+col*2+dx, y+row*2+dy, color.Gray{Y: 255})
+				}
+			}
+		}
+	}
+}
+
+// animeBatteryFrame draws the percentage, a gauge and, while charging, a
+// bolt below it.
+func animeBatteryFrame(pct int, charging bool) *image.Gray {
+	img := image.NewGray(image.Rect(0, 0, animeW, animeH))
+	text := strconv.Itoa(clamp(pct, 0, 100)) + "%"
+	w := len(text)*8 - 2
+	x := (animeW - w) / 2
+	for _, r := range text {
+		drawGlyph(img, x, 8, animeGlyphs[r])
+		x += 8
+	}
+
+	// gauge: outline with a tip on the right, filled in proportion
+	outline := color.Gray{Y: 160}
+	for x := 3; x <= 27; x++ {
+		img.SetGray(x, 22, outline)
+		img.SetGray(x, 33, outline)
+	}
+	for y := 22; y <= 33; y++ {
+		img.SetGray(3, y, outline)
+		img.SetGray(27, y, outline)
+	}
+	for y := 25; y <= 30; y++ {
+		img.SetGray(28, y, outline)
+		img.SetGray(29, y, outline)
+	}
+	fill := (21*clamp(pct, 0, 100) + 50) / 100
+	for y := 24; y <= 31; y++ {
+		for x := 5; x < 5+fill; x++ {
+			img.SetGray(x, y, color.Gray{Y: 255})
+		}
+	}
+
+	if charging {
+		drawGlyph(img, (animeW-6)/2, 38, animeBolt)
+	}
+	return img
+}
+
+// startAnimeBattery shows the battery on the AniMe display until Shutdown.
+func (a *App) startAnimeBattery() {
+	a.anime.stop = make(chan struct{})
+	stop := a.anime.stop
+	go func() {
+		tick := time.NewTicker(animeBatteryEvery)
+		defer tick.Stop()
+		for {
+			st := a.backend.GetBatteryStatus()
+			a.Post(func() { a.showAnimeBattery(st) })
+			select {
+			case <-stop:
+				return
+			case <-tick.C:
+			}
+		}
+	}()
+}
+
+func (a *App) stopAnimeBattery() {
+	if a.anime.stop == nil {
+		return
+	}
+	close(a.anime.stop)
+	a.anime.stop = nil
+	if a.anime.shown != "" {
+		ok, out := a.backend.ClearAnime()
+		a.automationLog("AniMe battery: clear", out, ok)
+	}
+}
+
+// showAnimeBattery pushes a frame for st unless the display already shows
+// the same percentage and state.
+func (a *App) showAnimeBattery(st BatteryStatus) {
+	if a.anime.stop == nil || !st.Present {
+		return
+	}
+	charging := st.Status == "Charging"
+	key := fmt.Sprintf("%d%% %v", st.Percent, charging)
+	if key == a.anime.shown {
+		return
+	}
+	what := fmt.Sprintf("AniMe battery: %d%%", st.Percent)
+	if charging {
+		what += " charging"
+	}
+	path := filepath.Join(os.TempDir(), "asusctl-tui-anime.png")
+	if err := writePNG(path, animeBatteryFrame(st.Percent, charging)); err != nil {
+		a.automationLog(what, err.Error(), false)
+		return
+	}
+	ok, out := a.backend.SetAnimeImage(path)
+	a.automationLog(what, out, ok)
+	if ok {
+		a.anime.shown = key
+	}
+}
+
+// writePNG writes img to path through a temp file, so asusctl never reads
+// half a frame.
+func writePNG(path string, img image.Image) error {
+	f, err := os.CreateTemp(filepath.Dir(path), ".anime-*.png")
+	if err != nil {
+		return err
+	}
+	if err := png.Encode(f, img); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
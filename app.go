@@ -2,7 +2,12 @@ package main
 
 import (
 	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -13,59 +18,180 @@ import (
 type Tab int
 
 const (
-	TabProfile Tab = iota
+	TabDashboard Tab = iota
+	TabProfile
 	TabKeyboard
 	TabAura
 	TabBattery
 	TabFans
+	TabGpu
 	TabBios
+	TabSystem
 	TabConsole
+	TabLogs
+	TabMonitor
+	TabAutomation
+	TabSlash
+	TabScenes
+	TabDisplay
+	TabSettings
 	TabCount
 )
 
 var tabNames = []string{
-	"Profile", "Keyboard", "Aura RGB", "Battery", "Fans", "BIOS", "Console",
+	"Dashboard", "Profile", "Keyboard", "Aura RGB", "Battery", "Fans", "GPU", "BIOS", "System", "Console", "Logs", "Monitor", "Automation", "Slash", "Scenes", "Display", "Settings",
 }
 
 var tabKeys = []string{
-	"1", "2", "3", "4", "5", "6", "7",
+	"", "1", "2", "3", "4", "5", "6", "7", "8", "9", "0", "", "", "", "", "", "",
 }
 
+// Tabs without a number key are reached with [ and ], which step through
+// every tab.
+
 type App struct {
 	term    *Terminal
-	backend *Backend
+	backend Backend
 	running bool
 
 	// Navigation
 	activeTab Tab
 	focusIdx  int // per-tab focus index
 
+	// Writes that run one at a time off the main loop
+	queue      *CommandQueue
+	sceneSteps *sceneSteps // set while applyScene queues through the setters
+
 	// State
-	profile       string
-	kbdLevel      int // 0=off,1=low,2=med,3=high
-	auraMode      int
-	auraSection   int // 0=modes, 1=colour1, 2=colour2, 3=speed
-	auraColour1   int // index into auraColours
-	auraColour2   int
-	auraSpeed     int // 0=low, 1=med, 2=high
-	chargeLimit   int
-	oneShotCharge bool
+	profile          string
+	kbdLevel         int // 0=off,1=low,2=med,3=high
+	auraMode         int
+	auraSection      int // 0=modes, 1=colour1, 2=colour2, 3=speed, 4=brightness, 5=zones, 6=direction
+	auraColour1      int // index into auraColours
+	auraColour2      int
+	auraCustom       [2]AuraColour            // colour entered with c per row, see aura_colour.go
+	auraSpeed        int                      // 0=low, 1=med, 2=high
+	auraDirection    int                      // index into auraDirections
+	auraDirty        bool                     // selection changed since the last apply
+	auraPreset       string                   // favourite last saved or selected
+	auraApplyGen     int                      // apply_on_select: only the last move's apply runs
+	auraApplyQueued  bool                     // apply_on_select will apply the unapplied selection
+	auraSupported    []string                 // effects the keyboard can do; nil = unknown, all offered
+	auraZones        int                      // zones of a multizone keyboard, 0 = one; see aura_zones.go
+	auraPower        []AuraPowerLed           // lightbar, logo and lid LEDs; see aura_power.go
+	lightsOut        *lightsOut               // what l switched off, see lights.go
+	auraZoneColour   [maxAuraZones]AuraColour // zone's own colour; empty follows Colour
+	auraAnim         auraPreview              // frames of the effect preview, see aura_preview.go
+	auraApplied      *AuraState               // what the keyboard shows, see aura_keyboard.go
+	auraAppliedZones []string                 // zone colours on the keyboard, see aura_keyboard.go
+	chargeLimit      int
+	chargeLimitSet   int  // the limit asusd holds; chargeLimit is the slider
+	oneShotCharge    bool // armed, read back from the kernel threshold
+	oneShotKnown     bool // the threshold could be read
 
 	// Fan curve
-	selectedFan   int // 0=CPU, 1=GPU
-	fanSpeeds     [2][8]int
-	fanTemps      [8]int
+	selectedFan   int // 0=CPU, 1=GPU, 2=Mid
+	fanSpeeds     [3][8]int
+	fanTemps      [3][8]int
+	fanMid        bool // asusd holds a curve for a mid fan
 	fanEnabled    bool
 	fanFocusPoint int
+	fanDirty      bool // curve edited since it was loaded or applied
+	fanLoaded     bool // fanSpeeds/fanTemps hold asusd's curves, not defaults
 
 	// BIOS
-	panelOverdrive  bool
 	gpuMuxDedicated bool
 
+	bios             armouryView
+	ppt              armouryView // power limits on the Profile tab
+	uefi             uefiWrites
+	kbdSleepLighting bool // no getter in asusctl; assumes the asusd default
+
+	profileSource string // "asusd" or the fallback service
+
+	// GPU (supergfxctl)
+	gfx gfxState
+
+	// Game mode; nil when inactive
+	gameMode *gameModeState
+
+	// Automation
+	automation automationState
+	workspace  string // active workspace, see workspace.go
+	rules      rulesState
+	quiet      quietState
+
+	// Slash lid LED bar
+	slash slashView
+
+	// Built-in panel (Display tab)
+	panel panelView
+
+	// System
+	platformLeds []PlatformLed
+	touchpad     TouchpadState
+	daemonStatus string // systemctl is-active asusd, "" without systemd
+
+	// Logs
+	logs logState
+
+	// Battery runtime planner
+	planner planner
+
+	// Monitor
+	monitor monitorState
+
+	// Releases the logind sleep inhibitor; nil when none is held
+	inhibitRelease func()
+
+	// Live hwmon readings, see sensors.go
+	sensors    SensorReading
+	sensorStop chan struct{}
+
 	// Console
-	consoleInput  string
+	consoleInput  LineEdit
 	consoleLog    []ConsoleLine
 	consoleScroll int
+	consoleFind   consoleSearch
+	history       consoleHistory // console.log bookkeeping
+
+	// Changes since launch (D), see changes.go
+	launch  launchState
+	changes *changesView
+
+	picker   *colourPicker   // HSV picker (h on the Aura tab), see colour_picker.go
+	perKey   *perKeyEditor   // per-key editor (k on the Aura tab), see aura_perkey.go
+	commands *commandPalette // Ctrl-P, see commands.go
+
+	anime animeBattery // --daemon with [anime] battery, see anime.go
+	logTo io.Writer    // --daemon: console lines are printed here too
+
+	// Completion alert, see completion.go
+	headerFlash   time.Time // header is tinted until then
+	headerFlashOk bool
+
+	loading bool            // startup reads still in flight
+	loaded  map[string]bool // startupProbes that have finished, for the splash
+
+	// --quick: only the quick panel, see quick.go
+	quick    bool
+	litLevel int // keyboard level toggleLighting restores
+
+	// Config
+	cfg         *Config
+	lastCommand string // exact command of the last action, for the hint line
+
+	// Work posted from background goroutines, run on the main loop
+	events chan func()
+	// SIGINT/SIGTERM for main's quit handler; muted while $EDITOR runs
+	quitSig chan os.Signal
+	// Frames requested by background tickers; holds at most one, so a
+	// slow loop draws once rather than catching up
+	redraw chan struct{}
+
+	// Modal dialogs, drawn on top and consuming input while open
+	confirm *Confirm
+	prompt  *Prompt
 
 	// Status
 	installed  bool
@@ -79,6 +205,7 @@ type ConsoleLine struct {
 	Command string
 	Output  string
 	Ok      bool
+	Hint    string // suggested fix for a failure, from classifyFailure
 }
 
 var kbdLabels = []string{"Off", "Low", "Med", "High"}
@@ -110,6 +237,13 @@ var auraColours = []AuraColour{
 var auraSpeeds = []string{"low", "med", "high"}
 var auraSpeedLabels = []string{"Low", "Med", "High"}
 
+// auraApplyDelay is how long the Aura selection rests before
+// apply_on_select applies it.
+const auraApplyDelay = 400 * time.Millisecond
+
+var auraDirections = []string{"left", "right", "up", "down"}
+var auraDirectionLabels = []string{"Left", "Right", "Up", "Down"}
+
 // auraEffectNeedsColour1 returns true if the effect uses --colour
 func auraEffectNeedsColour1(mode string) bool {
 	switch mode {
@@ -133,29 +267,223 @@ func auraEffectNeedsSpeed(mode string) bool {
 	return true
 }
 
-func NewApp(term *Terminal, backend *Backend) *App {
+// auraEffectNeedsDirection returns true if the effect uses --direction
+func auraEffectNeedsDirection(mode string) bool {
+	return mode == "Rainbow Wave"
+}
+
+func NewApp(term *Terminal, backend Backend, cfg *Config) *App {
 	a := &App{
-		term:        term,
-		backend:     backend,
-		running:     true,
-		activeTab:   TabProfile,
-		profile:     "Balanced",
-		kbdLevel:    2,
-		chargeLimit: 80,
-		auraSpeed:   1, // med
-		auraColour2: 4, // cyan (contrast with default red)
-		fanTemps:    [8]int{30, 40, 50, 60, 70, 80, 90, 100},
+		term:             term,
+		backend:          backend,
+		cfg:              cfg,
+		running:          true,
+		activeTab:        TabDashboard,
+		profile:          "Balanced",
+		kbdLevel:         2,
+		chargeLimit:      80,
+		kbdSleepLighting: true,
+		auraSpeed:        1, // med
+		auraDirection:    1, // right, what the wave always used before
+		auraColour2:      4, // cyan (contrast with default red)
+		fanTemps:         [3][8]int{defaultFanTemps, defaultFanTemps, defaultFanTemps},
+		events:           make(chan func(), 64),
+		redraw:           make(chan struct{}, 1),
+	}
+	a.queue = NewCommandQueue(backend)
+	// Start on the first tab the policy leaves open
+	for a.activeTab < TabCount-1 && cfg.Lockdown.TabLocked(a.activeTab) {
+		a.activeTab++
+	}
+	if cfg.Animations {
+		term.SetAnimator(NewAnimator())
 	}
+	fahrenheit = cfg.TemperatureUnit == "fahrenheit"
+	applyTheme(cfg.Theme)
+	term.SetASCII(cfg.ASCIIMode)
 	// Default fan curves
 	a.fanSpeeds[0] = [8]int{0, 5, 10, 20, 35, 55, 65, 65} // CPU
 	a.fanSpeeds[1] = [8]int{0, 5, 10, 15, 30, 50, 60, 60} // GPU
+	a.fanSpeeds[2] = [8]int{0, 5, 10, 15, 25, 40, 50, 55} // Mid
 	return a
 }
 
+// Init reads the hardware state in the background so the loading screen
+// appears immediately; the result is applied on the main loop.
 func (a *App) Init() {
 	a.installed = a.backend.IsInstalled()
-	if a.installed {
-		a.profile = a.backend.GetProfile()
+	if !a.installed {
+		return
+	}
+	a.loading = true
+	a.loaded = map[string]bool{}
+	go func() {
+		st := loadInitialState(a.backend, func(probe string) {
+			a.Post(func() { a.loaded[probe] = true })
+		})
+		a.Post(func() { a.applyInitialState(st) })
+	}()
+}
+
+// startupProbes name the concurrent reads of loadInitialState, in the
+// order the splash lists them.
+var startupProbes = []string{
+	"Profile", "Keyboard", "Battery", "Aura", "Fans", "GPU", "Slash", "asusd", "Platform", "Firmware",
+}
+
+// initialState is everything read from the backend at startup.
+type initialState struct {
+	profile       string
+	profileSource string
+	kbd           string
+	chargeLimit   int
+	threshold     int // kernel charge_control_end_threshold
+	thresholdOk   bool
+	aura          *AuraState
+	auraModes     []string
+	auraZones     int
+	auraPower     []AuraPowerLed
+	fanEnabled    bool
+	fanCurves     FanCurves
+	fanCurvesErr  error
+	bios          armouryView
+	gpuMux        string // raw armoury output, "" if unsupported
+	platformLeds  []PlatformLed
+	touchpad      TouchpadState
+	gfx           gfxState
+	slash         slashView
+	daemonStatus  string
+}
+
+// loadInitialState runs the startup reads concurrently; each one spawns
+// asusctl, so serially they add up to seconds on a slow system. progress,
+// if not nil, is called from the probe's goroutine as each one finishes.
+func loadInitialState(b Backend, progress func(probe string)) initialState {
+	var st initialState
+	var wg sync.WaitGroup
+	run := func(probe string, fn func()) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			fn()
+			if progress != nil {
+				progress(probe)
+			}
+		}()
+	}
+	run("Profile", func() {
+		st.profileSource = b.ProfileSource()
+		st.profile = b.GetProfile()
+		st.fanCurves, st.fanCurvesErr = b.ReadFanCurves(st.profile)
+	})
+	run("Keyboard", func() { st.kbd = b.GetKbdBrightness() })
+	run("Battery", func() {
+		st.chargeLimit = b.GetChargeLimit()
+		st.threshold, st.thresholdOk = b.GetChargeThreshold()
+	})
+	run("Aura", func() {
+		st.aura = b.GetAuraState()
+		st.auraModes = b.GetAuraModes()
+		st.auraZones = b.GetAuraZones()
+		st.auraPower = b.GetAuraPower()
+	})
+	run("Fans", func() { st.fanEnabled = b.GetFanEnabled() })
+	run("GPU", func() { st.gfx = readGfxState(b) })
+	run("Slash", func() { st.slash = readSlashView(b) })
+	run("asusd", func() { st.daemonStatus = b.DaemonStatus() })
+	run("Platform", func() {
+		st.platformLeds = b.GetPlatformLeds()
+		st.touchpad = b.GetTouchpad()
+	})
+	run("Firmware", func() {
+		st.bios = readArmouryView(b)
+		if ok, out := b.GetGpuMux(); ok {
+			st.gpuMux = out
+		}
+	})
+	wg.Wait()
+	return st
+}
+
+func (a *App) applyInitialState(st initialState) {
+	a.loading = false
+	a.loadConsoleHistory()
+	a.addLog("--version", a.backend.Version(), true)
+	a.profile = st.profile
+	a.profileSource = st.profileSource
+	for i, v := range kbdValues {
+		if v == st.kbd {
+			a.kbdLevel = i
+			break
+		}
+	}
+	a.chargeLimit, a.chargeLimitSet = st.chargeLimit, st.chargeLimit
+	a.setOneShot(st.threshold, st.thresholdOk)
+	a.auraSupported = st.auraModes
+	a.auraZones = st.auraZones
+	a.auraPower = st.auraPower
+	if st.aura != nil {
+		a.initAuraState(st.aura)
+		a.auraAppliedZones = st.aura.Zones
+	}
+	a.auraApplied = st.aura
+	a.fanEnabled = st.fanEnabled
+	a.setFanCurves(st.fanCurves, st.fanCurvesErr)
+	a.bios = st.bios
+	a.gpuMuxDedicated = parseArmouryValue(st.gpuMux) == "1"
+	a.platformLeds = st.platformLeds
+	a.touchpad = st.touchpad
+	a.gfx = st.gfx
+	a.slash = st.slash
+	a.daemonStatus = st.daemonStatus
+	if daemonDown(a.daemonStatus) {
+		a.offerDaemonRestart()
+	}
+	a.captureLaunch()
+
+	a.workspace = a.loadWorkspace()
+	a.loadUefiWrites()
+	a.startPlanner()
+	a.startMonitor()
+	a.startSensors()
+	a.startAutomation()
+
+	err := a.backend.WatchChanges(func(area ChangeArea) {
+		a.Post(func() { a.syncArea(area) })
+	})
+	if err != nil {
+		a.addLog("live sync", "disabled: "+err.Error(), false)
+	}
+}
+
+// Post queues fn to run on the main loop. Safe to call from any goroutine.
+func (a *App) Post(fn func()) {
+	select {
+	case a.events <- fn:
+	default: // loop is far behind; drop rather than block the sender
+	}
+}
+
+// RequestRender asks the main loop for a new frame. Requests made before it
+// gets to them count as one. Safe to call from any goroutine.
+func (a *App) RequestRender() {
+	select {
+	case a.redraw <- struct{}{}:
+	default:
+	}
+}
+
+// syncArea re-reads state that was changed outside the TUI.
+func (a *App) syncArea(area ChangeArea) {
+	switch area {
+	case ChangeProfile:
+		if p := a.backend.GetProfile(); p != a.profile {
+			a.profile = p
+			a.loadFanCurves()
+			a.SetStatus("Profile changed externally → "+p, true)
+			a.followProfile()
+		}
+	case ChangeKeyboard:
 		kbd := a.backend.GetKbdBrightness()
 		for i, v := range kbdValues {
 			if v == kbd {
@@ -163,34 +491,34 @@ func (a *App) Init() {
 				break
 			}
 		}
-		a.chargeLimit = a.backend.GetChargeLimit()
-		if aura := a.backend.GetAuraState(); aura != nil {
-			a.initAuraState(aura)
-		}
+	case ChangeAura:
+		a.refreshAura()
+	case ChangeFans:
 		a.fanEnabled = a.backend.GetFanEnabled()
-		a.fanSpeeds[0], a.fanSpeeds[1] = a.backend.ParseFanCurveSpeeds(a.profile)
+		if !a.fanDirty {
+			a.loadFanCurves()
+		}
 	}
 }
 
-func (a *App) initAuraState(aura *AuraState) {
-	// Map config mode names (e.g. "RainbowCycle") to display names ("Rainbow Cycle")
-	modeMap := map[string]string{
-		"RainbowCycle": "Rainbow Cycle",
-		"RainbowWave":  "Rainbow Wave",
+// refreshAura re-reads the effect, which the Fn keys and other tools change
+// behind the app's back, and moves the Aura tab's selection onto it unless
+// it holds unapplied edits.
+func (a *App) refreshAura() {
+	a.readAuraApplied()
+	if aura := a.auraApplied; aura != nil && !a.auraDirty {
+		a.initAuraState(aura)
 	}
-	displayMode := aura.Mode
-	if mapped, ok := modeMap[aura.Mode]; ok {
-		displayMode = mapped
-	}
-	for i, m := range auraModes {
-		if m == displayMode {
-			a.auraMode = i
-			break
-		}
+}
+
+func (a *App) initAuraState(aura *AuraState) {
+	// Config mode names (e.g. "RainbowCycle") to display names ("Rainbow Cycle")
+	if i := indexFold(auraModes, auraModeFromRon(aura.Mode)); i >= 0 {
+		a.auraMode = i
 	}
 
-	a.auraColour1 = closestAuraColour(aura.R1, aura.G1, aura.B1)
-	a.auraColour2 = closestAuraColour(aura.R2, aura.G2, aura.B2)
+	a.auraColour1 = a.matchAuraColour(0, aura.R1, aura.G1, aura.B1)
+	a.auraColour2 = a.matchAuraColour(1, aura.R2, aura.G2, aura.B2)
 
 	speedLo := strings.ToLower(aura.Speed)
 	for i, s := range auraSpeeds {
@@ -199,22 +527,12 @@ func (a *App) initAuraState(aura *AuraState) {
 			break
 		}
 	}
-}
-
-func closestAuraColour(r, g, b int) int {
-	best := 0
-	bestDist := 1<<31 - 1
-	for i, c := range auraColours {
-		dr := int(c.Rgb.R) - r
-		dg := int(c.Rgb.G) - g
-		db := int(c.Rgb.B) - b
-		dist := dr*dr + dg*dg + db*db
-		if dist < bestDist {
-			bestDist = dist
-			best = i
-		}
+	if i := indexFold(auraDirections, aura.Direction); i >= 0 {
+		a.auraDirection = i
+	}
+	if a.auraZones > 0 {
+		a.setZoneColours(aura.Zones)
 	}
-	return best
 }
 
 func (a *App) SetStatus(msg string, ok bool) {
@@ -223,16 +541,96 @@ func (a *App) SetStatus(msg string, ok bool) {
 	a.statusTime = time.Now()
 }
 
+// SetError classifies the output of a failed backend call and shows a
+// category-specific message with a suggested fix instead of raw stderr.
+func (a *App) SetError(out string) {
+	e := classifyFailure(out)
+	msg := e.Message()
+	if hint := e.Hint(); hint != "" {
+		msg += " — " + hint
+	}
+	a.SetStatus(msg, false)
+}
+
+// offerElevation asks to retry the last asusctl command through pkexec when
+// asusd refused it for lack of privileges. onOk runs after a successful retry.
+func (a *App) offerElevation(out string, onOk func()) {
+	a.offerElevationFor(a.backend.LastCommand(), out, onOk)
+}
+
+// offerElevationFor is offerElevation for a known argv, e.g. a queued command.
+func (a *App) offerElevationFor(argv []string, out string, onOk func()) {
+	if classifyFailure(out).Kind != ErrPermissionDenied || a.cfg.Lockdown.ActionLocked("elevate") {
+		return
+	}
+	if len(argv) == 0 {
+		return
+	}
+	cmd := strings.Join(argv, " ")
+	a.confirm = &Confirm{
+		Title: "Permission denied",
+		Lines: []string{
+			"asusd refused this command:",
+			"  " + cmd,
+			"",
+			"Retry with administrator rights via pkexec?",
+		},
+		OnYes: func() {
+			// pkexec may need the terminal for its password prompt
+			a.term.ExitRaw()
+			fmt.Println("Authenticating via pkexec for: " + cmd)
+			ok, out := a.backend.RunElevated(argv...)
+			a.term.EnterRaw()
+			a.addLog("pkexec "+cmd, out, ok)
+			if ok {
+				onOk()
+			} else {
+				a.SetError(out)
+			}
+		},
+	}
+}
+
+// logAction records the backend call that just ran in the console log under
+// its exact command line, and remembers it for the command hint. asusctl
+// commands are logged without the binary, like the Console prompt.
+func (a *App) logAction(out string, ok bool) {
+	a.logCommand(a.backend.LastCommand(), out, ok)
+}
+
+// logCommand is logAction for a known argv, e.g. a queued command.
+func (a *App) logCommand(argv []string, out string, ok bool) {
+	a.lastCommand = strings.Join(argv, " ")
+	if len(argv) > 0 && filepath.Base(argv[0]) == "asusctl" {
+		argv = argv[1:]
+	}
+	a.addLog(strings.Join(argv, " "), out, ok)
+}
+
 func (a *App) addLog(cmd, output string, ok bool) {
-	a.consoleLog = append(a.consoleLog, ConsoleLine{
+	line := ConsoleLine{
 		Time:    time.Now().Format("15:04:05"),
 		Command: cmd,
 		Output:  output,
 		Ok:      ok,
-	})
-	// Keep last 100 lines
-	if len(a.consoleLog) > 100 {
-		a.consoleLog = a.consoleLog[len(a.consoleLog)-100:]
+	}
+	if !ok {
+		line.Hint = classifyFailure(output).Hint()
+	}
+	a.consoleLog = append(a.consoleLog, line)
+	a.appendConsoleHistory(line)
+	if a.logTo != nil {
+		status := "ok"
+		if !ok {
+			status = "failed"
+		}
+		msg := strings.TrimSpace(line.Time + " [" + status + "] " + cmd + " " + strings.TrimSpace(output))
+		fmt.Fprintln(a.logTo, msg)
+	}
+	// Keep the last 100 lines, or the history's length if longer
+	if n := len(a.consoleLog) - max(100, a.cfg.ConsoleHistory); n > 0 {
+		a.consoleLog = a.consoleLog[n:]
+		a.trimConsoleSearch(n)
 	}
 }
 
@@ -240,10 +638,39 @@ func (a *App) addLog(cmd, output string, ok bool) {
 // Render — full screen redraw
 // ═══════════════════════════════════════════════════════════════════════════════
 
+// contentArea is the row the tab's page starts at and its height, between
+// the header (and quiet hours banner) and the footer.
+func (a *App) contentArea() (y, h int) {
+	y = 3 + a.quietBannerH()
+	footerH := 2
+	if a.cfg.ShowCommands {
+		footerH = 3 // extra line for the equivalent asusctl command
+	}
+	h = a.term.Height() - y - footerH // Leave room for footer
+	if !a.loading {
+		y += a.padY()
+		h -= a.padY()
+	}
+	return y, h
+}
+
+// accent is the active tab's accent colour.
+func (a *App) accent() Color {
+	if c, ok := tabAccents[a.activeTab]; ok && a.cfg.TabAccents {
+		return c
+	}
+	return ColAccent
+}
+
 func (a *App) Render() {
 	t := a.term
 	t.updateSize()
 	t.Clear()
+	t.SetAccent(a.accent())
+	if a.quick {
+		a.renderQuick()
+		return
+	}
 
 	W := t.Width()
 
@@ -251,8 +678,9 @@ func (a *App) Render() {
 	t.FillRect(0, 0, W, t.Height(), ColBg)
 
 	// ─── Header ──────────────────────────────────────────────────────────
+	headerBg := a.headerBg()
 	t.ResetStyle()
-	t.Bg(ColPanel)
+	t.Bg(headerBg)
 	t.MoveTo(0, 0)
 	t.Write(rep(" ", W))
 
@@ -264,7 +692,7 @@ func (a *App) Render() {
 	t.Write(" R ")
 
 	t.ResetStyle()
-	t.Bg(ColPanel)
+	t.Bg(headerBg)
 	t.Bold()
 	t.Fg(ColText)
 	t.MoveTo(5, 0)
@@ -273,13 +701,22 @@ func (a *App) Render() {
 	// Status indicator (right side)
 	statusStr := "● connected"
 	statusCol := ColSuccess
-	if !a.installed {
+	if _, demo := a.backend.(*MockBackend); demo {
+		statusStr = "● demo mode"
+		statusCol = ColWarning
+	} else if !a.installed {
 		statusStr = "● asusctl not found"
 		statusCol = ColError
 	}
 	t.Fg(statusCol)
 	t.MoveTo(W-len(statusStr)-2, 0)
 	t.Write(statusStr)
+	if a.daemonStatus != "" {
+		d := "asusd " + a.daemonStatus + " · "
+		t.Fg(daemonStatusColor(a.daemonStatus))
+		t.MoveTo(W-len(statusStr)-2-len([]rune(d)), 0)
+		t.Write(d)
+	}
 
 	// ─── Tab bar ─────────────────────────────────────────────────────────
 	t.ResetStyle()
@@ -287,22 +724,56 @@ func (a *App) Render() {
 	t.MoveTo(0, 1)
 	t.Write(rep(" ", W))
 
+	// Labels that don't fit scroll so the active tab stays visible.
+	labels := make([]string, TabCount)
+	widths := make([]int, TabCount)
+	starts := make([]int, TabCount)
 	x := 1
-	for i := 0; i < int(TabCount); i++ {
-		label := fmt.Sprintf(" %s:%s ", tabKeys[i], tabNames[i])
+	for i := range labels {
+		labels[i] = fmt.Sprintf(" %s:%s ", tabKeys[i], tabNames[i])
+		if tabKeys[i] == "" {
+			labels[i] = " " + tabNames[i] + " "
+		}
+		widths[i] = len([]rune(labels[i]))
+		if a.cfg.Lockdown.TabLocked(Tab(i)) {
+			labels[i] += lockGlyph + " "
+			widths[i] += 3
+		}
+		starts[i] = x
+		x += widths[i] + 1
+	}
+	off := 0
+	if end := starts[a.activeTab] + widths[a.activeTab]; end > W-2 {
+		off = end - (W - 2)
+	}
+	for i, label := range labels {
+		lx := starts[i] - off
+		if lx < 1 || lx+widths[i] > W-1 {
+			continue
+		}
 		if Tab(i) == a.activeTab {
 			t.ResetStyle()
 			t.Bold()
-			t.Bg(ColAccent)
+			t.Bg(a.accent())
 			t.Fg(Color{255, 255, 255})
 		} else {
 			t.ResetStyle()
 			t.Bg(ColPanel)
 			t.Fg(ColTextDim)
 		}
-		t.MoveTo(x, 1)
+		t.MoveTo(lx, 1)
 		t.Write(label)
-		x += len(label) + 1
+	}
+	t.ResetStyle()
+	t.Bg(ColPanel)
+	t.Fg(ColTextMut)
+	if off > 0 {
+		t.MoveTo(0, 1)
+		t.Write("‹")
+	}
+	if x-1-off > W-1 {
+		t.MoveTo(W-1, 1)
+		t.Write("›")
 	}
 
 	// ─── Separator ───────────────────────────────────────────────────────
@@ -312,28 +783,53 @@ func (a *App) Render() {
 	t.Write(rep("─", W))
 
 	// ─── Content area ────────────────────────────────────────────────────
-	contentY := 3
-	contentH := t.Height() - 5 // Leave room for footer
-
-	switch a.activeTab {
-	case TabProfile:
+	a.renderQuietBanner(3)
+	contentY, contentH := a.contentArea()
+
+	switch {
+	case a.loading:
+		a.renderLoading(contentY, contentH)
+	case a.activeTab == TabDashboard:
+		a.renderDashboard(contentY, contentH)
+	case a.activeTab == TabProfile:
 		a.renderProfile(contentY, contentH)
-	case TabKeyboard:
+	case a.activeTab == TabKeyboard:
 		a.renderKeyboard(contentY, contentH)
-	case TabAura:
+	case a.activeTab == TabAura:
 		a.renderAura(contentY, contentH)
-	case TabBattery:
+	case a.activeTab == TabBattery:
 		a.renderBattery(contentY, contentH)
-	case TabFans:
+	case a.activeTab == TabFans:
 		a.renderFans(contentY, contentH)
-	case TabBios:
+	case a.activeTab == TabGpu:
+		a.renderGpu(contentY, contentH)
+	case a.activeTab == TabBios:
 		a.renderBios(contentY, contentH)
-	case TabConsole:
+	case a.activeTab == TabSystem:
+		a.renderSystem(contentY, contentH)
+	case a.activeTab == TabConsole:
 		a.renderConsole(contentY, contentH)
+	case a.activeTab == TabLogs:
+		a.renderLogs(contentY, contentH)
+	case a.activeTab == TabMonitor:
+		a.renderMonitor(contentY, contentH)
+	case a.activeTab == TabAutomation:
+		a.renderAutomation(contentY, contentH)
+	case a.activeTab == TabSlash:
+		a.renderSlash(contentY, contentH)
+	case a.activeTab == TabScenes:
+		a.renderScenes(contentY, contentH)
+	case a.activeTab == TabDisplay:
+		a.renderDisplay(contentY, contentH)
+	case a.activeTab == TabSettings:
+		a.renderSettings(contentY, contentH)
+	}
+	if !a.loading {
+		a.renderLockNotice(contentY - a.padY())
 	}
 
 	// ─── Footer / status bar ─────────────────────────────────────────────
-	footerY := t.Height() - 2
+	footerY := contentY + contentH
 
 	t.ResetStyle()
 	t.Fg(ColBorder)
@@ -343,6 +839,15 @@ func (a *App) Render() {
 	t.Fg(ColTextMut)
 	t.MoveTo(W-len(ver)-1, footerY)
 	t.Write(ver)
+	if n := a.queue.Pending(); n > 0 {
+		busy := " ⟳ applying… "
+		if n > 1 {
+			busy = fmt.Sprintf(" ⟳ applying… +%d queued ", n-1)
+		}
+		t.Fg(ColWarning)
+		t.MoveTo(1, footerY)
+		t.Write(busy)
+	}
 
 	t.ResetStyle()
 	t.Bg(ColPanel)
@@ -350,29 +855,105 @@ func (a *App) Render() {
 	t.Write(rep(" ", W))
 
 	// Help text
-	t.Fg(ColTextDim)
-	t.MoveTo(1, footerY+1)
-	t.Write("1-7:Tab  ↑↓:Navigate  ←→:Adjust  Enter:Apply  q:Quit")
-
-	// Status message (right side)
-	if a.statusMsg != "" && time.Since(a.statusTime) < 4*time.Second {
+	firstKey, lastKey := "", ""
+	for _, k := range tabKeys {
+		if k != "" {
+			lastKey = k
+			if firstKey == "" {
+				firstKey = k
+			}
+		}
+	}
+	help := fmt.Sprintf("%s-%s [ ]:Tab  ↑↓:Navigate  ←→:Adjust  Enter:Apply  q:Quit", firstKey, lastKey)
+	helpW := len([]rune(help))
+
+	// Status message (right side). Long messages such as error hints take
+	// over the whole line instead of being cut off.
+	showStatus := a.statusMsg != "" && time.Since(a.statusTime) < 4*time.Second
+	msg := []rune(a.statusMsg)
+	wide := showStatus && len(msg) > W-helpW-5
+	if !wide {
+		t.Fg(ColTextDim)
+		t.MoveTo(1, footerY+1)
+		t.Write(help)
+	}
+	if showStatus {
 		sc := ColSuccess
 		if !a.statusOk {
 			sc = ColError
 		}
-		msg := a.statusMsg
-		if len(msg) > 40 {
-			msg = msg[:39] + "…"
+		x := W - len(msg) - 2
+		if wide {
+			if len(msg) > W-3 {
+				msg = append(msg[:W-4], '…')
+			}
+			x = 1
 		}
 		t.Fg(sc)
-		t.MoveTo(W-len(msg)-2, footerY+1)
-		t.Write(msg)
+		t.MoveTo(x, footerY+1)
+		t.Write(string(msg))
+	}
+
+	if a.cfg.ShowCommands {
+		t.ResetStyle()
+		t.Bg(ColPanel)
+		t.MoveTo(0, footerY+2)
+		t.Write(rep(" ", W))
+		t.Fg(ColTextMut)
+		t.MoveTo(1, footerY+2)
+		if a.lastCommand != "" {
+			t.Write(pad("$ "+a.lastCommand, W-2))
+		} else {
+			t.Write("$ (the asusctl command for each action appears here)")
+		}
+	}
+
+	if a.changes != nil {
+		a.renderChanges()
+	}
+	if a.picker != nil {
+		a.renderColourPicker()
+	}
+	if a.perKey != nil {
+		a.renderPerKey()
+	}
+	if a.commands != nil {
+		a.renderCommands()
+	}
+	if a.confirm != nil {
+		a.renderConfirm()
+	} else if a.prompt != nil {
+		a.renderPrompt()
 	}
 
 	t.ResetStyle()
 	t.Flush()
 }
 
+// renderLoading is the startup splash: the probes still running and a bar
+// for how many have finished.
+func (a *App) renderLoading(y, h int) {
+	t := a.term
+	w := 40
+	x := (t.Width() - w) / 2
+	top := y + max((h-len(startupProbes)-4)/2, 0)
+
+	t.TextBold(x, top, ColText, "Reading hardware state…")
+	t.DrawBar(x, top+1, w, float64(len(a.loaded))/float64(len(startupProbes)), a.accent(), ColInput)
+	for i, p := range startupProbes {
+		row := top + 3 + i/2
+		col := x + (i%2)*(w/2)
+		if row >= y+h {
+			break
+		}
+		if a.loaded[p] {
+			t.Text(col, row, ColSuccess, "✓ "+p)
+		} else {
+			t.Text(col, row, ColTextMut, "· "+p)
+		}
+	}
+}
+
 // ═══════════════════════════════════════════════════════════════════════════════
 // Page: Profile
 // ═══════════════════════════════════════════════════════════════════════════════
@@ -380,24 +961,23 @@ func (a *App) Render() {
 func (a *App) renderProfile(y, h int) {
 	t := a.term
 	W := t.Width()
-	cx := 3 // content x offset
+	cx := a.padX() // content x offset
 
 	t.TextBold(cx, y+1, ColText, "Power Profile")
-	t.Text(cx, y+2, ColTextDim, "Select a performance mode for your laptop")
+	sub := "Select a performance mode for your laptop"
+	if a.profileSource != "" && a.profileSource != "asusd" {
+		sub += " (via " + a.profileSource + ")"
+	}
+	t.Text(cx, y+2, ColTextDim, sub)
 
-	profiles := []struct {
-		name  string
-		icon  string
-		desc  string
-		color Color
-	}{
-		{"Performance", "⚡", "Maximum clocks, aggressive fans", ColPerf},
-		{"Balanced", "⚖", "Auto-tuned balance of speed & efficiency", ColBal},
-		{"Quiet", "🔇", "Minimal fan noise, power saving", ColQuiet},
+	if a.cfg.ProfileLayout == "compact" {
+		a.renderProfileCompact(y, h)
+		return
 	}
 
-	for i, p := range profiles {
-		row := y + 4 + i*3
+	pitch := max(a.spread(3), 2) // a card is two rows
+	for i, p := range profileCards {
+		row := y + 4 + i*pitch
 		selected := a.profile == p.name
 		focused := a.focusIdx == i
 
@@ -413,7 +993,7 @@ func (a *App) renderProfile(y, h int) {
 			t.Bold()
 			t.MoveTo(cx+1, row)
 			if focused {
-				t.Write("▸ ")
+				a.writeFocused("▸ ")
 			} else {
 				t.Write("● ")
 			}
@@ -437,7 +1017,7 @@ func (a *App) renderProfile(y, h int) {
 			if focused {
 				t.Fg(ColText)
 				t.MoveTo(cx+1, row)
-				t.Write("▸ " + p.icon + " " + p.name)
+				a.writeFocused("▸ " + p.icon + " " + p.name)
 			} else {
 				t.Fg(ColTextDim)
 				t.MoveTo(cx+1, row)
@@ -450,29 +1030,145 @@ func (a *App) renderProfile(y, h int) {
 	}
 
 	t.ResetStyle()
-	t.Fg(ColTextMut)
-	t.MoveTo(cx, y+4+9+1)
-	t.Write("Press Enter to switch profile, or ↑/↓ to navigate")
+	row := a.renderPowerLimits(cx, y+4+len(profileCards)*pitch+1)
+	t.Text(cx, row, ColTextMut, "Enter switch profile / write limit  ↑↓ navigate  ←→ PgUp/PgDn adjust")
+}
+
+// renderProfileCompact is the profile_layout = "compact" Profile tab: one
+// line per profile, then the live readings the cards leave no room for.
+func (a *App) renderProfileCompact(y, h int) {
+	t := a.term
+	W := t.Width()
+	cx := a.padX()
+	w := min(W-6, 72)
+
+	row := y + 4
+	for i, p := range profileCards {
+		selected := a.profile == p.name
+		marker := "  "
+		switch {
+		case a.focusIdx == i:
+			marker = "▸ "
+		case selected:
+			marker = "● "
+		}
+		line := marker + p.icon + " " + pad(p.name, 12)
+		t.ResetStyle()
+		switch {
+		case selected:
+			t.TextBold(cx+w-8, row, p.color, "ACTIVE")
+			t.Bold()
+			t.Fg(p.color)
+		case a.focusIdx == i:
+			t.Fg(ColText)
+		default:
+			t.Fg(ColTextDim)
+		}
+		t.MoveTo(cx+1, row)
+		a.writeFocused(line)
+		t.Text(cx+19, row, ColTextMut, p.desc)
+		row++
+	}
+
+	row = a.renderPowerLimits(cx, row+1)
+
+	// ─── Live readings ───
+	t.HLine(cx, row, w, ColBorder)
+	row++
+	s := a.sensors
+	bat := a.planner.battery
+	power := "—"
+	if bat.Present {
+		power = fmt.Sprintf("%d%%", bat.Percent)
+		if bat.PowerW > 0 {
+			dir := "draw"
+			if bat.Status == "Charging" {
+				dir = "charging"
+			}
+			power += fmt.Sprintf(" · %.1f W %s", bat.PowerW, dir)
+		}
+	}
+	readings := [][2]string{
+		{"CPU", formatTemp(s.CPUTempC)},
+		{"GPU", formatTemp(s.GPUTempC)},
+		{"CPU fan", a.formatFanLive("cpu")},
+		{"GPU fan", a.formatFanLive("gpu")},
+	}
+	if a.fanMid {
+		readings = append(readings, [2]string{"Mid fan", a.formatFanLive("mid")})
+	}
+	for _, kv := range append(readings, [2]string{"Battery", power}) {
+		if row >= y+h-2 {
+			break
+		}
+		t.Text(cx+1, row, ColTextDim, kv[0])
+		t.Text(cx+11, row, ColText, kv[1])
+		row++
+	}
+
+	t.Text(cx, min(row+1, y+h-1), ColTextMut, "Enter switch profile / write limit  ↑↓ navigate  ←→ PgUp/PgDn adjust")
 }
 
 func (a *App) handleProfile(key KeyEvent) {
+	rows := len(profileNames) + len(a.ppt.attrs)
 	switch key.Type {
-	case KeyUp:
-		a.focusIdx = (a.focusIdx + 2) % 3
-	case KeyDown:
-		a.focusIdx = (a.focusIdx + 1) % 3
-	case KeyEnter:
-		profiles := []string{"Performance", "Balanced", "Quiet"}
-		p := profiles[a.focusIdx]
-		ok, out := a.backend.SetProfile(p)
+	case KeyUp, KeyDown:
+		if key.Type == KeyUp {
+			a.focusIdx = (a.focusIdx + rows - 1) % rows
+		} else {
+			a.focusIdx = (a.focusIdx + 1) % rows
+		}
+		if a.cfg.ApplyOnSelect && a.focusIdx < len(profileNames) {
+			a.selectProfile(profileNames[a.focusIdx])
+		}
+	default:
+		if i := a.focusIdx - len(profileNames); i >= 0 {
+			a.handlePowerLimit(i, key)
+		} else if key.Type == KeyEnter {
+			a.selectProfile(profileNames[a.focusIdx])
+		}
+	}
+}
+
+// profileNames are the asusd platform profiles in Profile tab order.
+var profileNames = []string{"Performance", "Balanced", "Quiet"}
+
+// profileCards is how the Profile tab shows each of profileNames.
+var profileCards = []struct {
+	name  string
+	icon  string
+	desc  string
+	color Color
+}{
+	{"Performance", "⚡", "Maximum clocks, aggressive fans", ColPerf},
+	{"Balanced", "⚖", "Auto-tuned balance of speed & efficiency", ColBal},
+	{"Quiet", "🔇", "Minimal fan noise, power saving", ColQuiet},
+}
+
+// selectProfile switches the power profile, unless locked or quiet hours
+// hold it.
+func (a *App) selectProfile(p string) {
+	if a.locked("profile") {
+		return
+	}
+	if a.quiet.active && p != quietProfile {
+		a.SetStatus("Quiet hours until "+a.cfg.QuietHours.End+" — Ctrl-O to override", false)
+		return
+	}
+	a.submit("profile", func(b Backend) (bool, string) {
+		return b.SetProfile(p)
+	}, func(ok bool, out string, argv []string) {
 		if ok {
 			a.profile = p
+			a.loadFanCurves() // curves are per profile
+			a.readPpt()
 			a.SetStatus("Profile → "+p, true)
+			a.followProfile()
 		} else {
-			a.SetStatus("Failed: "+out, false)
+			a.SetError(out)
 		}
-		a.addLog("profile --profile-set "+p, out, ok)
-	}
+		a.logCommand(argv, out, ok)
+	})
 }
 
 // ═══════════════════════════════════════════════════════════════════════════════
@@ -481,13 +1177,14 @@ func (a *App) handleProfile(key KeyEvent) {
 
 func (a *App) renderKeyboard(y, h int) {
 	t := a.term
-	cx := 3
+	cx := a.padX()
 
 	t.TextBold(cx, y+1, ColText, "Keyboard Backlight")
 	t.Text(cx, y+2, ColTextDim, "Adjust keyboard backlight brightness level")
 
+	pitch := a.spread(2)
 	for i, label := range kbdLabels {
-		row := y + 4 + i*2
+		row := y + 4 + i*pitch
 		selected := a.kbdLevel == i
 		focused := a.focusIdx == i
 
@@ -497,14 +1194,14 @@ func (a *App) renderKeyboard(y, h int) {
 		if selected {
 			t.ResetStyle()
 			t.Bold()
-			t.Fg(ColAccent)
+			t.Fg(a.accent())
 			t.MoveTo(cx+1, row)
 			if focused {
-				t.Write("▸ ● " + label)
+				a.writeFocused("▸ ● " + label)
 			} else {
 				t.Write("  ● " + label)
 			}
-			t.Fg(ColAccent)
+			t.Fg(a.accent())
 			t.MoveTo(cx+14, row)
 			t.Write(rep("█", barLen))
 			t.Fg(ColTextMut)
@@ -518,7 +1215,7 @@ func (a *App) renderKeyboard(y, h int) {
 			if focused {
 				t.Fg(ColText)
 				t.MoveTo(cx+1, row)
-				t.Write("▸ ○ " + label)
+				a.writeFocused("▸ ○ " + label)
 			} else {
 				t.Fg(ColTextDim)
 				t.MoveTo(cx+1, row)
@@ -530,55 +1227,185 @@ func (a *App) renderKeyboard(y, h int) {
 		}
 	}
 
-	t.Text(cx, y+13, ColTextMut, "Enter to set brightness")
-}
-
-func (a *App) handleKeyboard(key KeyEvent) {
-	switch key.Type {
-	case KeyUp:
-		a.focusIdx = (a.focusIdx + 3) % 4
-	case KeyDown:
-		a.focusIdx = (a.focusIdx + 1) % 4
-	case KeyEnter:
-		ok, out := a.backend.SetKbdBrightness(kbdValues[a.focusIdx])
-		if ok {
-			a.kbdLevel = a.focusIdx
-			a.SetStatus("Keyboard → "+kbdLabels[a.focusIdx], true)
+	// Extra toggles below the brightness levels
+	for i, id := range a.keyboardRows() {
+		row := y + 4 + len(kbdLabels)*pitch + i
+		label, on, writable := "", false, true
+		switch id {
+		case kbdRowTouchpad:
+			label, on, writable = "Touchpad", a.touchpad.Enabled, a.touchpad.Writable
+		case kbdRowGameMode:
+			label, on = "Game Mode", a.gameMode != nil
+		case kbdRowSuper:
+			label, on = "Disable Super key in game mode", a.cfg.GameMode.DisableSuper
+		default:
+			led := a.auraPower[id-kbdRowLed]
+			label, on = led.Label, led.On
+		}
+		if a.focusIdx == len(kbdValues)+i {
+			a.focusText(cx+1, row, "▸ "+label)
+		} else {
+			t.Text(cx+1, row, ColTextDim, "  "+label)
+		}
+		if writable {
+			t.DrawToggle(cx+34, row, on)
 		} else {
-			a.SetStatus("Failed: "+out, false)
+			st := "○ OFF"
+			if on {
+				st = "● ON"
+			}
+			t.Text(cx+35, row, ColTextMut, st+"  (read-only)")
 		}
-		a.addLog("--kbd-bright "+kbdValues[a.focusIdx], out, ok)
 	}
-}
-
-// ═══════════════════════════════════════════════════════════════════════════════
-// Page: Aura RGB
-// ═══════════════════════════════════════════════════════════════════════════════
+	row := y + 4 + len(kbdLabels)*pitch + len(a.keyboardRows())
+	rog := "unchanged"
+	if a.cfg.GameMode.RogKeyCommand != "" {
+		rog = "runs " + a.cfg.GameMode.RogKeyCommand
+	}
+	t.Text(cx+3, row, ColTextMut, "ROG key in game mode: "+rog)
 
-func (a *App) renderAura(y, h int) {
-	t := a.term
-	W := t.Width()
-	cx := 3
+	t.Text(cx, row+2, ColTextMut, "Enter set / toggle  │  t touchpad  │  g game mode")
+}
 
-	t.TextBold(cx, y+1, ColAura, "Aura RGB Lighting")
-	t.Text(cx, y+2, ColTextDim, "Choose effect, colour, and speed")
+// Rows shown under the brightness levels, in order.
+const (
+	kbdRowTouchpad = iota
+	kbdRowGameMode
+	kbdRowSuper
+	kbdRowLed // + index into auraPower, one row per LED group; see aura_power.go
+)
 
-	cols := 3
-	if W > 80 {
-		cols = 4
+func (a *App) keyboardRows() []int {
+	var rows []int
+	if a.touchpad.Present {
+		rows = append(rows, kbdRowTouchpad)
 	}
+	for i := range a.auraPower {
+		rows = append(rows, kbdRowLed+i)
+	}
+	return append(rows, kbdRowGameMode, kbdRowSuper)
+}
+
+// toggleTouchpad flips the touchpad through asus-wmi or input inhibit.
+func (a *App) toggleTouchpad() {
+	if !a.touchpad.Present {
+		a.SetStatus("No touchpad toggle found on this laptop", false)
+		return
+	}
+	if !a.touchpad.Writable {
+		a.SetStatus("Touchpad toggle is read-only here (needs root or a udev rule)", false)
+		return
+	}
+	on := !a.touchpad.Enabled
+	st := "OFF"
+	if on {
+		st = "ON"
+	}
+	a.submit("touchpad", func(b Backend) (bool, string) {
+		return b.SetTouchpad(on)
+	}, func(ok bool, out string, _ []string) {
+		a.addLog("sysfs touchpad → "+st, out, ok)
+		if !ok {
+			a.SetError(out)
+			return
+		}
+		a.touchpad.Enabled = on
+		a.SetStatus("Touchpad → "+st, true)
+	})
+}
+
+func (a *App) handleKeyboard(key KeyEvent) {
+	extra := a.keyboardRows()
+	rows := len(kbdValues) + len(extra)
+	switch key.Type {
+	case KeyUp, KeyDown:
+		if key.Type == KeyUp {
+			a.focusIdx = (a.focusIdx + rows - 1) % rows
+		} else {
+			a.focusIdx = (a.focusIdx + 1) % rows
+		}
+		if a.cfg.ApplyOnSelect && a.focusIdx < len(kbdValues) {
+			a.setKbdLevel(a.focusIdx)
+		}
+	case KeyChar:
+		switch key.Char {
+		case 't':
+			a.toggleTouchpad()
+		case 'g':
+			a.setGameMode(a.gameMode == nil)
+		}
+	case KeyEnter:
+		if i := a.focusIdx - len(kbdValues); i >= 0 {
+			switch extra[i] {
+			case kbdRowTouchpad:
+				a.toggleTouchpad()
+			case kbdRowGameMode:
+				a.setGameMode(a.gameMode == nil)
+			case kbdRowSuper:
+				a.toggleDisableSuper()
+			default:
+				a.toggleAuraPower(extra[i] - kbdRowLed)
+			}
+			return
+		}
+		a.setKbdLevel(a.focusIdx)
+	}
+}
+
+func (a *App) setKbdLevel(level int) {
+	a.submit("kbd", func(b Backend) (bool, string) {
+		return b.SetKbdBrightness(kbdValues[level])
+	}, func(ok bool, out string, argv []string) {
+		if ok {
+			a.kbdLevel = level
+			if level > 0 {
+				a.lightsOut = nil // lit by hand; l turns it off again
+			}
+			a.SetStatus("Keyboard → "+kbdLabels[level], true)
+		} else {
+			a.SetError(out)
+		}
+		a.logCommand(argv, out, ok)
+	})
+}
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// Page: Aura RGB
+// ═══════════════════════════════════════════════════════════════════════════════
+
+func (a *App) renderAura(y, h int) {
+	t := a.term
+	W := t.Width()
+	cx := a.padX()
+
+	t.TextBold(cx, y+1, ColAura, "Aura RGB Lighting")
+	sub := "Choose effect, colour, and speed"
+	if n := len(auraModes) - len(a.auraSupported); a.auraSupported != nil && n > 0 {
+		sub += fmt.Sprintf(" · %d greyed out: not supported by this keyboard", n)
+	}
+	t.Text(cx, y+2, ColTextDim, sub)
+
+	cols := 3
+	if W > 80 {
+		cols = 4
+	}
+
+	// ─── Mode grid ───
+	pitch := a.spread(2)
+	for pos, i := range a.auraGrid() {
+		col := pos % cols
+		row := pos / cols
+		px := cx + col*18
+		py := y + 4 + row*pitch
+
+		selected := a.auraMode == i
+		focused := a.auraSection == 0 && a.focusIdx == pos
 
-	// ─── Mode grid ───
-	for i, mode := range auraModes {
-		col := i % cols
-		row := i / cols
-		px := cx + col*18
-		py := y + 4 + row*2
-
-		selected := a.auraMode == i
-		focused := a.auraSection == 0 && a.focusIdx == i
-
 		w := 16
+		mode := auraModes[i]
+		if a.auraPinned(i) {
+			mode += " ★"
+		}
 		label := center(mode, w)
 
 		if selected {
@@ -588,90 +1415,56 @@ func (a *App) renderAura(y, h int) {
 			t.Bold()
 			t.MoveTo(px, py)
 			if focused {
-				t.Write("▸" + label)
+				a.writeFocused("▸" + label)
 			} else {
 				t.Write(" " + label)
 			}
 		} else if focused {
 			t.ResetStyle()
 			t.Fg(ColText)
+			if !a.auraModeOK(i) {
+				t.Fg(ColTextMut)
+			}
 			t.MoveTo(px, py)
-			t.Write("▸" + pad(mode, w))
+			a.writeFocused("▸" + pad(mode, w))
 		} else {
 			t.ResetStyle()
 			t.Fg(ColTextDim)
+			if !a.auraModeOK(i) {
+				t.Fg(ColTextMut)
+			}
 			t.MoveTo(px, py)
 			t.Write(" " + pad(mode, w))
 		}
 	}
 
 	modeRows := (len(auraModes)-1)/cols + 1
-	sectionY := y + 4 + modeRows*2 + 1
+	sectionY := y + 4 + modeRows*pitch + 1
 	curMode := auraModes[a.auraMode]
 
+	// ─── Preview ───
+	a.renderAuraPreview(sectionY)
+	sectionY += 2
+
 	// ─── Colour 1 ───
 	if auraEffectNeedsColour1(curMode) {
 		t.Text(cx, sectionY, ColTextDim, "Colour:")
-		for i, c := range auraColours {
-			px := cx + 9 + i*4
-			focused := a.auraSection == 1 && a.focusIdx == i
-			selected := a.auraColour1 == i
-			t.ResetStyle()
-			t.Bg(c.Rgb)
-			if focused {
-				t.Fg(Color{0, 0, 0})
-				t.Bold()
-				t.MoveTo(px, sectionY)
-				if selected {
-					t.Write("▸◆ ")
-				} else {
-					t.Write("▸  ")
-				}
-			} else {
-				t.MoveTo(px, sectionY)
-				if selected {
-					t.Fg(Color{0, 0, 0})
-					t.Bold()
-					t.Write(" ◆ ")
-				} else {
-					t.Write("   ")
-				}
-			}
-		}
-		t.ResetStyle()
+		a.renderSwatches(0, sectionY)
+		a.renderColourName(0, sectionY)
+		sectionY += 2
+	}
+
+	// ─── Zones ───
+	if a.auraZoneRow() {
+		a.renderAuraZones(sectionY)
 		sectionY += 2
 	}
 
 	// ─── Colour 2 ───
 	if auraEffectNeedsColour2(curMode) {
 		t.Text(cx, sectionY, ColTextDim, "Colour2:")
-		for i, c := range auraColours {
-			px := cx + 9 + i*4
-			focused := a.auraSection == 2 && a.focusIdx == i
-			selected := a.auraColour2 == i
-			t.ResetStyle()
-			t.Bg(c.Rgb)
-			if focused {
-				t.Fg(Color{0, 0, 0})
-				t.Bold()
-				t.MoveTo(px, sectionY)
-				if selected {
-					t.Write("▸◆ ")
-				} else {
-					t.Write("▸  ")
-				}
-			} else {
-				t.MoveTo(px, sectionY)
-				if selected {
-					t.Fg(Color{0, 0, 0})
-					t.Bold()
-					t.Write(" ◆ ")
-				} else {
-					t.Write("   ")
-				}
-			}
-		}
-		t.ResetStyle()
+		a.renderSwatches(1, sectionY)
+		a.renderColourName(1, sectionY)
 		sectionY += 2
 	}
 
@@ -689,7 +1482,7 @@ func (a *App) renderAura(y, h int) {
 				t.Bold()
 				t.MoveTo(px, sectionY)
 				if focused {
-					t.Write("▸" + label + " ")
+					a.writeFocused("▸" + label + " ")
 				} else {
 					t.Write(" " + label + " ")
 				}
@@ -697,7 +1490,7 @@ func (a *App) renderAura(y, h int) {
 				t.ResetStyle()
 				t.Fg(ColText)
 				t.MoveTo(px, sectionY)
-				t.Write("▸" + label + " ")
+				a.writeFocused("▸" + label + " ")
 			} else {
 				t.ResetStyle()
 				t.Fg(ColTextDim)
@@ -709,7 +1502,84 @@ func (a *App) renderAura(y, h int) {
 		sectionY += 2
 	}
 
-	t.Text(cx, sectionY, ColTextMut, "Enter to apply  │  ↑/↓ sections  │  ←/→ select")
+	// ─── Direction ───
+	if auraEffectNeedsDirection(curMode) {
+		t.Text(cx, sectionY, ColTextDim, "Direction:")
+		for i, label := range auraDirectionLabels {
+			px := cx + 11 + i*9
+			focused := a.auraSection == 6 && a.focusIdx == i
+			t.ResetStyle()
+			switch {
+			case a.auraDirection == i:
+				t.Bg(ColAura)
+				t.Fg(Color{255, 255, 255})
+				t.Bold()
+			case focused:
+				t.Fg(ColText)
+			default:
+				t.Fg(ColTextDim)
+			}
+			t.MoveTo(px, sectionY)
+			if focused {
+				a.writeFocused("▸" + label + " ")
+			} else {
+				t.Write(" " + label + " ")
+			}
+		}
+		t.ResetStyle()
+		sectionY += 2
+	}
+
+	// ─── Brightness (the Keyboard tab's setting) ───
+	t.Text(cx, sectionY, ColTextDim, "Brightness:")
+	for i, label := range kbdLabels {
+		px := cx + 12 + i*7
+		focused := a.auraSection == 4 && a.focusIdx == i
+		t.ResetStyle()
+		switch {
+		case a.kbdLevel == i:
+			t.Bg(ColAura)
+			t.Fg(Color{255, 255, 255})
+			t.Bold()
+		case focused:
+			t.Fg(ColText)
+		default:
+			t.Fg(ColTextDim)
+		}
+		t.MoveTo(px, sectionY)
+		if focused {
+			a.writeFocused("▸" + label + " ")
+		} else {
+			t.Write(" " + label + " ")
+		}
+	}
+	t.ResetStyle()
+	sectionY += 2
+
+	if len(a.cfg.AuraPresets) > 0 {
+		t.Text(cx, sectionY, ColTextDim, "Favourites:")
+		px := cx + 12
+		for _, p := range a.cfg.AuraPresets {
+			if strings.EqualFold(p.Name, a.auraPreset) {
+				t.TextBold(px, sectionY, a.accent(), "● "+p.Name)
+			} else {
+				t.Text(px, sectionY, ColTextMut, p.Name)
+				px -= 2
+			}
+			px += len([]rune(p.Name)) + 5
+		}
+		sectionY += 2
+	}
+
+	t.Text(cx, sectionY, ColTextMut, "Enter select  │  a apply  │  ↑/↓ sections  │  ←/→ move  │  c hex / h pick colour  │  +/- palette  │  p save favourite  │  f next favourite  │  e export / i import  │  * pin effect")
+	if a.auraDirty && !a.auraApplyQueued {
+		t.TextBold(cx, sectionY+1, ColWarning, "● Unapplied changes — press a to apply")
+	}
+
+	// ─── Keyboard view, when there is room ───
+	if sectionY+3+len(keySketchRows) <= y+h {
+		a.renderAuraKeyboard(sectionY + 2)
+	}
 }
 
 // auraSections returns which sections are active for the current mode
@@ -719,13 +1589,19 @@ func (a *App) auraSections() []int {
 	if auraEffectNeedsColour1(mode) {
 		sections = append(sections, 1)
 	}
+	if a.auraZoneRow() {
+		sections = append(sections, 5)
+	}
 	if auraEffectNeedsColour2(mode) {
 		sections = append(sections, 2)
 	}
 	if auraEffectNeedsSpeed(mode) {
 		sections = append(sections, 3)
 	}
-	return sections
+	if auraEffectNeedsDirection(mode) {
+		sections = append(sections, 6)
+	}
+	return append(sections, 4) // keyboard brightness, always present
 }
 
 func (a *App) auraClampSection() {
@@ -739,7 +1615,7 @@ func (a *App) auraClampSection() {
 	}
 	if !found {
 		a.auraSection = 0
-		a.focusIdx = a.auraMode
+		a.focusIdx = a.auraGridPos(a.auraMode)
 	}
 }
 
@@ -760,17 +1636,7 @@ func (a *App) handleAura(key KeyEvent) {
 			}
 		}
 		if cur > 0 {
-			a.auraSection = sections[cur-1]
-			switch a.auraSection {
-			case 0:
-				a.focusIdx = a.auraMode
-			case 1:
-				a.focusIdx = a.auraColour1
-			case 2:
-				a.focusIdx = a.auraColour2
-			case 3:
-				a.focusIdx = a.auraSpeed
-			}
+			a.auraEnterSection(sections[cur-1])
 		} else if a.auraSection == 0 {
 			// Navigate within mode grid
 			a.focusIdx -= cols
@@ -797,84 +1663,285 @@ func (a *App) handleAura(key KeyEvent) {
 				a.focusIdx = next
 			} else if cur < len(sections)-1 {
 				// Move to next section
-				a.auraSection = sections[cur+1]
-				switch a.auraSection {
-				case 1:
-					a.focusIdx = a.auraColour1
-				case 2:
-					a.focusIdx = a.auraColour2
-				case 3:
-					a.focusIdx = a.auraSpeed
-				}
+				a.auraEnterSection(sections[cur+1])
 			}
 		} else if cur < len(sections)-1 {
-			a.auraSection = sections[cur+1]
-			switch a.auraSection {
-			case 1:
-				a.focusIdx = a.auraColour1
-			case 2:
-				a.focusIdx = a.auraColour2
-			case 3:
-				a.focusIdx = a.auraSpeed
-			}
+			a.auraEnterSection(sections[cur+1])
 		}
 	case KeyLeft:
 		switch a.auraSection {
 		case 0:
 			a.focusIdx = (a.focusIdx + len(auraModes) - 1) % len(auraModes)
-		case 1:
-			a.focusIdx = (a.focusIdx + len(auraColours) - 1) % len(auraColours)
-		case 2:
-			a.focusIdx = (a.focusIdx + len(auraColours) - 1) % len(auraColours)
+		case 1, 2:
+			n := len(a.auraPalette(a.auraSection - 1))
+			a.focusIdx = (a.focusIdx + n - 1) % n
 		case 3:
 			a.focusIdx = (a.focusIdx + len(auraSpeeds) - 1) % len(auraSpeeds)
+		case 6:
+			a.focusIdx = (a.focusIdx + len(auraDirections) - 1) % len(auraDirections)
+		case 4, 5:
+			a.focusIdx = max(a.focusIdx-1, 0)
 		}
 	case KeyRight:
 		switch a.auraSection {
 		case 0:
 			a.focusIdx = (a.focusIdx + 1) % len(auraModes)
-		case 1:
-			a.focusIdx = (a.focusIdx + 1) % len(auraColours)
-		case 2:
-			a.focusIdx = (a.focusIdx + 1) % len(auraColours)
+		case 1, 2:
+			a.focusIdx = (a.focusIdx + 1) % len(a.auraPalette(a.auraSection-1))
 		case 3:
 			a.focusIdx = (a.focusIdx + 1) % len(auraSpeeds)
+		case 6:
+			a.focusIdx = (a.focusIdx + 1) % len(auraDirections)
+		case 4:
+			a.focusIdx = min(a.focusIdx+1, len(kbdValues)-1)
+		case 5:
+			a.focusIdx = min(a.focusIdx+1, a.auraZones)
 		}
 	case KeyEnter:
+		// Enter only selects; hardware is touched by the explicit apply key.
 		switch a.auraSection {
 		case 0:
-			a.auraMode = a.focusIdx
+			i := a.auraGrid()[a.focusIdx]
+			if !a.auraModeOK(i) {
+				a.SetStatus(auraModes[i]+" is not supported by this keyboard", false)
+				return
+			}
+			a.auraMode = i
 			a.auraClampSection()
+			// Move into the first configuration section of this effect
+			if sections := a.auraSections(); len(sections) > 1 {
+				a.auraEnterSection(sections[1])
+			}
 		case 1:
 			a.auraColour1 = a.focusIdx
 		case 2:
 			a.auraColour2 = a.focusIdx
 		case 3:
 			a.auraSpeed = a.focusIdx
+		case 4:
+			// brightness is its own setting, applied at once as on the
+			// Keyboard tab
+			a.setKbdLevel(a.focusIdx)
+			return
+		case 5:
+			a.selectAuraZone(a.focusIdx)
+		case 6:
+			a.auraDirection = a.focusIdx
+		}
+		a.auraEdited()
+	case KeyChar:
+		switch key.Char {
+		case 'a':
+			a.applyAura()
+		case 'p':
+			a.promptAuraPreset()
+		case 'f':
+			a.nextAuraPreset()
+		case '*':
+			if a.auraSection != 0 {
+				a.SetStatus("Move to the effects first", false)
+				return
+			}
+			a.togglePinnedEffect()
+		case 'e':
+			a.promptAuraExport()
+		case 'i':
+			a.promptAuraImport()
+		case 'k':
+			a.openPerKey()
+		case 'c', 'h', '+', '-':
+			n := a.auraSection - 1
+			switch {
+			case n != 0 && n != 1:
+				a.SetStatus("Move to a colour row first", false)
+			case key.Char == 'c':
+				a.promptAuraColour(n)
+			case key.Char == 'h':
+				a.openColourPicker(n)
+			case key.Char == '+':
+				a.promptPaletteColour(n)
+			default:
+				a.deletePaletteColour(n)
+			}
 		}
-		// Apply the effect
-		mode := auraModes[a.auraMode]
-		colour1 := ""
-		colour2 := ""
-		speed := ""
-		if auraEffectNeedsColour1(mode) {
-			colour1 = auraColours[a.auraColour1].Hex
+	}
+	switch key.Type {
+	case KeyUp, KeyDown, KeyLeft, KeyRight:
+		if a.cfg.ApplyOnSelect {
+			a.auraSelectOnMove()
 		}
-		if auraEffectNeedsColour2(mode) {
-			colour2 = auraColours[a.auraColour2].Hex
+	}
+}
+
+// auraSelectOnMove is apply_on_select on the Aura tab: the focused item is
+// selected as Enter would, and the effect is applied once the selection has
+// rested for auraApplyDelay, so browsing effects sends one write. The
+// brightness row applies at once, as on the Keyboard tab.
+func (a *App) auraSelectOnMove() {
+	var sel *int
+	val := a.focusIdx
+	switch a.auraSection {
+	case 0:
+		val = a.auraGrid()[a.focusIdx]
+		if !a.auraModeOK(val) {
+			return
 		}
-		if auraEffectNeedsSpeed(mode) {
-			speed = auraSpeeds[a.auraSpeed]
+		sel = &a.auraMode
+	case 1:
+		sel = &a.auraColour1
+	case 2:
+		sel = &a.auraColour2
+	case 3:
+		sel = &a.auraSpeed
+	case 6:
+		sel = &a.auraDirection
+	case 4:
+		if a.focusIdx != a.kbdLevel {
+			a.setKbdLevel(a.focusIdx)
 		}
-		ok, out := a.backend.SetAuraMode(mode, colour1, colour2, speed)
-		if ok {
+		return
+	default:
+		return
+	}
+	if *sel == val {
+		return
+	}
+	*sel = val
+	a.auraDirty, a.auraApplyQueued = true, true
+	a.auraApplyGen++
+	gen := a.auraApplyGen
+	time.AfterFunc(auraApplyDelay, func() {
+		a.Post(func() {
+			if gen == a.auraApplyGen && a.auraDirty {
+				a.applyAura()
+			}
+		})
+	})
+}
+
+// auraEdited marks the selection changed by an edit apply_on_select does
+// not apply (Enter, a typed or picked colour, a favourite, an import), so
+// the tab shows it as unapplied until a.
+func (a *App) auraEdited() {
+	a.auraDirty, a.auraApplyQueued = true, false
+}
+
+// auraEnterSection moves focus to a section, on its currently selected item.
+func (a *App) auraEnterSection(section int) {
+	a.auraSection = section
+	switch section {
+	case 0:
+		a.focusIdx = a.auraGridPos(a.auraMode)
+	case 1:
+		a.focusIdx = a.auraColour1
+	case 2:
+		a.focusIdx = a.auraColour2
+	case 3:
+		a.focusIdx = a.auraSpeed
+	case 4:
+		a.focusIdx = a.kbdLevel
+	case 5:
+		a.focusIdx = 0
+	case 6:
+		a.focusIdx = a.auraDirection
+	}
+}
+
+// auraModeOK reports whether the keyboard supports effect i of auraModes.
+func (a *App) auraModeOK(i int) bool {
+	return a.auraSupported == nil || indexFold(a.auraSupported, auraModes[i]) >= 0
+}
+
+// auraArgs are the SetAuraMode arguments for the selected effect; the
+// options the effect does not use are empty.
+func (a *App) auraArgs() (mode, colour1, colour2, speed, direction string) {
+	mode = auraModes[a.auraMode]
+	if auraEffectNeedsColour1(mode) {
+		colour1 = a.selectedAuraColour(0).Hex
+	}
+	if auraEffectNeedsColour2(mode) {
+		colour2 = a.selectedAuraColour(1).Hex
+	}
+	if auraEffectNeedsSpeed(mode) {
+		speed = auraSpeeds[a.auraSpeed]
+	}
+	if auraEffectNeedsDirection(mode) {
+		direction = auraDirections[a.auraDirection]
+	}
+	return mode, colour1, colour2, speed, direction
+}
+
+// applyAura sends the selected effect, colours, speed and direction to the
+// hardware.
+func (a *App) applyAura() {
+	if a.locked("aura") {
+		return
+	}
+	if !a.auraModeOK(a.auraMode) {
+		a.SetStatus(auraModes[a.auraMode]+" is not supported by this keyboard", false)
+		return
+	}
+	mode, colour1, colour2, speed, direction := a.auraArgs()
+	zones := a.auraZoned()
+	if daemonDown(a.daemonStatus) {
+		if zones {
+			a.SetStatus("Zone colours need asusd running; put all zones on one colour to save the effect", false)
+			return
+		}
+		a.writeAuraOffline(mode, colour1, colour2, speed, direction)
+		return
+	}
+	colours := a.zoneColours()
+	a.submit("aura", func(b Backend) (bool, string) {
+		if zones {
+			return b.SetAuraZones(mode, colours, colour2, speed, direction)
+		}
+		return b.SetAuraMode(mode, colour1, colour2, speed, direction)
+	}, func(ok bool, out string, argv []string) {
+		a.logCommand(argv, out, ok)
+		switch {
+		case ok:
+			a.auraDirty = false
+			a.auraAppliedZones = nil
+			if zones {
+				a.auraAppliedZones = colours
+			}
+			a.readAuraApplied()
 			a.SetStatus("Aura → "+mode, true)
-		} else {
-			a.SetStatus("Failed: "+out, false)
+		case classifyFailure(out).Kind == ErrDaemonDown:
+			a.confirm = &Confirm{
+				Title: "asusd is not running",
+				Lines: []string{
+					"The effect could not be applied now.",
+					"",
+					"Save it to asusd's aura config so it is applied",
+					"when asusd next starts?",
+				},
+				OnYes: func() { a.writeAuraOffline(mode, colour1, colour2, speed, direction) },
+			}
+		default:
+			a.SetError(out)
 		}
-		subcmd := strings.ToLower(strings.ReplaceAll(mode, " ", "-"))
-		a.addLog("aura effect "+subcmd, out, ok)
+	})
+}
+
+// writeAuraOffline saves the effect in asusd's config file while asusd is
+// down, retrying through pkexec when the file is not writable.
+func (a *App) writeAuraOffline(mode, colour1, colour2, speed, direction string) {
+	saved := func() {
+		a.auraDirty = false
+		a.auraAppliedZones = nil
+		a.readAuraApplied()
+		a.SetStatus("Aura → "+mode+" saved; asusd applies it when it starts", true)
+	}
+	ok, out := a.backend.WriteAuraOffline(mode, colour1, colour2, speed, direction)
+	a.logAction(out, ok)
+	if !ok {
+		a.SetError(out)
+		a.offerElevation(out, saved)
+		return
 	}
+	saved()
 }
 
 // ═══════════════════════════════════════════════════════════════════════════════
@@ -884,9 +1951,10 @@ func (a *App) handleAura(key KeyEvent) {
 func (a *App) renderBattery(y, h int) {
 	t := a.term
 	W := t.Width()
-	cx := 3
+	cx := a.padX()
 
 	t.TextBold(cx, y+1, ColText, "Battery & Charging")
+	a.renderBatteryLive(cx+22, y+1)
 
 	// Charge limit slider
 	t.Text(cx, y+3, ColTextDim, "Charge Limit")
@@ -894,16 +1962,8 @@ func (a *App) renderBattery(y, h int) {
 	barW := min(W-20, 50)
 	pct := float64(a.chargeLimit-20) / 80.0
 
-	t.MoveTo(cx, y+5)
-	t.ResetStyle()
-
 	// Draw slider track
-	filled := int(pct * float64(barW))
-	t.Bg(ColAccent)
-	t.Write(rep(" ", filled))
-	t.Bg(ColInput)
-	t.Write(rep(" ", barW-filled))
-	t.ResetStyle()
+	t.DrawBar(cx, y+5, barW, pct, a.accent(), ColInput)
 
 	// Value
 	t.Bold()
@@ -919,9 +1979,9 @@ func (a *App) renderBattery(y, h int) {
 
 	// Focus indicator
 	if a.focusIdx == 0 {
-		t.Fg(ColAccent)
+		t.Fg(a.accent())
 		t.MoveTo(cx-2, y+5)
-		t.Write("▸")
+		a.writeFocused("▸")
 	}
 
 	// Help text
@@ -939,14 +1999,34 @@ func (a *App) renderBattery(y, h int) {
 
 	focused1 := a.focusIdx == 1
 	t.Text(cx, y+16, ColTextDim, "One-Shot Full Charge")
-	t.Text(cx, y+17, ColTextMut, "Temporarily charge to 100% (once)")
+	if a.oneShotCharge {
+		a.renderOneShotProgress(cx, y+17)
+	} else {
+		t.Text(cx, y+17, ColTextMut, "Temporarily charge to 100% (once)")
+	}
 
 	if focused1 {
-		t.TextBold(cx-2, y+16, ColAccent, "▸")
+		t.ResetStyle()
+		t.Bold()
+		t.Fg(a.accent())
+		t.MoveTo(cx-2, y+16)
+		a.writeFocused("▸")
+	}
+
+	if a.oneShotKnown {
+		a.term.DrawToggle(cx+30, y+16, a.oneShotCharge)
+	} else {
+		a.term.DrawButton(cx+30, y+16, "Toggle", focused1, a.accent())
 	}
 
-	t.MoveTo(cx+30, y+16)
-	a.term.DrawButton(cx+30, y+16, "Toggle", focused1, ColAccent)
+	// Runtime planner: beside the controls when wide, below when tall
+	switch {
+	case W >= 120:
+		a.renderPlanner(cx+60, y+3)
+	case h >= 29:
+		t.HLine(cx, y+19, min(W-6, 50), ColBorder)
+		a.renderPlanner(cx, y+20)
+	}
 }
 
 func (a *App) handleBattery(key KeyEvent) {
@@ -965,25 +2045,47 @@ func (a *App) handleBattery(key KeyEvent) {
 		}
 	case KeyEnter:
 		if a.focusIdx == 0 {
-			ok, out := a.backend.SetChargeLimit(a.chargeLimit)
-			if ok {
-				a.SetStatus(fmt.Sprintf("Charge limit → %d%%", a.chargeLimit), true)
-			} else {
-				a.SetStatus("Failed: "+out, false)
-			}
-			a.addLog(fmt.Sprintf("--chg-limit %d", a.chargeLimit), out, ok)
-		} else {
+			a.applyChargeLimit()
+		} else if !a.locked("charge_limit") {
 			ok, out := a.backend.ToggleOneShotCharge()
 			if ok {
-				a.SetStatus("One-shot charge toggled", true)
+				a.oneShotCharge = !a.oneShotCharge
+				if a.oneShotKnown {
+					a.readOneShot()
+				}
+				a.planner.battery = a.backend.GetBatteryStatus()
+				if a.oneShotCharge {
+					a.SetStatus("One-shot charge on — charging to 100% once", true)
+				} else {
+					a.SetStatus("One-shot charge off", true)
+				}
 			} else {
-				a.SetStatus("Failed: "+out, false)
+				a.SetError(out)
 			}
-			a.addLog("--one-shot-chg", out, ok)
+			a.logAction(out, ok)
 		}
 	}
 }
 
+// applyChargeLimit writes a.chargeLimit.
+func (a *App) applyChargeLimit() {
+	if a.locked("charge_limit") {
+		return
+	}
+	limit := a.chargeLimit
+	a.submit("charge_limit", func(b Backend) (bool, string) {
+		return b.SetChargeLimit(limit)
+	}, func(ok bool, out string, argv []string) {
+		if ok {
+			a.chargeLimitSet = limit
+			a.SetStatus(fmt.Sprintf("Charge limit → %d%%", limit), true)
+		} else {
+			a.SetError(out)
+		}
+		a.logCommand(argv, out, ok)
+	})
+}
+
 // ═══════════════════════════════════════════════════════════════════════════════
 // Page: Fans
 // ═══════════════════════════════════════════════════════════════════════════════
@@ -995,26 +2097,71 @@ var fanPresets = map[string][8]int{
 	"full":        {100, 100, 100, 100, 100, 100, 100, 100},
 }
 
+// defaultFanTemps are the curve temperatures shown until asusd's are read.
+var defaultFanTemps = [8]int{30, 40, 50, 60, 70, 80, 90, 100}
+
+// fanNames are the --fan values understood by asusctl, indexed like fanSpeeds.
+// Only some models have the mid (system) fan; see App.fans.
+var fanNames = []string{"cpu", "gpu", "mid"}
+
+// fans are the fanNames of this laptop's fans: the mid fan only when asusd
+// holds a curve for it.
+func (a *App) fans() []string {
+	if a.fanMid {
+		return fanNames
+	}
+	return fanNames[:2]
+}
+
+// fanPresetKeys maps the preset hotkeys to fanPresets entries; the shifted
+// key applies the preset to all fans at once.
+var fanPresetKeys = map[rune]string{
+	's': "silent",
+	'b': "balanced",
+	'p': "performance",
+	'f': "full",
+}
+
+var fanPresetLabels = map[string]string{
+	"silent":      "Silent",
+	"balanced":    "Balanced",
+	"performance": "Performance",
+	"full":        "Full Speed",
+}
+
 func (a *App) renderFans(y, h int) {
 	t := a.term
+	acc := a.accent()
 	W := t.Width()
-	cx := 3
+	cx := a.padX()
 
 	t.TextBold(cx, y+1, ColText, "Fan Curve Editor")
+	if a.fanLoaded {
+		t.Text(cx+18, y+1, ColTextDim, "· "+a.profile+" profile")
+	} else {
+		t.Text(cx+18, y+1, ColWarning, "· defaults shown, asusd's curves for "+a.profile+" could not be read")
+	}
 
 	// Fan selector
-	cpuActive := a.selectedFan == 0
-	gpuActive := a.selectedFan == 1
-
 	t.MoveTo(cx, y+3)
 	t.ResetStyle()
 	t.Write("Fan: ")
-	a.term.DrawButton(cx+5, y+3, "CPU", cpuActive, ColAccent)
-	a.term.DrawButton(cx+13, y+3, "GPU", gpuActive, ColAccent)
+	for i, fan := range a.fans() {
+		a.term.DrawButton(cx+5+i*8, y+3, strings.ToUpper(fan), a.selectedFan == i, acc)
+	}
+	sx := cx + 8*(len(a.fans())-2) // what follows moves over for the mid fan
 
 	// Custom curves toggle
-	a.term.DrawToggle(cx+24, y+3, a.fanEnabled)
-	t.Text(cx+33, y+3, ColTextDim, "Custom curves")
+	a.term.DrawToggle(sx+24, y+3, a.fanEnabled)
+	t.Text(sx+33, y+3, ColTextDim, "Custom curves")
+
+	// Live reading for the selected fan
+	t.Text(sx+50, y+3, ColTextDim, "Now ")
+	t.Text(sx+54, y+3, ColText, a.formatFanLive(fanNames[a.selectedFan]))
+	if fanNames[a.selectedFan] == "gpu" && a.sensors.DGPU.Present {
+		t.Text(sx+50, y+4, ColTextDim, "dGPU ")
+		t.Text(sx+55, y+4, ColText, a.sensors.DGPU.String())
+	}
 
 	// Fan curve ASCII graph
 	graphX := cx + 5
@@ -1022,6 +2169,8 @@ func (a *App) renderFans(y, h int) {
 	graphW := min(W-14, 56)
 	graphH := min(h-12, 12)
 	speeds := a.fanSpeeds[a.selectedFan]
+	temps := a.fanTemps[a.selectedFan]
+	markCol := a.fanMarkerCol(graphW)
 
 	// Y axis labels
 	for row := 0; row <= graphH; row++ {
@@ -1060,11 +2209,11 @@ func (a *App) renderFans(y, h int) {
 						t.ResetStyle()
 						t.Bold()
 						t.Fg(Color{255, 255, 255})
-						t.Bg(ColAccent)
+						t.Bg(acc)
 						t.Write("◆")
 					} else {
 						t.ResetStyle()
-						t.Fg(ColAccent)
+						t.Fg(acc)
 						t.Write("●")
 					}
 					break
@@ -1076,15 +2225,20 @@ func (a *App) renderFans(y, h int) {
 
 			if row == spdRow {
 				t.ResetStyle()
-				t.Fg(ColAccent)
+				t.Fg(acc)
 				t.Write("─")
+			} else if col == markCol {
+				// live temperature, see fan_live.go
+				t.ResetStyle()
+				t.Fg(ColWarning)
+				t.Write("┊")
 			} else if row > spdRow && pct%25 == 0 {
 				t.ResetStyle()
 				t.Fg(ColTextMut)
 				t.Write("┄")
 			} else if row > spdRow {
 				t.ResetStyle()
-				t.Fg(Color{ColAccent.R / 8, ColAccent.G / 8, ColAccent.B / 8})
+				t.Fg(Color{acc.R / 8, acc.G / 8, acc.B / 8})
 				t.Write("░")
 			} else {
 				t.ResetStyle()
@@ -1098,22 +2252,144 @@ func (a *App) renderFans(y, h int) {
 	for p := 0; p < 8; p++ {
 		px := graphX + p*(graphW-1)/7
 		t.MoveTo(px-1, graphY+graphH+1)
-		t.Write(fmt.Sprintf("%d°", a.fanTemps[p]))
+		t.Write(formatTempDeg(temps[p]))
 	}
+	a.renderFanLive(graphX, graphW, graphY+graphH+2)
 
 	// Point value display
 	infoY := graphY + graphH + 3
 	t.Text(cx, infoY, ColTextDim,
-		fmt.Sprintf("Point %d: %d°C → %d%%   (↑↓ speed, ←→ point, Tab fan, Enter apply, e toggle)",
-			a.focusIdx+1, a.fanTemps[a.focusIdx], speeds[a.focusIdx]))
+		fmt.Sprintf("Point %d: %s → %d%%   (↑↓ speed, ←→ point, Tab fan, Enter apply, e toggle)",
+			a.focusIdx+1, formatTemp(float64(temps[a.focusIdx])), speeds[a.focusIdx]))
 
 	// Presets
-	t.Text(cx, infoY+2, ColTextDim, "Presets:  s=Silent  b=Balanced  p=Performance  f=Full")
+	t.Text(cx, infoY+2, ColTextDim, "Presets:  s=Silent  b=Balanced  p=Performance  f=Full   (Shift = apply to all fans)   i=Import")
 
 	// Current data string
 	t.Fg(ColTextMut)
 	t.MoveTo(cx, infoY+3)
-	t.Write("Data: " + FormatFanCurve(a.fanTemps[:], speeds[:]))
+	t.Write("Data: " + FormatFanCurve(temps[:], speeds[:]))
+}
+
+// loadFanCurves reads the active profile's curves from asusd, so an apply
+// never starts from defaults and overwrites a tuned curve unseen.
+func (a *App) loadFanCurves() {
+	fc, err := a.backend.ReadFanCurves(a.profile)
+	a.setFanCurves(fc, err)
+}
+
+// setFanCurves takes curves read from asusd. On error the current values
+// stay and the Fans tab warns that they are not asusd's.
+func (a *App) setFanCurves(fc FanCurves, err error) {
+	if err != nil {
+		a.addLog("fan-curve --mod-profile "+a.profile, err.Error(), false)
+		a.fanLoaded = false
+		return
+	}
+	a.fanSpeeds, a.fanTemps = fc.Speeds, fc.Temps
+	a.fanLoaded, a.fanDirty = true, false
+	a.fanMid = fc.Mid
+	a.selectedFan = min(a.selectedFan, len(a.fans())-1)
+}
+
+// fanWrite is one fan's part of a queued curve write.
+type fanWrite struct {
+	fan  int // index into fanNames
+	data string
+	ok   bool
+	out  string
+	argv []string
+}
+
+// queueFanCurves writes the curves of fans, as edited, for the active
+// profile as one queued job, then turns custom curves on if they are off
+// so the curves take effect. Each write is logged; report gets the
+// results and, if enabling the curves failed, its output.
+func (a *App) queueFanCurves(fans []int, report func(writes []fanWrite, enableErr string)) {
+	profile, enabled := a.profile, a.fanEnabled
+	writes := make([]fanWrite, len(fans))
+	var names []string
+	for j, i := range fans {
+		speeds, temps := a.quietCapFor(i, profile, a.fanSpeeds[i]), a.fanTemps[i]
+		writes[j] = fanWrite{fan: i, data: FormatFanCurve(temps[:], speeds[:])}
+		names = append(names, fanNames[i])
+	}
+	enabling := false
+	a.submit("fan_curves:"+strings.Join(names, ","), func(b Backend) (bool, string) {
+		allOk := true
+		for j := range writes {
+			w := &writes[j]
+			w.ok, w.out = b.SetFanCurve(fanNames[w.fan], profile, w.data)
+			w.argv = b.LastCommand()
+			allOk = allOk && w.ok
+		}
+		if !allOk || enabled {
+			return allOk, ""
+		}
+		enabling = true
+		return b.EnableFanCurves(profile, true)
+	}, func(ok bool, out string, argv []string) {
+		allOk := true
+		for _, w := range writes {
+			a.logCommand(w.argv, w.out, w.ok)
+			allOk = allOk && w.ok
+		}
+		var enableErr string
+		if enabling {
+			a.logCommand(argv, out, ok)
+			if !ok {
+				enableErr = out
+			}
+		}
+		if profile == a.profile && allOk {
+			a.fanDirty = false
+			a.fanEnabled = a.fanEnabled || enableErr == ""
+		}
+		report(writes, enableErr)
+	})
+}
+
+// applyPresetAllFans loads a preset into every fan and applies them together,
+// reporting the result per fan.
+func (a *App) applyPresetAllFans(preset string) {
+	for i := range a.fans() {
+		a.fanSpeeds[i] = fanPresets[preset]
+	}
+	a.applyAllFans(fmt.Sprintf("Preset %s on all fans", fanPresetLabels[preset]))
+}
+
+// applyAllFans applies every fan's curve as currently edited in one queued
+// job, reporting the result per fan after what.
+func (a *App) applyAllFans(what string) {
+	fans := make([]int, len(a.fans()))
+	for i := range fans {
+		fans[i] = i
+	}
+	a.queueFanCurves(fans, func(writes []fanWrite, enableErr string) {
+		var results []string
+		allOk := true
+		var firstErr string
+		for _, w := range writes {
+			mark := "✓"
+			if !w.ok {
+				mark = "✗"
+				if allOk {
+					firstErr = w.out
+				}
+				allOk = false
+			}
+			results = append(results, strings.ToUpper(fanNames[w.fan])+" "+mark)
+		}
+		if allOk && enableErr != "" {
+			a.SetStatus("Curve set but enable failed: "+classifyFailure(enableErr).Message(), false)
+			return
+		}
+		msg := fmt.Sprintf("%s: %s", what, strings.Join(results, "  "))
+		if !allOk {
+			msg += " — " + classifyFailure(firstErr).Message()
+		}
+		a.SetStatus(msg, allOk)
+	})
 }
 
 func (a *App) handleFans(key KeyEvent) {
@@ -1122,64 +2398,89 @@ func (a *App) handleFans(key KeyEvent) {
 	switch key.Type {
 	case KeyUp:
 		speeds[a.focusIdx] = clamp(speeds[a.focusIdx]+5, 0, 100)
+		a.fanDirty = true
 	case KeyDown:
 		speeds[a.focusIdx] = clamp(speeds[a.focusIdx]-5, 0, 100)
+		a.fanDirty = true
 	case KeyLeft:
 		a.focusIdx = (a.focusIdx + 7) % 8
 	case KeyRight:
 		a.focusIdx = (a.focusIdx + 1) % 8
 	case KeyTab:
-		a.selectedFan = (a.selectedFan + 1) % 2
+		a.selectedFan = (a.selectedFan + 1) % len(a.fans())
 	case KeyEnter:
-		data := FormatFanCurve(a.fanTemps[:], speeds[:])
-		fan := "cpu"
-		if a.selectedFan == 1 {
-			fan = "gpu"
+		if a.locked("fan_curves") {
+			return
 		}
-		ok, out := a.backend.SetFanCurve(fan, a.profile, data)
-		if ok {
-			// Also enable custom fan curves so the curve actually takes effect
-			if !a.fanEnabled {
-				eok, eout := a.backend.EnableFanCurves(a.profile, true)
-				if eok {
-					a.fanEnabled = true
-				} else {
-					a.SetStatus("Curve set but enable failed: "+eout, false)
-					a.addLog("fan-curve --enable-fan-curves true", eout, false)
+		fan := strings.ToUpper(fanNames[a.selectedFan])
+		a.queueFanCurves([]int{a.selectedFan}, func(writes []fanWrite, enableErr string) {
+			switch w := writes[0]; {
+			case !w.ok:
+				a.SetError(w.out)
+				a.offerElevationFor(w.argv, w.out, func() {
+					a.SetStatus(fmt.Sprintf("Fan curve applied (%s)", fan), true)
+				})
+			case enableErr != "":
+				a.SetStatus("Curve set but enable failed: "+classifyFailure(enableErr).Message(), false)
+			default:
+				a.SetStatus(fmt.Sprintf("Fan curve applied (%s)", fan), true)
+			}
+		})
+	case KeyChar:
+		// Shift+preset applies the preset to every fan in one batch
+		if key.Char >= 'A' && key.Char <= 'Z' {
+			if preset, ok := fanPresetKeys[key.Char+'a'-'A']; ok {
+				if a.locked("fan_curves") {
 					return
 				}
+				a.applyPresetAllFans(preset)
+				return
 			}
-			a.SetStatus(fmt.Sprintf("Fan curve applied (%s)", strings.ToUpper(fan)), true)
-		} else {
-			a.SetStatus("Failed: "+out, false)
 		}
-		a.addLog("fan-curve --fan "+fan+" --data "+data, out, ok)
-	case KeyChar:
 		switch key.Char {
 		case 's':
 			a.fanSpeeds[a.selectedFan] = fanPresets["silent"]
+			a.fanDirty = true
 			a.SetStatus("Preset: Silent", true)
 		case 'b':
 			a.fanSpeeds[a.selectedFan] = fanPresets["balanced"]
+			a.fanDirty = true
 			a.SetStatus("Preset: Balanced", true)
 		case 'p':
 			a.fanSpeeds[a.selectedFan] = fanPresets["performance"]
+			a.fanDirty = true
 			a.SetStatus("Preset: Performance", true)
 		case 'f':
 			a.fanSpeeds[a.selectedFan] = fanPresets["full"]
+			a.fanDirty = true
 			a.SetStatus("Preset: Full Speed", true)
+		case 'i':
+			a.promptFanImport()
 		case 'e':
-			a.fanEnabled = !a.fanEnabled
-			ok, out := a.backend.EnableFanCurves(a.profile, a.fanEnabled)
-			if ok {
+			if a.locked("fan_curves") {
+				return
+			}
+			want := !a.fanEnabled
+			applied := func() {
+				a.fanEnabled = want
 				st := "disabled"
-				if a.fanEnabled {
+				if want {
 					st = "enabled"
 				}
 				a.SetStatus("Custom fan curves "+st, true)
-			} else {
-				a.SetStatus("Failed: "+out, false)
 			}
+			profile := a.profile
+			a.submit("fan_enable:"+profile, func(b Backend) (bool, string) {
+				return b.EnableFanCurves(profile, want)
+			}, func(ok bool, out string, argv []string) {
+				a.logCommand(argv, out, ok)
+				if ok {
+					applied()
+				} else {
+					a.SetError(out)
+					a.offerElevationFor(argv, out, applied)
+				}
+			})
 		}
 	}
 }
@@ -1190,75 +2491,207 @@ func (a *App) handleFans(key KeyEvent) {
 
 func (a *App) renderBios(y, h int) {
 	t := a.term
-	cx := 3
+	cx := a.padX()
+	v := &a.bios
 
 	t.TextBold(cx, y+1, ColWarning, "⚠ BIOS / EFI Settings")
 	t.Text(cx, y+2, ColTextDim, "Stored in UEFI variables. Changes may require a reboot.")
+	writes := fmt.Sprintf("UEFI writes: %d this session · %d total", a.uefi.session, a.uefi.total)
+	if a.uefi.excessive() {
+		t.TextBold(cx+58, y+2, ColWarning, "⚠ "+writes)
+	} else {
+		t.Text(cx+58, y+2, ColTextMut, writes)
+	}
 
-	// Panel overdrive
 	row := y + 4
-	focused0 := a.focusIdx == 0
-	if focused0 {
-		t.TextBold(cx, row, ColText, "▸ Panel Overdrive")
-	} else {
-		t.Text(cx, row, ColTextDim, "  Panel Overdrive")
+	if v.err != "" {
+		t.Text(cx+2, row, ColTextMut, v.err)
+		row += 2
 	}
-	t.Text(cx+2, row+1, ColTextMut, "Reduce ghosting (may introduce artifacts)")
-	a.term.DrawToggle(cx+46, row, a.panelOverdrive)
 
-	// GPU MUX
-	row = y + 7
-	focused1 := a.focusIdx == 1
-	if focused1 {
-		t.TextBold(cx, row, ColText, "▸ GPU MUX — Dedicated / G-Sync")
-	} else {
-		t.Text(cx, row, ColTextDim, "  GPU MUX — Dedicated / G-Sync")
+	// One line per attribute, then keyboard sleep lighting (an Aura power
+	// setting, not an armoury attribute)
+	rows := len(v.attrs) + 1
+	visible := max(y+h-row-6, 3)
+	if a.focusIdx < v.scroll {
+		v.scroll = a.focusIdx
+	}
+	if a.focusIdx >= v.scroll+visible {
+		v.scroll = a.focusIdx - visible + 1
+	}
+	v.scroll = clamp(v.scroll, 0, max(rows-visible, 0))
+	for i := v.scroll; i < min(rows, v.scroll+visible); i++ {
+		label := "Keyboard Lighting in Sleep"
+		if i < len(v.attrs) {
+			label = armouryLabel(v.attrs[i].Name)
+		}
+		if a.focusIdx == i {
+			a.focusText(cx, row, "▸ "+label)
+		} else {
+			t.Text(cx, row, ColTextDim, "  "+label)
+		}
+		if i < len(v.attrs) {
+			a.renderArmouryWidget(v, cx+32, row, v.attrs[i])
+		} else {
+			t.DrawToggle(cx+32, row, a.kbdSleepLighting)
+		}
+		row++
+	}
+	if v.scroll+visible < rows {
+		t.Text(cx+2, row, ColTextMut, fmt.Sprintf("↓ %d more", rows-v.scroll-visible))
+		row++
 	}
-	t.Text(cx+2, row+1, ColTextMut, "Route display through dGPU only (requires reboot)")
-	a.term.DrawToggle(cx+46, row, a.gpuMuxDedicated)
 
-	t.Text(cx, y+11, ColTextMut, "Enter to toggle selected setting")
+	// What the focused setting does
+	row++
+	switch {
+	case a.focusIdx < len(v.attrs):
+		at := v.attrs[a.focusIdx]
+		desc := at.Name
+		if info, ok := armouryInfo[at.Name]; ok {
+			desc += " — " + info.desc
+		}
+		switch at.Kind() {
+		case "enum":
+			desc += "  (values " + strings.Join(at.Options, ", ") + ")"
+		case "int":
+			desc += "  (" + armouryRange(at) + ")"
+		}
+		t.Text(cx+2, row, ColTextMut, desc)
+	default:
+		t.Text(cx+2, row, ColTextMut, "Keep the keyboard lit while suspended")
+	}
+
+	t.Text(cx, row+2, ColTextMut, "↑↓ select  ←→ pick  Enter toggle / write  r re-read  │  panel settings: Display tab")
+}
+
+// renderArmouryWidget draws an attribute's value as a toggle, a picker, a
+// slider or plain text, marking a pick that is not written yet.
+func (a *App) renderArmouryWidget(v *armouryView, x, row int, at ArmouryAttr) {
+	t := a.term
+	val := v.shown(at)
+	end := x
+	switch at.Kind() {
+	case "bool":
+		t.DrawToggle(x, row, val == "1")
+		end = x + 8
+	case "enum":
+		for _, o := range at.Options {
+			text := armouryValueLabel(at, o)
+			col := ColTextMut
+			if o == val {
+				col = a.accent()
+				text = "● " + text
+			}
+			t.Text(end, row, col, text)
+			end += len([]rune(text)) + 2
+		}
+	case "int":
+		n, _ := strconv.Atoi(val)
+		pct := 0.0
+		if at.Max > at.Min {
+			pct = float64(n-at.Min) / float64(at.Max-at.Min)
+		}
+		text := armouryUnit(at.Name, val)
+		t.DrawBar(x, row, 20, pct, a.accent(), ColInput)
+		t.Text(x+22, row, ColText, text)
+		end = x + 22 + len([]rune(text)) + 2
+	default:
+		t.Text(x, row, ColText, orDash(val))
+		end = x + len([]rune(orDash(val))) + 2
+	}
+	if _, ok := v.pending[at.Name]; ok {
+		t.TextBold(end, row, ColWarning, "● Enter to write")
+	}
 }
 
 func (a *App) handleBios(key KeyEvent) {
+	v := &a.bios
+	last := len(v.attrs)
+	dir := 1
 	switch key.Type {
 	case KeyUp:
-		a.focusIdx = 0
+		if a.focusIdx > 0 {
+			a.focusIdx--
+		}
+		return
 	case KeyDown:
-		a.focusIdx = 1
+		if a.focusIdx < last {
+			a.focusIdx++
+		}
+		return
+	case KeyChar:
+		if key.Char == 'r' {
+			a.readBios()
+			a.SetStatus("Firmware attributes re-read", true)
+		}
+		return
+	case KeyLeft:
+		dir = -1
+	case KeyRight:
 	case KeyEnter:
-		if a.focusIdx == 0 {
-			a.panelOverdrive = !a.panelOverdrive
-			ok, out := a.backend.SetPanelOverdrive(a.panelOverdrive)
-			if ok {
-				st := "OFF"
-				if a.panelOverdrive {
-					st = "ON"
-				}
-				a.SetStatus("Panel overdrive → "+st, true)
-			} else {
-				a.SetStatus("Failed: "+out, false)
-				a.panelOverdrive = !a.panelOverdrive // revert
-			}
-			a.addLog(fmt.Sprintf("armoury set panel_od %v", a.panelOverdrive), out, ok)
-		} else {
-			a.gpuMuxDedicated = !a.gpuMuxDedicated
-			ok, out := a.backend.SetGpuMux(a.gpuMuxDedicated)
-			if ok {
-				st := "Hybrid"
-				if a.gpuMuxDedicated {
-					st = "Dedicated"
-				}
-				a.SetStatus("GPU MUX → "+st+" (reboot required)", true)
+		if a.locked("bios") {
+			return
+		}
+		if a.focusIdx == last {
+			a.toggleKbdSleepLighting()
+			return
+		}
+		at := v.attrs[a.focusIdx]
+		switch at.Kind() {
+		case "bool":
+			a.setArmoury(v, at.Name, strconv.Itoa(boolInt(at.Value != "1")), nil)
+		case "text":
+			a.promptArmoury(at)
+		default:
+			if p, ok := v.pending[at.Name]; ok {
+				a.setArmoury(v, at.Name, p, nil)
 			} else {
-				a.SetStatus("Failed: "+out, false)
-				a.gpuMuxDedicated = !a.gpuMuxDedicated
+				a.SetStatus("Pick a value with ←→ first", false)
 			}
-			a.addLog(fmt.Sprintf("armoury set gpu_mux_mode %v", a.gpuMuxDedicated), out, ok)
 		}
+		return
+	default:
+		return
+	}
+	if a.focusIdx < last {
+		v.step(a.focusIdx, dir)
 	}
 }
 
+// promptArmoury asks for the value of an attribute asusctl gives no range
+// or options for.
+func (a *App) promptArmoury(at ArmouryAttr) {
+	a.prompt = &Prompt{
+		Title: "Set " + armouryLabel(at.Name),
+		Label: fmt.Sprintf("New value for %s (now %s)", at.Name, orDash(at.Value)),
+		OnOk:  func(val string) { a.setArmoury(&a.bios, at.Name, val, nil) },
+	}
+}
+
+func (a *App) toggleKbdSleepLighting() {
+	on := !a.kbdSleepLighting
+	a.submit("kbd_sleep_lighting", func(b Backend) (bool, string) {
+		return b.SetKbdSleepLighting(on)
+	}, func(ok bool, out string, argv []string) {
+		a.logCommand(argv, out, ok)
+		if ok {
+			a.kbdSleepLighting = on
+			st := "OFF"
+			if on {
+				st = "ON"
+			}
+			a.SetStatus("Keyboard lighting in sleep → "+st, true)
+			return
+		}
+		a.SetError(out)
+		a.offerElevationFor(argv, out, func() {
+			a.kbdSleepLighting = on
+			a.SetStatus("Keyboard sleep lighting changed (elevated)", true)
+		})
+	})
+}
+
 // ═══════════════════════════════════════════════════════════════════════════════
 // Page: Console
 // ═══════════════════════════════════════════════════════════════════════════════
@@ -1266,43 +2699,27 @@ func (a *App) handleBios(key KeyEvent) {
 func (a *App) renderConsole(y, h int) {
 	t := a.term
 	W := t.Width()
-	cx := 3
+	cx := a.padX()
 
 	t.TextBold(cx, y+1, ColText, "Raw Console")
-	t.Text(cx, y+2, ColTextDim, "Run any asusctl command directly")
+	t.Text(cx, y+2, ColTextDim, "Run any asusctl command directly  ·  / searches the log")
 
 	// Input line
 	t.Fg(ColTextDim)
 	t.MoveTo(cx, y+4)
 	t.Write("asusctl ")
-	t.ResetStyle()
-	t.Fg(ColText)
-	t.Bg(ColInput)
-
 	inputW := min(W-14, 60)
-	display := a.consoleInput
-	if len(display) > inputW-1 {
-		display = display[len(display)-inputW+1:]
-	}
-	t.Write(pad(display, inputW))
-	t.ResetStyle()
-	t.Fg(ColTextMut)
-	t.Write(" Enter")
+	a.consoleInput.Render(t, cx+8, y+4, inputW, true)
+	t.Text(cx+8+inputW, y+4, ColTextMut, " Enter")
 
 	// Log area
 	logY := y + 6
-	logH := h - 7
-	if logH < 3 {
-		logH = 3
-	}
+	logH := consoleRowsIn(h)
 
 	t.HLine(cx, logY, min(W-6, 70), ColBorder)
 
 	visibleLines := logH
-	start := len(a.consoleLog) - visibleLines - a.consoleScroll
-	if start < 0 {
-		start = 0
-	}
+	start := a.consoleStart()
 	end := start + visibleLines
 	if end > len(a.consoleLog) {
 		end = len(a.consoleLog)
@@ -1312,28 +2729,40 @@ func (a *App) renderConsole(y, h int) {
 		entry := a.consoleLog[i]
 		row := logY + 1 + lineIdx
 
+		if f := a.consoleFind; f.query != "" && f.match == i {
+			t.ResetStyle()
+			t.Bold()
+			t.Fg(a.accent())
+			t.MoveTo(cx-2, row)
+			a.writeFocused("▸")
+		}
+		t.ResetStyle()
 		t.Fg(ColTextMut)
 		t.MoveTo(cx, row)
 		t.Write(entry.Time + " ")
 
-		t.Fg(ColAccent)
-		t.Write("$ " + entry.Command)
+		a.writeHighlighted("$ "+entry.Command, a.accent())
 		lineIdx++
 
 		if entry.Output != "" && lineIdx < visibleLines {
 			row = logY + 1 + lineIdx
-			if entry.Ok {
-				t.Fg(ColSuccess)
-			} else {
-				t.Fg(ColError)
-			}
-			out := entry.Output
-			maxW := W - cx - 4
-			if len(out) > maxW {
-				out = out[:maxW-1] + "…"
+			col := ColSuccess
+			if !entry.Ok {
+				col = ColError
 			}
+			out := a.shownOutput(entry.Output, W-cx-4)
 			t.MoveTo(cx+2, row)
-			t.Write(out)
+			a.writeHighlighted(out, col)
+			lineIdx++
+		}
+
+		if entry.Hint != "" && lineIdx < visibleLines {
+			hint := "↳ " + entry.Hint
+			if maxW := W - cx - 4; len([]rune(hint)) > maxW {
+				hint = string([]rune(hint)[:maxW-1]) + "…"
+			}
+			t.MoveTo(cx+2, logY+1+lineIdx)
+			a.writeHighlighted(hint, ColWarning)
 			lineIdx++
 		}
 
@@ -1350,31 +2779,61 @@ func (a *App) renderConsole(y, h int) {
 }
 
 func (a *App) handleConsole(key KeyEvent) {
-	switch key.Type {
-	case KeyChar:
-		if key.Char >= 32 && key.Char < 127 {
-			a.consoleInput += string(key.Char)
+	if key.Type == KeyPaste {
+		// commands copied from docs usually start with the binary
+		a.consoleInput.Insert(strings.TrimPrefix(sanitizePaste(key.Text), "asusctl "))
+		return
+	}
+	if key.Type == KeyChar && !key.Alt && a.consoleInput.Empty() {
+		switch {
+		case key.Char == '/':
+			a.promptConsoleSearch()
+			return
+		case key.Char == 'n' && a.consoleFind.query != "":
+			a.findConsole(-1)
+			return
+		case key.Char == 'N' && a.consoleFind.query != "":
+			a.findConsole(1)
+			return
 		}
-	case KeyBackspace:
-		if len(a.consoleInput) > 0 {
-			a.consoleInput = a.consoleInput[:len(a.consoleInput)-1]
+	}
+	if a.consoleInput.HandleKey(key) {
+		return
+	}
+	switch key.Type {
+	case KeyEscape:
+		if a.consoleFind.query != "" {
+			a.endConsoleSearch()
+			a.SetStatus("Search ended", true)
 		}
 	case KeyEnter:
-		if a.consoleInput != "" {
-			cmd := a.consoleInput
-			a.consoleInput = ""
+		if !a.consoleInput.Empty() {
+			if a.locked("console") {
+				return
+			}
+			cmd := a.consoleInput.String()
+			a.consoleInput.Clear()
+			cmd, err := normalizeCurveCommand(cmd)
+			if err != nil {
+				a.addLog(cmd, err.Error(), false)
+				a.SetError(err.Error())
+				return
+			}
 			ok, out := a.backend.RunRaw(cmd)
 			a.addLog(cmd, out, ok)
 			if ok {
 				a.SetStatus("Command OK", true)
 			} else {
-				a.SetStatus("Command failed", false)
+				a.SetError(out)
 			}
 			a.consoleScroll = 0
+			a.consoleFind.follow = false
 		}
 	case KeyPgUp:
+		a.unfollowConsole()
 		a.consoleScroll = min(a.consoleScroll+3, max(0, len(a.consoleLog)-5))
 	case KeyPgDn:
+		a.unfollowConsole()
 		a.consoleScroll = max(a.consoleScroll-3, 0)
 	}
 }
@@ -1383,26 +2842,144 @@ func (a *App) handleConsole(key KeyEvent) {
 // Input Dispatch
 // ═══════════════════════════════════════════════════════════════════════════════
 
+// switchTab activates tab, resetting per-tab focus and re-reading state that
+// is cheap to fetch and may have changed behind our back.
+func (a *App) switchTab(tab Tab) {
+	if tab == a.activeTab {
+		return
+	}
+	if a.cfg.Lockdown.TabLocked(tab) {
+		a.SetStatus(lockGlyph+" The "+tabNames[tab]+" tab is disabled by your administrator", false)
+		return
+	}
+	a.activeTab = tab
+	a.focusIdx = 0
+	a.auraSection = 0
+	a.stopAuraPreview()
+	switch tab {
+	case TabDashboard:
+		a.refreshDashboard()
+	case TabProfile:
+		a.readPpt()
+		if a.cfg.ProfileLayout == "compact" {
+			a.refreshDashboard()
+		}
+	case TabKeyboard:
+		a.touchpad = a.backend.GetTouchpad()
+		a.auraPower = a.backend.GetAuraPower()
+	case TabAura:
+		a.refreshAura()
+		a.startAuraPreview()
+	case TabBattery:
+		a.planner.battery = a.backend.GetBatteryStatus()
+		a.readOneShot()
+	case TabFans:
+		if !a.fanDirty {
+			a.loadFanCurves()
+		}
+	case TabGpu:
+		a.readGfx()
+	case TabSlash:
+		a.slash = readSlashView(a.backend)
+	case TabDisplay:
+		a.panel = readPanelView(a.backend)
+	case TabBios:
+		a.readBios()
+	case TabSystem:
+		a.platformLeds = a.backend.GetPlatformLeds()
+	case TabLogs:
+		a.startLogs()
+	}
+}
+
+// stepTab moves to the next (dir 1) or previous (dir -1) tab the lockdown
+// policy leaves open, wrapping around.
+func (a *App) stepTab(dir int) {
+	tab := a.activeTab
+	for i := 1; i < int(TabCount); i++ {
+		tab = Tab((int(tab) + dir + int(TabCount)) % int(TabCount))
+		if !a.cfg.Lockdown.TabLocked(tab) {
+			a.switchTab(tab)
+			return
+		}
+	}
+}
+
 func (a *App) HandleKey(key KeyEvent) {
+	if a.confirm != nil {
+		a.handleConfirm(key)
+		return
+	}
+	if a.prompt != nil {
+		a.handlePrompt(key)
+		return
+	}
+	if a.changes != nil {
+		a.handleChanges(key)
+		return
+	}
+	if a.picker != nil {
+		a.handleColourPicker(key)
+		return
+	}
+	if a.perKey != nil {
+		a.handlePerKey(key)
+		return
+	}
+	if a.commands != nil {
+		a.handleCommands(key)
+		return
+	}
+	if a.quick {
+		a.handleQuick(key)
+		return
+	}
+	if a.loading {
+		// Only quitting makes sense until the hardware state has been read
+		if key.Type == KeyCtrlC || key.Type == KeyCtrlQ || (key.Type == KeyChar && key.Char == 'q') {
+			a.running = false
+		}
+		return
+	}
+
 	// Global keys
 	switch key.Type {
 	case KeyCtrlC, KeyCtrlQ:
 		a.running = false
 		return
+	case KeyCtrlO:
+		a.toggleQuietOverride()
+		return
+	case KeyCtrlP:
+		a.openCommands()
+		return
 	case KeyChar:
 		if key.Char == 'q' && a.activeTab != TabConsole {
 			a.running = false
 			return
 		}
 		// Tab switching with number keys (only outside console)
-		if a.activeTab != TabConsole || a.consoleInput == "" {
-			if key.Char >= '1' && key.Char <= '7' {
-				newTab := Tab(key.Char - '1')
-				if newTab != a.activeTab {
-					a.activeTab = newTab
-					a.focusIdx = 0
-					a.auraSection = 0
+		if a.activeTab != TabConsole || a.consoleInput.Empty() {
+			for i, k := range tabKeys {
+				if string(key.Char) == k {
+					a.switchTab(Tab(i))
+					return
+				}
+			}
+			switch key.Char {
+			case 'D':
+				a.openChanges()
+				return
+			case 'l':
+				if a.activeTab != TabConsole {
+					a.toggleLights()
+					return
 				}
+			case '[':
+				a.stepTab(-1)
+				return
+			case ']':
+				a.stepTab(1)
 				return
 			}
 		}
@@ -1410,6 +2987,8 @@ func (a *App) HandleKey(key KeyEvent) {
 
 	// Per-tab handlers
 	switch a.activeTab {
+	case TabDashboard:
+		a.handleDashboard(key)
 	case TabProfile:
 		a.handleProfile(key)
 	case TabKeyboard:
@@ -1420,9 +2999,27 @@ func (a *App) HandleKey(key KeyEvent) {
 		a.handleBattery(key)
 	case TabFans:
 		a.handleFans(key)
+	case TabGpu:
+		a.handleGpu(key)
 	case TabBios:
 		a.handleBios(key)
+	case TabSystem:
+		a.handleSystem(key)
 	case TabConsole:
 		a.handleConsole(key)
+	case TabLogs:
+		a.handleLogs(key)
+	case TabMonitor:
+		a.handleMonitor(key)
+	case TabAutomation:
+		a.handleAutomation(key)
+	case TabSlash:
+		a.handleSlash(key)
+	case TabScenes:
+		a.handleScenes(key)
+	case TabDisplay:
+		a.handleDisplay(key)
+	case TabSettings:
+		a.handleSettings(key)
 	}
 }
@@ -0,0 +1,382 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// Armoury attributes — every firmware attribute `asusctl armoury list`
+// reports for this laptop, for the BIOS tab. Each is a toggle (0/1), a
+// picker (a list of values) or a slider (a min–max range); anything else is
+// edited as text. Values picked with ←→ are only written on Enter, since
+// every write lands in UEFI NVRAM.
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// ArmouryAttr is one firmware attribute and what it accepts.
+type ArmouryAttr struct {
+	Name     string
+	Value    string
+	Options  []string // allowed values, for a picker
+	Min, Max int      // allowed range, when Ranged
+	Ranged   bool
+}
+
+// Kind picks the widget: "bool", "enum", "int" or "text".
+func (at ArmouryAttr) Kind() string {
+	switch {
+	case len(at.Options) == 2 && at.Options[0] == "0" && at.Options[1] == "1":
+		return "bool"
+	case len(at.Options) > 0:
+		return "enum"
+	case at.Ranged:
+		return "int"
+	}
+	return "text"
+}
+
+// armouryInfo names the attributes asusd knows; others show their raw name.
+var armouryInfo = map[string]struct{ label, desc string }{
+	"gpu_mux_mode":      {"GPU MUX — Dedicated", "Route the display through the dGPU only (requires reboot)"},
+	"mcu_powersave":     {"MCU Power-Save", "Lower standby drain; disables USB charging while off"},
+	"panel_od":          {"Panel Overdrive", "Reduce ghosting (may introduce artifacts)"},
+	"mini_led_mode":     {"Mini-LED", "Local dimming zones: deeper blacks, higher power draw"},
+	"boot_sound":        {"Boot Sound", "Play the POST sound at power on"},
+	"dgpu_disable":      {"dGPU Disabled", "Power the discrete GPU off entirely"},
+	"egpu_enable":       {"eGPU Enabled", "Use an attached XG Mobile GPU"},
+	"apu_mem":           {"APU Memory", "System memory reserved for the integrated GPU"},
+	"cores_performance": {"Performance Cores", "CPU performance cores enabled (requires reboot)"},
+	"cores_efficiency":  {"Efficiency Cores", "CPU efficiency cores enabled (requires reboot)"},
+	"ppt_pl1_spl":       {"CPU Sustained Power", "PL1 / SPL in watts"},
+	"ppt_pl2_sppt":      {"CPU Boost Power", "PL2 / SPPT in watts"},
+	"ppt_pl3_fppt":      {"CPU Fast Boost Power", "PL3 / FPPT in watts"},
+	"ppt_fppt":          {"CPU Fast Boost Power", "FPPT in watts"},
+	"ppt_apu_sppt":      {"APU Boost Power", "APU SPPT in watts"},
+	"ppt_platform_sppt": {"Platform Boost Power", "Platform SPPT in watts"},
+	"nv_dynamic_boost":  {"GPU Dynamic Boost", "Watts the dGPU may borrow from the CPU"},
+	"nv_temp_target":    {"GPU Temperature Target", "Temperature the dGPU throttles at"},
+	"nv_tgp":            {"GPU Total Power", "dGPU TGP in watts"},
+}
+
+func armouryLabel(name string) string {
+	if info, ok := armouryInfo[name]; ok {
+		return info.label
+	}
+	return name
+}
+
+// armouryValueLabel names a value where the raw number says little.
+func armouryValueLabel(at ArmouryAttr, v string) string {
+	switch {
+	case at.Kind() == "bool":
+		return map[string]string{"0": "OFF", "1": "ON"}[v]
+	case at.Kind() == "int":
+		return armouryUnit(at.Name, v)
+	case at.Name == "mini_led_mode":
+		return miniLedLabel(v, at.Options)
+	}
+	return v
+}
+
+// parseArmouryList reads `asusctl armoury list`. Each attribute is one
+// line — "panel_od: [(0),1]" with the current value in parentheses,
+// "ppt_pl1_spl: 80, min: 15, max: 80" or just "boot_sound: 1" — or, from
+// builds that print the sysfs fields, a "name:" line followed by indented
+// current_value / possible_values / min_value / max_value lines.
+func parseArmouryList(out string) []ArmouryAttr {
+	var attrs []ArmouryAttr
+	for _, line := range strings.Split(out, "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		key, v, ok := strings.Cut(strings.TrimSpace(line), ":")
+		if !ok {
+			continue
+		}
+		v = strings.TrimSpace(v)
+		indented := line[0] == ' ' || line[0] == '\t'
+		if indented && len(attrs) > 0 {
+			at := &attrs[len(attrs)-1]
+			switch key {
+			case "current_value", "current":
+				at.Value = v
+			case "possible_values", "options":
+				at.Options = strings.FieldsFunc(strings.Trim(v, "[]"), func(r rune) bool { return r == ',' || r == ';' || r == ' ' })
+			case "min_value", "min":
+				at.Min, _ = strconv.Atoi(v)
+				at.Ranged = true
+			case "max_value", "max":
+				at.Max, _ = strconv.Atoi(v)
+			}
+			continue
+		}
+		if strings.ContainsAny(key, " \t") {
+			continue // a heading or message, not an attribute
+		}
+		attrs = append(attrs, parseArmouryLine(key, v))
+	}
+	return attrs
+}
+
+// parseArmouryLine reads the value part of a one-line attribute.
+func parseArmouryLine(name, v string) ArmouryAttr {
+	at := ArmouryAttr{Name: name}
+	switch {
+	case strings.HasPrefix(v, "["):
+		for _, o := range strings.Split(strings.Trim(v, "[]"), ",") {
+			o = strings.TrimSpace(o)
+			if strings.HasPrefix(o, "(") {
+				o = strings.Trim(o, "()")
+				at.Value = o
+			}
+			at.Options = append(at.Options, o)
+		}
+	case strings.Contains(v, "min:"):
+		fields := strings.Split(v, ",")
+		at.Value = strings.TrimSpace(fields[0])
+		for _, f := range fields[1:] {
+			k, n, _ := strings.Cut(strings.TrimSpace(f), ":")
+			val, _ := strconv.Atoi(strings.TrimSpace(n))
+			switch k {
+			case "min":
+				at.Min, at.Ranged = val, true
+			case "max":
+				at.Max = val
+			}
+		}
+	default:
+		at.Value = v
+	}
+	return at
+}
+
+// armouryView is a list of attributes with the values picked for them;
+// the BIOS tab's, and the Profile tab's power limits. Read on tab entry.
+type armouryView struct {
+	attrs   []ArmouryAttr
+	err     string
+	pending map[string]string // attribute → value picked with ←→, not yet written
+	scroll  int
+}
+
+func readArmouryView(b Backend) armouryView {
+	ok, out := b.ListArmoury()
+	if !ok {
+		return armouryView{err: strings.TrimSpace(out)}
+	}
+	v := armouryView{attrs: parseArmouryList(out)}
+	if len(v.attrs) == 0 {
+		v.err = "asusctl lists no firmware attributes for this laptop"
+	}
+	return v
+}
+
+// readBios re-reads the BIOS tab and keeps its focus on a row that is
+// still there; the row after the attributes is keyboard sleep lighting.
+func (a *App) readBios() {
+	a.bios = readArmouryView(a.backend)
+	if a.activeTab == TabBios {
+		a.focusIdx = min(a.focusIdx, len(a.bios.attrs))
+	}
+}
+
+// shown is the value the BIOS tab shows: the pending pick, else the current.
+func (v *armouryView) shown(at ArmouryAttr) string {
+	if p, ok := v.pending[at.Name]; ok {
+		return p
+	}
+	return at.Value
+}
+
+// setValue records that attribute name now holds val, leaving any pending
+// edit of it in place.
+// attr looks an attribute up by name; the list changes with every re-read.
+func (v *armouryView) attr(name string) (ArmouryAttr, bool) {
+	for _, at := range v.attrs {
+		if at.Name == name {
+			return at, true
+		}
+	}
+	return ArmouryAttr{}, false
+}
+
+func (v *armouryView) setValue(name, val string) {
+	for i := range v.attrs {
+		if v.attrs[i].Name == name {
+			v.attrs[i].Value = val
+		}
+	}
+}
+
+// step moves attribute i's pending value to the next (dir > 0) or previous
+// option, or by dir within its range.
+func (v *armouryView) step(i, dir int) {
+	at := v.attrs[i]
+	cur := v.shown(at)
+	next := cur
+	switch at.Kind() {
+	case "enum":
+		idx := indexFold(at.Options, cur)
+		n := len(at.Options)
+		if dir > 0 {
+			next = at.Options[(idx+1)%n]
+		} else {
+			next = at.Options[(idx+n-1)%n]
+		}
+	case "int":
+		n, _ := strconv.Atoi(cur)
+		next = strconv.Itoa(clamp(n+dir, at.Min, at.Max))
+	default:
+		return
+	}
+	if v.pending == nil {
+		v.pending = map[string]string{}
+	}
+	if next == at.Value {
+		delete(v.pending, at.Name)
+	} else {
+		v.pending[at.Name] = next
+	}
+}
+
+// setArmoury queues a write of attribute name of v; firmware writes can
+// take seconds. then, if set, learns whether it was written. The view may
+// be re-read before the write completes, so the completion finds the
+// attribute by name again. The GPU MUX goes through askGpuMux so the
+// switch is confirmed and the GPU tab stays in step.
+func (a *App) setArmoury(v *armouryView, name, val string, then func(ok bool)) {
+	at, listed := v.attr(name)
+	if a.locked("bios") || !listed {
+		if then != nil {
+			then(false)
+		}
+		return
+	}
+	if at.Name == "gpu_mux_mode" {
+		a.askGpuMux(val == "1", func(ok bool) {
+			if ok {
+				v.setValue(at.Name, val)
+			}
+			delete(v.pending, at.Name)
+			if then != nil {
+				then(ok)
+			}
+		})
+		return
+	}
+	applied := func() {
+		v.setValue(at.Name, val)
+		delete(v.pending, at.Name)
+		a.SetStatus(fmt.Sprintf("%s → %s", armouryLabel(at.Name), armouryValueLabel(at, val)), true)
+		if then != nil {
+			then(true)
+		}
+	}
+	a.submit("armoury:"+at.Name, func(b Backend) (bool, string) {
+		return b.SetArmoury(at.Name, val)
+	}, func(ok bool, out string, argv []string) {
+		a.logCommand(argv, out, ok)
+		if ok {
+			applied()
+			a.countUefiWrite(out)
+		} else {
+			a.SetError(out)
+			a.offerElevationFor(argv, out, func() { applied(); a.countUefiWrite(out) })
+			if then != nil {
+				then(false)
+			}
+		}
+	})
+}
+
+// ─── UEFI write counter ──────────────────────────────────────────────────────
+
+// More than uefiWarnWrites firmware writes within uefiWarnWindow earns a
+// warning: NVRAM survives many thousands of writes, but toggling a BIOS
+// setting back and forth is how they add up.
+const (
+	uefiWarnWrites = 5
+	uefiWarnWindow = 10 * time.Minute
+)
+
+// uefiWrites counts firmware writes: this session's, and the lifetime total
+// kept in uefi_writes in the state directory.
+type uefiWrites struct {
+	session int
+	total   int
+	recent  []time.Time // writes within uefiWarnWindow
+	persist bool        // false in demo mode
+}
+
+func uefiWritesPath() string {
+	dir := stateDir()
+	if dir == "" {
+		return ""
+	}
+	return filepath.Join(dir, "uefi_writes")
+}
+
+// loadUefiWrites reads the lifetime total; a missing or damaged file
+// starts it at 0.
+func (a *App) loadUefiWrites() {
+	_, demo := a.backend.(*MockBackend)
+	a.uefi.persist = !demo
+	if !a.uefi.persist {
+		return
+	}
+	data, err := os.ReadFile(uefiWritesPath())
+	if err != nil {
+		return
+	}
+	a.uefi.total, _ = strconv.Atoi(strings.TrimSpace(string(data)))
+}
+
+func (u *uefiWrites) save() error {
+	path := uefiWritesPath()
+	if path == "" {
+		return errors.New("no state directory")
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(path+".tmp", []byte(strconv.Itoa(u.total)+"\n"), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(path+".tmp", path)
+}
+
+// excessive reports whether the recent writes call for the warning.
+func (u *uefiWrites) excessive() bool {
+	return len(u.recent) > uefiWarnWrites
+}
+
+// countUefiWrite records a firmware write that printed out and replaces the
+// status with a warning when they come too often. Writes the cache skipped
+// as unchanged never reached the firmware and are not counted.
+func (a *App) countUefiWrite(out string) {
+	if out == unchangedOut {
+		return
+	}
+	u := &a.uefi
+	now := time.Now()
+	u.session++
+	recent := u.recent[:0]
+	for _, t := range u.recent {
+		if now.Sub(t) < uefiWarnWindow {
+			recent = append(recent, t)
+		}
+	}
+	u.recent = append(recent, now)
+	u.total++
+	if u.persist {
+		u.save() // best effort; the session count is still shown
+	}
+	if u.excessive() {
+		a.SetStatus(fmt.Sprintf("%d firmware writes in %d minutes — each one wears UEFI NVRAM", len(u.recent), int(uefiWarnWindow.Minutes())), false)
+	}
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"io"
+	"testing"
+)
+
+// TestArmouryWriteAfterReread checks a queued firmware write completes
+// against the view as re-read meanwhile, found by name.
+func TestArmouryWriteAfterReread(t *testing.T) {
+	a := NewApp(NewFakeTerminal(80, 24, io.Discard), NewMockBackend(), DefaultConfig())
+	a.switchTab(TabBios)
+	a.setArmoury(&a.bios, "panel_od", "1", nil)
+	a.bios = armouryView{err: "Error: org.freedesktop.DBus.Error.NoReply"}
+	drainQueue(t, a)
+
+	a.readBios()
+	a.setArmoury(&a.bios, "panel_od", "1", nil)
+	var fewer []ArmouryAttr // re-read without an attribute listed before it
+	before := map[string]string{}
+	for _, at := range a.bios.attrs {
+		if at.Name != "boot_sound" {
+			fewer = append(fewer, at)
+			before[at.Name] = at.Value
+		}
+	}
+	a.bios.attrs = fewer
+	drainQueue(t, a)
+	before["panel_od"] = "1"
+	for _, at := range a.bios.attrs {
+		if at.Value != before[at.Name] {
+			t.Errorf("%s = %q after the write, want %q", at.Name, at.Value, before[at.Name])
+		}
+	}
+}
+
+// TestBiosFocusAfterReread checks the BIOS tab's keys stay on the list
+// when a re-read shortens it.
+func TestBiosFocusAfterReread(t *testing.T) {
+	a := NewApp(NewFakeTerminal(80, 24, io.Discard), NewMockBackend(), DefaultConfig())
+	a.switchTab(TabBios)
+	a.focusIdx = len(a.bios.attrs) + 5
+	a.readBios()
+	if a.focusIdx != len(a.bios.attrs) {
+		t.Fatalf("focusIdx = %d, want %d", a.focusIdx, len(a.bios.attrs))
+	}
+	for _, k := range []KeyEvent{{Type: KeyUp}, {Type: KeyLeft}, {Type: KeyRight}, {Type: KeyEnter}} {
+		a.HandleKey(k)
+	}
+}
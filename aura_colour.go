@@ -0,0 +1,289 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// Aura colour rows — the built-in swatches, then the user's palette
+// ([[palette]] in the config; + saves the focused colour, - removes one),
+// then the row's custom colour: one set with c or the picker, or one that
+// came from asusd, a favourite, a scene or a rule and is none of the
+// swatches, kept exactly rather than snapped to the nearest. A row's index
+// (auraColour1 / auraColour2) counts across all three, so after the palette
+// changes the selections are found again by colour with matchAuraColour.
+// ═══════════════════════════════════════════════════════════════════════════════
+
+func (p PaletteColour) Validate() error {
+	if strings.TrimSpace(p.Name) == "" {
+		return fmt.Errorf("name: must not be empty")
+	}
+	if _, _, _, ok := parseHexColour(p.Hex); !ok {
+		return fmt.Errorf("hex: %q is not an rrggbb colour", p.Hex)
+	}
+	return nil
+}
+
+// basePalette is the built-in swatches followed by the user's palette.
+func (a *App) basePalette() []AuraColour {
+	p := append([]AuraColour(nil), auraColours...)
+	for _, pc := range a.cfg.Palette {
+		if c, ok := parseColourInput(pc.Hex); ok {
+			c.Name = pc.Name
+			p = append(p, c)
+		}
+	}
+	return p
+}
+
+// auraPalette is the swatches of colour row n (0 = Colour, 1 = Colour2):
+// the base palette, plus the custom colour once one was entered.
+func (a *App) auraPalette(n int) []AuraColour {
+	p := a.basePalette()
+	if a.auraCustom[n].Hex != "" {
+		p = append(p, a.auraCustom[n])
+	}
+	return p
+}
+
+// selectedAuraColour is the colour selected on row n.
+func (a *App) selectedAuraColour(n int) AuraColour {
+	i := a.auraColour1
+	if n == 1 {
+		i = a.auraColour2
+	}
+	p := a.auraPalette(n)
+	return p[clamp(i, 0, len(p)-1)]
+}
+
+func (a *App) setAuraColourIndex(n, i int) {
+	if n == 0 {
+		a.auraColour1 = i
+	} else {
+		a.auraColour2 = i
+	}
+}
+
+// matchAuraColour is the swatch on row n for a colour that did not come
+// from the row itself (read back from the hardware, or a favourite's, a
+// scene's or a rule's): a swatch of exactly that colour, else the row's
+// custom swatch, which becomes that colour.
+func (a *App) matchAuraColour(n, r, g, b int) int {
+	rgb := Color{r, g, b}
+	base := a.basePalette()
+	for i, c := range base {
+		if c.Rgb == rgb {
+			return i
+		}
+	}
+	if c := a.auraCustom[n]; c.Rgb != rgb || c.Hex == "" {
+		hex := fmt.Sprintf("%02x%02x%02x", r, g, b)
+		a.auraCustom[n] = AuraColour{Name: "#" + hex, Hex: hex, Rgb: rgb}
+	}
+	return len(base)
+}
+
+// swatchNameW is the room renderColourName gets after a colour row.
+const swatchNameW = 16
+
+// swatchWindow is the part of colour row n that fits the terminal, 4
+// columns a swatch: the first swatch shown and how many. With more than
+// fit, the window follows the focus on the row, else the selected swatch.
+func (a *App) swatchWindow(n int) (first, count int) {
+	total := len(a.auraPalette(n))
+	count = max((a.term.Width()-a.padX()-9-2-swatchNameW)/4, 3)
+	if total <= count {
+		return 0, total
+	}
+	anchor := a.auraColour1
+	if n == 1 {
+		anchor = a.auraColour2
+	}
+	if a.auraSection == n+1 {
+		anchor = a.focusIdx
+	}
+	return clamp(anchor-count/2, 0, total-count), count
+}
+
+// renderSwatches draws colour row n at y: the swatches in swatchWindow,
+// with ‹ and › where more are scrolled off.
+func (a *App) renderSwatches(n, y int) {
+	t := a.term
+	x0 := a.padX() + 9
+	selected := a.auraColour1
+	if n == 1 {
+		selected = a.auraColour2
+	}
+	p := a.auraPalette(n)
+	first, count := a.swatchWindow(n)
+	for i := first; i < first+count; i++ {
+		t.ResetStyle()
+		t.Bg(p[i].Rgb)
+		t.MoveTo(x0+(i-first)*4, y)
+		mark := "   "
+		if i == selected {
+			t.Fg(Color{0, 0, 0})
+			t.Bold()
+			mark = " ◆ "
+		}
+		if a.auraSection == n+1 && a.focusIdx == i {
+			t.Fg(Color{0, 0, 0})
+			t.Bold()
+			a.writeFocused("▸" + mark[1:])
+		} else {
+			t.Write(mark)
+		}
+	}
+	t.ResetStyle()
+	if first > 0 {
+		t.Text(x0-1, y, ColTextMut, "‹")
+	}
+	if first+count < len(p) {
+		t.Text(x0+count*4, y, ColTextMut, "›")
+	}
+}
+
+// renderColourName labels row n: the name of a focused palette colour, or
+// "Custom" and the custom swatch's hex value.
+func (a *App) renderColourName(n, y int) {
+	t := a.term
+	t.ResetStyle()
+	p := a.auraPalette(n)
+	_, count := a.swatchWindow(n)
+	x := a.padX() + 9 + count*4 + 2
+	focused := a.auraSection == n+1 && a.focusIdx >= len(auraColours) && a.focusIdx < len(a.cfg.Palette)+len(auraColours)
+	switch {
+	case focused:
+		t.Text(x, y, ColText, p[a.focusIdx].Name)
+	case a.auraCustom[n].Hex != "":
+		t.Text(x, y, ColTextDim, "Custom "+a.auraCustom[n].Name)
+	}
+}
+
+// parseColourInput accepts "1e90ff", "#1e90ff" or "#19f".
+func parseColourInput(s string) (AuraColour, bool) {
+	hex := strings.ToLower(strings.TrimPrefix(strings.TrimSpace(s), "#"))
+	if len(hex) == 3 {
+		hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+	}
+	r, g, b, ok := parseHexColour(hex)
+	if !ok {
+		return AuraColour{}, false
+	}
+	return AuraColour{Name: "#" + hex, Hex: hex, Rgb: Color{r, g, b}}, true
+}
+
+// promptAuraColour asks for a custom colour for row n and selects it.
+func (a *App) promptAuraColour(n int) {
+	title := "Custom colour"
+	if n == 1 {
+		title = "Custom colour 2"
+	}
+	a.prompt = &Prompt{
+		Title: title,
+		Label: "Hex RGB, e.g. 1e90ff",
+		Swatch: func(text string) (Color, bool) {
+			c, ok := parseColourInput(text)
+			return c.Rgb, ok
+		},
+		OnOk: func(text string) {
+			c, ok := parseColourInput(text)
+			if !ok {
+				a.SetStatus(fmt.Sprintf("%q is not a hex colour (use six digits such as 1e90ff)", text), false)
+				return
+			}
+			a.selectCustomColour(n, c)
+		},
+	}
+}
+
+// selectCustomColour makes c row n's custom colour and selects it.
+func (a *App) selectCustomColour(n int, c AuraColour) {
+	a.auraCustom[n] = c
+	i := len(a.basePalette())
+	a.setAuraColourIndex(n, i)
+	a.focusIdx = i
+	a.auraEdited()
+	title := "Colour"
+	if n == 1 {
+		title = "Colour 2"
+	}
+	a.SetStatus(title+" → "+c.Name+"; press a to apply", true)
+}
+
+// ─── Palette ─────────────────────────────────────────────────────────────────
+
+// promptPaletteColour asks for a name for the swatch focused on row n and
+// adds it to the palette.
+func (a *App) promptPaletteColour(n int) {
+	c := a.auraPalette(n)[a.focusIdx]
+	if a.focusIdx < len(auraColours) {
+		a.SetStatus(c.Name+" is built in; save a custom colour (c or h) instead", false)
+		return
+	}
+	a.prompt = &Prompt{
+		Title: "Save #" + c.Hex + " to your palette",
+		Label: "Name for this colour, e.g. dodger blue",
+		OnOk:  func(name string) { a.changePalette(n, name, c.Hex) },
+	}
+}
+
+// deletePaletteColour removes the palette colour focused on row n. A row
+// that had it selected keeps it as its custom colour.
+func (a *App) deletePaletteColour(n int) {
+	i := a.focusIdx - len(auraColours)
+	if i < 0 || i >= len(a.cfg.Palette) {
+		a.SetStatus("Only colours you saved with + can be removed", false)
+		return
+	}
+	pc := a.cfg.Palette[i]
+	a.ask(&Confirm{
+		Title: "Remove " + pc.Name + " from your palette?",
+		Lines: []string{"A colour row that uses #" + pc.Hex + " keeps it as its custom colour."},
+		OnYes: func() { a.changePalette(n, pc.Name, "") },
+	})
+}
+
+// changePalette stores hex under name, or removes name when hex is empty,
+// saves the config and finds the rows' selections again.
+func (a *App) changePalette(n int, name, hex string) {
+	sel := [2]AuraColour{a.selectedAuraColour(0), a.selectedAuraColour(1)}
+	var kept []PaletteColour
+	for _, pc := range a.cfg.Palette {
+		if !strings.EqualFold(pc.Name, name) {
+			kept = append(kept, pc)
+		}
+	}
+	verb := "removed from"
+	if hex != "" {
+		kept = append(kept, PaletteColour{Name: name, Hex: hex})
+		verb = "saved to"
+	}
+	a.cfg.Palette = kept
+
+	base := a.basePalette()
+	for m := range sel {
+		if a.auraCustom[m].Hex == hex {
+			a.auraCustom[m] = AuraColour{} // now a palette swatch
+		}
+		in := false
+		for _, c := range base {
+			in = in || c.Hex == sel[m].Hex
+		}
+		if !in {
+			a.auraCustom[m] = AuraColour{Name: "#" + sel[m].Hex, Hex: sel[m].Hex, Rgb: sel[m].Rgb}
+		}
+		a.setAuraColourIndex(m, a.matchAuraColour(m, sel[m].Rgb.R, sel[m].Rgb.G, sel[m].Rgb.B))
+	}
+	a.focusIdx = min(a.focusIdx, len(a.auraPalette(n))-1)
+	if hex != "" {
+		a.focusIdx = len(base) - 1
+	}
+
+	if err := a.cfg.Persist(); err != nil {
+		a.SetStatus("Colour "+name+" "+verb+" the palette for this session only: "+err.Error(), false)
+		return
+	}
+	a.SetStatus("Colour "+name+" "+verb+" the palette", true)
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"testing"
+)
+
+// TestSwatchWindowFits checks a long colour row scrolls with the focus
+// instead of running off a narrow terminal.
+func TestSwatchWindowFits(t *testing.T) {
+	cfg := DefaultConfig()
+	for i := 0; i < 10; i++ {
+		cfg.Palette = append(cfg.Palette, PaletteColour{Name: fmt.Sprint("mine ", i), Hex: fmt.Sprintf("%02x0000", i*20)})
+	}
+	for _, w := range []int{50, 80, 200} {
+		a := NewApp(NewFakeTerminal(w, 24, io.Discard), NewMockBackend(), cfg)
+		total := len(a.auraPalette(0))
+		a.auraSection = 1
+		for focus := 0; focus < total; focus++ {
+			a.focusIdx = focus
+			first, count := a.swatchWindow(0)
+			if end := a.padX() + 9 + count*4 + 2 + swatchNameW; count < total && end > w {
+				t.Fatalf("width %d: %d swatches end at column %d", w, count, end)
+			}
+			if focus < first || focus >= first+count {
+				t.Fatalf("width %d: focus %d outside the window %d+%d", w, focus, first, count)
+			}
+		}
+		if _, count := a.swatchWindow(0); w == 200 && count != total {
+			t.Errorf("width 200 shows %d of %d swatches", count, total)
+		}
+	}
+}
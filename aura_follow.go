@@ -0,0 +1,85 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// Aura follows profile — [aura_profiles] names an Aura favourite for each
+// power profile (red static for Performance, blue breathe for Balanced…).
+// Every path that changes the profile calls followProfile afterwards: the
+// Profile tab, the policies (quiet hours, power source, game mode) and the
+// live sync for the Fn key and other tools. Scenes, rules and workspaces
+// bring their own lighting, so they hold the follow for the profile they
+// set (holdAuraFollow) rather than have the favourite overwrite theirs.
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// For is the favourite to apply for profile p; "" leaves Aura alone.
+func (c AuraProfilesConfig) For(p string) string {
+	switch matchProfile(p) {
+	case "Performance":
+		return c.Performance
+	case "Balanced":
+		return c.Balanced
+	case "Quiet":
+		return c.Quiet
+	}
+	return ""
+}
+
+func (c AuraProfilesConfig) Enabled() bool {
+	return c.Performance != "" || c.Balanced != "" || c.Quiet != ""
+}
+
+// checkAuraProfiles makes sure each favourite named exists.
+func (c *Config) checkAuraProfiles() error {
+	for _, p := range profileNames {
+		name := c.AuraProfiles.For(p)
+		if name != "" && auraPresetIndex(c.AuraPresets, name) < 0 {
+			return fmt.Errorf("aura_profiles.%s: no Aura favourite named %q", strings.ToLower(p), name)
+		}
+	}
+	return nil
+}
+
+// holdAuraFollow marks p as followed already, for a scene, rule or
+// workspace about to switch to it with lighting of its own.
+func (a *App) holdAuraFollow(p string) {
+	a.automation.auraProfile = p
+}
+
+// followProfile queues the favourite of the profile when it changed since
+// the last follow or hold. Unapplied edits on the Aura tab are kept.
+func (a *App) followProfile() {
+	au := &a.automation
+	p := a.profile
+	if p == au.auraProfile {
+		return
+	}
+	au.auraProfile = p
+	i := auraPresetIndex(a.cfg.AuraPresets, a.cfg.AuraProfiles.For(p))
+	if i < 0 {
+		return
+	}
+	fav := a.cfg.AuraPresets[i]
+	what := "profile " + p + ": Aura " + fav.Name
+	if a.cfg.Lockdown.ActionLocked("aura") {
+		a.automationLog(what, lockActions["aura"]+" is disabled by your administrator", false)
+		return
+	}
+	mode, colour1, colour2, speed, direction := fav.args()
+	a.submit("aura", func(b Backend) (bool, string) {
+		return b.SetAuraMode(mode, colour1, colour2, speed, direction)
+	}, func(ok bool, out string, argv []string) {
+		a.automationLog(what, out, ok)
+		if !ok {
+			return
+		}
+		a.auraAppliedZones = nil
+		a.readAuraApplied()
+		if !a.auraDirty {
+			a.selectAuraPreset(fav)
+		}
+	})
+}
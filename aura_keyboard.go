@@ -0,0 +1,114 @@
+package main
+
+import "strconv"
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// Keyboard view — a sketch of the keyboard at the bottom of the Aura tab,
+// lit the way the hardware is: the effect and colours read back with
+// GetAuraState (at startup, after each apply and when asusd reports a
+// change), the zone colours of the last zoned apply and the brightness.
+// The rows above show what is selected; this shows what is applied.
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// keySketchRows are key widths in cells, one gap cell between keys: number
+// row, three letter rows and the space bar row.
+var keySketchRows = [][]int{
+	{2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 5},
+	{3, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 4},
+	{4, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 5},
+	{5, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 7},
+	{3, 3, 3, 19, 3, 3, 3},
+}
+
+// keySketchWidth is the widest row in cells.
+func keySketchWidth() int {
+	w := 0
+	for _, row := range keySketchRows {
+		n := len(row) - 1
+		for _, k := range row {
+			n += k
+		}
+		w = max(w, n)
+	}
+	return w
+}
+
+// auraModeFromRon is the Aura tab name of asusd's effect key, e.g.
+// RainbowWave → Rainbow Wave; unknown keys come back as they are.
+func auraModeFromRon(key string) string {
+	for _, m := range auraModes {
+		if auraRonMode(m) == key {
+			return m
+		}
+	}
+	return key
+}
+
+// readAuraApplied re-reads the effect the keyboard shows, with its zone
+// colours when asusd's config has them.
+func (a *App) readAuraApplied() {
+	if st := a.backend.GetAuraState(); st != nil {
+		a.auraApplied = st
+		if len(st.Zones) > 0 {
+			a.auraAppliedZones = st.Zones
+		}
+	}
+}
+
+// keyColour is the colour of the key whose middle is at column x of w,
+// before brightness: rainbow effects spread the hues over the keyboard,
+// stars mix both colours and the rest light keys in their zone's colour.
+func (a *App) keyColour(st *AuraState, x, w int) Color {
+	c1 := Color{st.R1, st.G1, st.B1}
+	c2 := Color{st.R2, st.G2, st.B2}
+	if n := len(a.auraAppliedZones); n > 0 {
+		if r, g, b, ok := parseHexColour(a.auraAppliedZones[x*n/w]); ok {
+			c1 = Color{r, g, b}
+		}
+	}
+	switch auraModeFromRon(st.Mode) {
+	case "Rainbow Cycle", "Rainbow Wave", "Rain":
+		return hsvToRGB(x*360/w, 100, 100)
+	case "Stars":
+		if previewNoise(x, 2) < 0.5 {
+			return c2
+		}
+	}
+	return c1
+}
+
+// renderAuraKeyboard draws the keyboard view from row y; it needs
+// len(keySketchRows)+1 rows.
+func (a *App) renderAuraKeyboard(y int) {
+	t := a.term
+	cx := a.padX()
+	st := a.auraApplied
+	if st == nil || t.Width()-cx < keySketchWidth()+2 {
+		return
+	}
+	mode := auraModeFromRon(st.Mode)
+	label := "On the keyboard: " + mode + " · brightness " + kbdLabels[a.kbdLevel]
+	if n := len(a.auraAppliedZones); n > 0 {
+		label += " · " + strconv.Itoa(n) + " zones"
+	}
+	t.Text(cx, y, ColTextDim, label)
+
+	// brightness scales the colours; off leaves the keys unlit
+	level := []float64{0, 0.45, 0.75, 1}[a.kbdLevel]
+	w := keySketchWidth()
+	for r, row := range keySketchRows {
+		x := 0
+		for _, k := range row {
+			c := lerpColor(ColBg, a.keyColour(st, x+k/2, w), level)
+			if level == 0 {
+				c = ColBorder
+			}
+			t.ResetStyle()
+			t.Bg(c)
+			t.MoveTo(cx+x, y+1+r)
+			t.Write(pad("", k))
+			x += k + 1
+		}
+	}
+	t.ResetStyle()
+}
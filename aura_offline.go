@@ -0,0 +1,172 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// Offline Aura — edit asusd's aura_*.ron when asusd is not running
+// (initramfs, a bare tty, a crashed daemon). asusd applies the file on its
+// next start. The file is root-owned, so the write is a copy with install(1)
+// that offerElevation can retry through pkexec.
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// auraRonMode is asusd's name for an effect: the display name without
+// spaces, except that Stars is "Star".
+func auraRonMode(mode string) string {
+	if mode == "Stars" {
+		return "Star"
+	}
+	return strings.ReplaceAll(mode, " ", "")
+}
+
+// setAuraRon makes mode the current effect of an aura_*.ron document and
+// stores its colours, speed and direction. Empty arguments leave that
+// setting alone.
+func setAuraRon(doc *ronValue, mode, colour1, colour2, speed, direction string) error {
+	key := auraRonMode(mode)
+	effect := doc.Field("builtins").Field(key)
+	if effect == nil || effect.Kind != ronStruct {
+		return fmt.Errorf("%s is not in this keyboard's aura config", mode)
+	}
+	for _, c := range []struct{ field, hex string }{{"colour1", colour1}, {"colour2", colour2}} {
+		if c.hex == "" {
+			continue
+		}
+		r, g, b, ok := parseHexColour(c.hex)
+		if !ok {
+			return fmt.Errorf("invalid colour %q", c.hex)
+		}
+		effect.SetField(c.field, &ronValue{Kind: ronStruct, Fields: []ronField{
+			{"r", ronInt(r)}, {"g", ronInt(g)}, {"b", ronInt(b)},
+		}})
+	}
+	for _, o := range []struct{ field, value string }{{"speed", speed}, {"direction", direction}} {
+		if o.value != "" {
+			effect.SetField(o.field, ronIdent(strings.ToUpper(o.value[:1])+o.value[1:]))
+		}
+	}
+	doc.SetField("current_mode", ronIdent(key))
+	return nil
+}
+
+// auraRonPath is the keyboard's aura config; asusd names it after the USB
+// product id. With several files (a keyboard swapped, or an external ASUS
+// keyboard), the one of a plugged-in ASUS device wins, else the one asusd
+// wrote last.
+func auraRonPath() (string, error) {
+	configs, _ := filepath.Glob("/etc/asusd/aura_*.ron")
+	if len(configs) == 0 {
+		return "", fmt.Errorf("no /etc/asusd/aura_*.ron: asusd has never run on this laptop")
+	}
+	return pickAuraRon(configs, asusUsbProducts()), nil
+}
+
+// pickAuraRon chooses among aura configs: the first named after one of
+// products, else the newest.
+func pickAuraRon(configs, products []string) string {
+	for _, path := range configs {
+		id := strings.TrimSuffix(strings.TrimPrefix(filepath.Base(path), "aura_"), ".ron")
+		if indexFold(products, id) >= 0 {
+			return path
+		}
+	}
+	best, bestTime := configs[0], time.Time{}
+	for _, path := range configs {
+		if fi, err := os.Stat(path); err == nil && fi.ModTime().After(bestTime) {
+			best, bestTime = path, fi.ModTime()
+		}
+	}
+	return best
+}
+
+// asusUsbProducts are the product ids of the ASUS USB devices plugged in.
+func asusUsbProducts() []string {
+	vendors, _ := filepath.Glob("/sys/bus/usb/devices/*/idVendor")
+	var ids []string
+	for _, path := range vendors {
+		if data, err := os.ReadFile(path); err != nil || strings.TrimSpace(string(data)) != "0b05" {
+			continue
+		}
+		if data, err := os.ReadFile(filepath.Join(filepath.Dir(path), "idProduct")); err == nil {
+			ids = append(ids, strings.TrimSpace(string(data)))
+		}
+	}
+	return ids
+}
+
+// readAuraRon is the text of the keyboard's aura config; ok is false when
+// there is none or it cannot be read.
+func readAuraRon() (string, bool) {
+	path, err := auraRonPath()
+	if err != nil {
+		return "", false
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", false
+	}
+	return string(data), true
+}
+
+// WriteAuraOffline stores the effect in the aura config for asusd's next
+// start. The new file is staged in a private directory (0700, the file
+// 0600) that no other user can swap it in, and copied into place. A failed
+// copy keeps it for the pkexec retry until the next write.
+func (b *ExecBackend) WriteAuraOffline(mode, colour1, colour2, speed, direction string) (bool, string) {
+	path, err := auraRonPath()
+	if err != nil {
+		return false, err.Error()
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return false, err.Error()
+	}
+	doc, err := parseRon(string(data))
+	if err != nil {
+		return false, path + ": " + err.Error()
+	}
+	if err := setAuraRon(doc, mode, colour1, colour2, speed, direction); err != nil {
+		return false, err.Error()
+	}
+	staged, err := b.stageAuraRon(filepath.Base(path), encodeRon(doc, 0)+"\n")
+	if err != nil {
+		return false, err.Error()
+	}
+	ok, out := b.runBin("install", "-m", "0644", staged, path)
+	if ok {
+		b.dropAuraStage()
+	}
+	return ok, out
+}
+
+// stageAuraRon writes data to a new file in a fresh private directory,
+// dropping the one a previous write left behind.
+func (b *ExecBackend) stageAuraRon(name, data string) (string, error) {
+	b.dropAuraStage()
+	dir, err := os.MkdirTemp("", "asusctl-tui-")
+	if err != nil {
+		return "", err
+	}
+	b.auraStage = dir
+	f, err := os.CreateTemp(dir, strings.TrimSuffix(name, ".ron")+"-*.ron")
+	if err != nil {
+		return "", err
+	}
+	if _, err := f.WriteString(data); err != nil {
+		f.Close()
+		return "", err
+	}
+	return f.Name(), f.Close()
+}
+
+func (b *ExecBackend) dropAuraStage() {
+	if b.auraStage != "" {
+		os.RemoveAll(b.auraStage)
+		b.auraStage = ""
+	}
+}
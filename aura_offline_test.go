@@ -0,0 +1,38 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestPickAuraRon(t *testing.T) {
+	dir := t.TempDir()
+	var configs []string
+	hoursOld := []int{3, 1, 2} // aura_19b6.ron is the newest
+	for i, name := range []string{"aura_1866.ron", "aura_19b6.ron", "aura_1a30.ron"} {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		at := time.Now().Add(-time.Duration(hoursOld[i]) * time.Hour)
+		if err := os.Chtimes(path, at, at); err != nil {
+			t.Fatal(err)
+		}
+		configs = append(configs, path)
+	}
+	tests := []struct {
+		products []string
+		want     string
+	}{
+		{[]string{"1A30"}, "aura_1a30.ron"},
+		{[]string{"abcd", "1866"}, "aura_1866.ron"},
+		{nil, "aura_19b6.ron"},
+	}
+	for _, tt := range tests {
+		if got := filepath.Base(pickAuraRon(configs, tt.products)); got != tt.want {
+			t.Errorf("products %v: picked %s, want %s", tt.products, got, tt.want)
+		}
+	}
+}
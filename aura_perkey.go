@@ -0,0 +1,345 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// Per-key editor — k on the Aura tab opens a modal with a keyboard grid. The
+// cursor moves across the keys and each one takes a colour from the palette;
+// the result is saved by name in config.toml ([[per_key_layouts]]) and sent
+// with a. asusctl has no per-key command yet: per-key lighting is only
+// reachable through asusd's D-Bus direct addressing, in USB packets laid out
+// per keyboard model. Sending waits for a perKeyOp entry in cliArgTable;
+// until then layouts are designed and saved but not sent.
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// perKeyOp is the cliArgTable operation that sends a frame, one "rrggbb"
+// per key in perKeyLayout order, joined by commas.
+const perKeyOp = "aura.direct"
+
+// errNoPerKey is SetAuraPerKey's output while asusctl has no perKeyOp.
+const errNoPerKey = "asusctl has no per-key lighting command"
+
+func perKeySupported() bool {
+	_, ok := cliArgTable[perKeyOp]
+	return ok
+}
+
+type perKeyCap struct {
+	label string
+	w     int // width in cells; one gap cell between keys
+}
+
+// perKeyLayout is the editor's grid, a TKL keyboard in rows of 73 cells.
+// A layout's colours are stored in this order, so keys are only appended.
+var perKeyLayout = [][]perKeyCap{
+	{{"Esc", 4}, {"F1", 4}, {"F2", 4}, {"F3", 4}, {"F4", 4}, {"F5", 4}, {"F6", 4},
+		{"F7", 4}, {"F8", 4}, {"F9", 4}, {"F10", 4}, {"F11", 4}, {"F12", 4}, {"Del", 8}},
+	{{"`", 4}, {"1", 4}, {"2", 4}, {"3", 4}, {"4", 4}, {"5", 4}, {"6", 4},
+		{"7", 4}, {"8", 4}, {"9", 4}, {"0", 4}, {"-", 4}, {"=", 4}, {"Bksp", 8}},
+	{{"Tab", 6}, {"Q", 4}, {"W", 4}, {"E", 4}, {"R", 4}, {"T", 4}, {"Y", 4},
+		{"U", 4}, {"I", 4}, {"O", 4}, {"P", 4}, {"[", 4}, {"]", 4}, {"\\", 6}},
+	{{"Caps", 7}, {"A", 4}, {"S", 4}, {"D", 4}, {"F", 4}, {"G", 4}, {"H", 4},
+		{"J", 4}, {"K", 4}, {"L", 4}, {";", 4}, {"'", 4}, {"Enter", 10}},
+	{{"Shift", 9}, {"Z", 4}, {"X", 4}, {"C", 4}, {"V", 4}, {"B", 4}, {"N", 4},
+		{"M", 4}, {",", 4}, {".", 4}, {"/", 4}, {"Shift", 8}, {"↑", 4}},
+	{{"Ctrl", 5}, {"Fn", 4}, {"Win", 4}, {"Alt", 4}, {"Space", 27}, {"Alt", 4},
+		{"Ctrl", 4}, {"←", 4}, {"↓", 4}, {"→", 4}},
+}
+
+const perKeyWidth = 73
+
+// perKeyCount is the number of keys in perKeyLayout.
+func perKeyCount() int {
+	n := 0
+	for _, row := range perKeyLayout {
+		n += len(row)
+	}
+	return n
+}
+
+// perKeyIndex is the position of the key at row, col in layout order.
+func perKeyIndex(row, col int) int {
+	n := col
+	for _, r := range perKeyLayout[:row] {
+		n += len(r)
+	}
+	return n
+}
+
+// perKeyAt is the key of row whose cells are nearest column x.
+func perKeyAt(row, x int) int {
+	best, dist := 0, perKeyWidth
+	pos := 0
+	for i, k := range perKeyLayout[row] {
+		mid := pos + k.w/2
+		if d := max(mid-x, x-mid); d < dist {
+			best, dist = i, d
+		}
+		pos += k.w + 1
+	}
+	return best
+}
+
+// perKeyMid is the middle column of the key at row, col.
+func perKeyMid(row, col int) int {
+	pos := 0
+	for _, k := range perKeyLayout[row][:col] {
+		pos += k.w + 1
+	}
+	return pos + perKeyLayout[row][col].w/2
+}
+
+func (l PerKeyLayout) Validate() error {
+	if strings.TrimSpace(l.Name) == "" {
+		return errors.New("name: must not be empty")
+	}
+	if len(l.Keys) > perKeyCount() {
+		return fmt.Errorf("keys: %d colours for %d keys", len(l.Keys), perKeyCount())
+	}
+	for i, hex := range l.Keys {
+		if _, _, _, ok := parseHexColour(hex); hex != "" && !ok {
+			return fmt.Errorf("keys[%d]: %q is not an rrggbb colour", i, hex)
+		}
+	}
+	return nil
+}
+
+// perKeyLayoutIndex finds a saved layout by name, ignoring case; -1 if none.
+func perKeyLayoutIndex(layouts []PerKeyLayout, name string) int {
+	for i, l := range layouts {
+		if strings.EqualFold(l.Name, name) {
+			return i
+		}
+	}
+	return -1
+}
+
+type perKeyEditor struct {
+	row, col int
+	keys     []string // "rrggbb" per key in layout order, "" unlit
+	brush    int      // index into basePalette()
+	name     string   // saved layout last loaded or saved
+}
+
+func (a *App) openPerKey() {
+	a.perKey = &perKeyEditor{keys: make([]string, perKeyCount())}
+}
+
+func (e *perKeyEditor) key() *string {
+	return &e.keys[perKeyIndex(e.row, e.col)]
+}
+
+// load puts saved layout l in the editor; keys it has no colour for are
+// left unlit.
+func (e *perKeyEditor) load(l PerKeyLayout) {
+	e.keys = make([]string, perKeyCount())
+	for i, hex := range l.Keys {
+		e.keys[i] = strings.ToLower(hex)
+	}
+	e.name = l.Name
+}
+
+// savePerKey stores the editor's keys under name, replacing a layout of the
+// same name.
+func (a *App) savePerKey(name string) {
+	e := a.perKey
+	l := PerKeyLayout{Name: name, Keys: append([]string(nil), e.keys...)}
+	if i := perKeyLayoutIndex(a.cfg.PerKeyLayouts, name); i >= 0 {
+		a.cfg.PerKeyLayouts[i] = l
+	} else {
+		a.cfg.PerKeyLayouts = append(a.cfg.PerKeyLayouts, l)
+	}
+	e.name = name
+	if err := a.cfg.Persist(); err != nil {
+		a.SetStatus("Layout "+name+" not saved: "+err.Error(), false)
+		return
+	}
+	a.SetStatus("Layout "+name+" saved", true)
+}
+
+// promptPerKey asks for a name for the editor's keys.
+func (a *App) promptPerKey() {
+	a.prompt = &Prompt{
+		Title: "Save per-key layout",
+		Label: "Name for this layout, e.g. wasd",
+		OnOk: func(name string) {
+			if i := perKeyLayoutIndex(a.cfg.PerKeyLayouts, name); i >= 0 {
+				a.ask(&Confirm{
+					Title: fmt.Sprintf("Replace layout %s?", a.cfg.PerKeyLayouts[i].Name),
+					Lines: []string{"A layout with this name already exists."},
+					OnYes: func() { a.savePerKey(name) },
+				})
+				return
+			}
+			a.savePerKey(name)
+		},
+	}
+	a.prompt.Input.Set(a.perKey.name)
+}
+
+// nextPerKey loads the saved layout after the one last loaded.
+func (a *App) nextPerKey() {
+	layouts := a.cfg.PerKeyLayouts
+	if len(layouts) == 0 {
+		a.SetStatus("No saved layouts yet; press s to save this one", false)
+		return
+	}
+	l := layouts[(perKeyLayoutIndex(layouts, a.perKey.name)+1)%len(layouts)]
+	a.perKey.load(l)
+	a.SetStatus("Layout "+l.Name+" loaded", true)
+}
+
+// sendPerKey queues the editor's keys as one frame, once asusctl can take
+// one.
+func (a *App) sendPerKey() {
+	if a.locked("aura") {
+		return
+	}
+	if !perKeySupported() {
+		a.SetStatus(errNoPerKey+"; save the layout with s for when it has one", false)
+		return
+	}
+	frame := make([]string, len(a.perKey.keys))
+	for i, hex := range a.perKey.keys {
+		frame[i] = hex
+		if hex == "" {
+			frame[i] = "000000"
+		}
+	}
+	a.submit("aura", func(b Backend) (bool, string) {
+		return b.SetAuraPerKey(frame)
+	}, func(ok bool, out string, argv []string) {
+		a.logCommand(argv, out, ok)
+		if !ok {
+			a.SetError(out)
+			return
+		}
+		a.SetStatus("Per-key layout sent", true)
+	})
+}
+
+// SetAuraPerKey sends one frame through perKeyOp.
+func (b *ExecBackend) SetAuraPerKey(colours []string) (bool, string) {
+	if !perKeySupported() {
+		return false, errNoPerKey
+	}
+	return b.runOp(perKeyOp, strings.Join(colours, ","))
+}
+
+func (a *App) renderPerKey() {
+	e := a.perKey
+	t := a.term
+	W, H := t.Width(), t.Height()
+	w := min(perKeyWidth+4, W-2)
+	h := 2*len(perKeyLayout) + 8
+	x, y := (W-w)/2, max((H-h)/2, 0)
+
+	t.ResetStyle()
+	t.FillRect(x, y, w, h, ColCard)
+	t.Bg(ColCard)
+	t.DrawBox(x, y, w, h, t.Accent())
+	t.ResetStyle()
+	t.Bg(ColCard)
+	t.Bold()
+	t.Fg(ColText)
+	t.MoveTo(x+2, y+1)
+	title := "Per-key lighting"
+	if e.name != "" {
+		title += " · " + e.name
+	}
+	t.Write(title)
+	if w < perKeyWidth+4 {
+		t.TextBg(x+2, y+3, ColTextDim, ColCard, "Widen the terminal to edit keys")
+		return
+	}
+
+	for r, row := range perKeyLayout {
+		cx := x + 2
+		for c, k := range row {
+			bg, fg := ColBorder, ColTextDim
+			if rr, g, b, ok := parseHexColour(e.keys[perKeyIndex(r, c)]); ok {
+				bg = Color{rr, g, b}
+				// black or white, whichever shows on the key
+				fg = Color{255, 255, 255}
+				if rr*3+g*6+b > 1280 {
+					fg = Color{0, 0, 0}
+				}
+			}
+			label := []rune(" " + k.label)
+			if r == e.row && c == e.col {
+				label[0] = '▸'
+			}
+			label = label[:min(len(label), k.w)]
+			text := string(label) + strings.Repeat(" ", k.w-len(label))
+			t.ResetStyle()
+			t.Bg(bg)
+			t.Fg(fg)
+			t.MoveTo(cx, y+3+2*r)
+			if r == e.row && c == e.col {
+				t.Bold()
+				a.writeFocused(text)
+			} else {
+				t.Write(text)
+			}
+			cx += k.w + 1
+		}
+	}
+
+	brush := a.basePalette()[e.brush]
+	by := y + 3 + 2*len(perKeyLayout)
+	t.TextBg(x+2, by, ColTextDim, ColCard, "Colour")
+	t.TextBg(x+10, by, ColText, brush.Rgb, "    ")
+	t.TextBg(x+15, by, ColText, ColCard, brush.Name)
+	hint := "Enter paint  x clear  f fill  Tab colour  s save  l load  a send"
+	t.TextBg(x+2, y+h-2, ColTextMut, ColCard, hint)
+	t.ResetStyle()
+}
+
+// handlePerKey consumes every key while the editor is open.
+func (a *App) handlePerKey(key KeyEvent) {
+	e := a.perKey
+	rows := len(perKeyLayout)
+	switch key.Type {
+	case KeyEscape, KeyCtrlC:
+		a.perKey = nil
+	case KeyLeft:
+		e.col = (e.col + len(perKeyLayout[e.row]) - 1) % len(perKeyLayout[e.row])
+	case KeyRight:
+		e.col = (e.col + 1) % len(perKeyLayout[e.row])
+	case KeyUp, KeyDown:
+		mid := perKeyMid(e.row, e.col)
+		if key.Type == KeyUp {
+			e.row = (e.row + rows - 1) % rows
+		} else {
+			e.row = (e.row + 1) % rows
+		}
+		e.col = perKeyAt(e.row, mid)
+	case KeyTab:
+		e.brush = (e.brush + 1) % len(a.basePalette())
+	case KeyEnter:
+		*e.key() = a.basePalette()[e.brush].Hex
+	case KeyChar:
+		switch key.Char {
+		case ' ':
+			*e.key() = a.basePalette()[e.brush].Hex
+		case 'x':
+			*e.key() = ""
+		case 'f':
+			for i := range e.keys {
+				e.keys[i] = a.basePalette()[e.brush].Hex
+			}
+		case 's':
+			a.promptPerKey()
+		case 'l':
+			a.nextPerKey()
+		case 'a':
+			a.sendPerKey()
+		case 'q', 'k':
+			a.perKey = nil
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"io"
+	"path/filepath"
+	"testing"
+)
+
+func TestPerKeyLayoutRows(t *testing.T) {
+	for r, row := range perKeyLayout {
+		w := len(row) - 1
+		for _, k := range row {
+			w += k.w
+		}
+		if w != perKeyWidth {
+			t.Errorf("row %d is %d cells, want %d", r, w, perKeyWidth)
+		}
+	}
+}
+
+func TestPerKeyCursor(t *testing.T) {
+	a := NewApp(NewFakeTerminal(100, 30, io.Discard), NewMockBackend(), DefaultConfig())
+	a.switchTab(TabAura)
+	a.HandleKey(KeyEvent{Type: KeyChar, Char: 'k'})
+	if a.perKey == nil {
+		t.Fatal("k did not open the editor")
+	}
+	e := a.perKey
+	// down keeps to the column: Esc, `, Tab, Caps, Shift, Ctrl
+	for i := 0; i < 5; i++ {
+		a.HandleKey(KeyEvent{Type: KeyDown})
+	}
+	if got := perKeyLayout[e.row][e.col].label; got != "Ctrl" {
+		t.Errorf("down from Esc ends on %q, want Ctrl", got)
+	}
+	e.row, e.col = 4, 5 // B
+	a.HandleKey(KeyEvent{Type: KeyDown})
+	if got := perKeyLayout[e.row][e.col].label; got != "Space" {
+		t.Errorf("down from B ends on %q, want Space", got)
+	}
+	a.HandleKey(KeyEvent{Type: KeyLeft})
+	a.HandleKey(KeyEvent{Type: KeyLeft})
+	a.HandleKey(KeyEvent{Type: KeyLeft})
+	a.HandleKey(KeyEvent{Type: KeyLeft})
+	a.HandleKey(KeyEvent{Type: KeyLeft})
+	if got := perKeyLayout[e.row][e.col].label; got != "→" {
+		t.Errorf("left wraps to %q, want →", got)
+	}
+	a.Render()
+	a.HandleKey(KeyEvent{Type: KeyEscape})
+	if a.perKey != nil {
+		t.Error("Esc left the editor open")
+	}
+}
+
+func TestPerKeySaveAndLoad(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.path = filepath.Join(t.TempDir(), "config.toml")
+	a := NewApp(NewFakeTerminal(100, 30, io.Discard), NewMockBackend(), cfg)
+	a.switchTab(TabAura)
+	a.HandleKey(KeyEvent{Type: KeyChar, Char: 'k'})
+	e := a.perKey
+	e.row, e.col = 3, 2 // S
+	a.HandleKey(KeyEvent{Type: KeyEnter})
+	brush := a.basePalette()[0].Hex
+	if got := e.keys[perKeyIndex(3, 2)]; got != brush {
+		t.Fatalf("S is %q after Enter, want %q", got, brush)
+	}
+
+	a.HandleKey(KeyEvent{Type: KeyChar, Char: 's'})
+	a.prompt.Input.Set("wasd")
+	a.HandleKey(KeyEvent{Type: KeyEnter})
+	loaded, err := LoadConfig(cfg.path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(loaded.PerKeyLayouts) != 1 || loaded.PerKeyLayouts[0].Name != "wasd" {
+		t.Fatalf("saved layouts %+v, want wasd", loaded.PerKeyLayouts)
+	}
+	if got := loaded.PerKeyLayouts[0].Keys[perKeyIndex(3, 2)]; got != brush {
+		t.Errorf("saved S as %q, want %q", got, brush)
+	}
+
+	a.HandleKey(KeyEvent{Type: KeyChar, Char: 'x'})
+	a.HandleKey(KeyEvent{Type: KeyChar, Char: 'l'})
+	if got := e.keys[perKeyIndex(3, 2)]; got != brush {
+		t.Errorf("S is %q after loading wasd, want %q", got, brush)
+	}
+}
+
+// TestPerKeySendNeedsOp checks a waits for asusctl to have a per-key
+// command instead of queueing a write that can only fail.
+func TestPerKeySendNeedsOp(t *testing.T) {
+	a := NewApp(NewFakeTerminal(100, 30, io.Discard), NewMockBackend(), DefaultConfig())
+	a.switchTab(TabAura)
+	a.HandleKey(KeyEvent{Type: KeyChar, Char: 'k'})
+	a.HandleKey(KeyEvent{Type: KeyChar, Char: 'f'})
+	a.HandleKey(KeyEvent{Type: KeyChar, Char: 'a'})
+	if n := a.queue.Pending(); n != 0 {
+		t.Errorf("%d writes queued without a per-key command", n)
+	}
+
+	cliArgTable[perKeyOp] = map[int][]string{6: {"aura", "direct", "{0}"}}
+	defer delete(cliArgTable, perKeyOp)
+	a.HandleKey(KeyEvent{Type: KeyChar, Char: 'a'})
+	drainQueue(t, a)
+	if a.statusMsg != "Per-key layout sent" {
+		t.Errorf("status %q after sending", a.statusMsg)
+	}
+}
+
+func TestPerKeyLayoutValidate(t *testing.T) {
+	tests := []struct {
+		l  PerKeyLayout
+		ok bool
+	}{
+		{PerKeyLayout{Name: "wasd", Keys: []string{"", "ff0000"}}, true},
+		{PerKeyLayout{Name: " "}, false},
+		{PerKeyLayout{Name: "bad", Keys: []string{"red"}}, false},
+		{PerKeyLayout{Name: "long", Keys: make([]string, perKeyCount()+1)}, false},
+	}
+	for _, tt := range tests {
+		if err := tt.l.Validate(); (err == nil) != tt.ok {
+			t.Errorf("%+v: err %v, want ok %v", tt.l, err, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// Pinned effects — * on the Aura tab's effect grid pins the focused effect
+// to the top of the grid (and unpins it), so the few effects in daily use
+// stay in the first row however many the keyboard offers. Pins are saved in
+// the config as pinned_effects, in the order they were pinned. In the grid
+// section focusIdx is a grid position; auraGrid maps it to auraModes.
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// checkPinnedEffects makes sure each pinned effect exists.
+func (c *Config) checkPinnedEffects() error {
+	for _, name := range c.PinnedEffects {
+		if indexFold(auraModes, name) < 0 {
+			return fmt.Errorf("pinned_effects: unknown effect %q", name)
+		}
+	}
+	return nil
+}
+
+// auraPinned reports whether effect i of auraModes is pinned.
+func (a *App) auraPinned(i int) bool {
+	return indexFold(a.cfg.PinnedEffects, auraModes[i]) >= 0
+}
+
+// auraGrid is the effect grid's order as auraModes indices: the pinned
+// effects, then the rest in auraModes order.
+func (a *App) auraGrid() []int {
+	grid := make([]int, 0, len(auraModes))
+	for _, name := range a.cfg.PinnedEffects {
+		if i := indexFold(auraModes, name); i >= 0 && indexInt(grid, i) < 0 {
+			grid = append(grid, i)
+		}
+	}
+	for i := range auraModes {
+		if !a.auraPinned(i) {
+			grid = append(grid, i)
+		}
+	}
+	return grid
+}
+
+// auraGridPos is the grid position of effect i of auraModes.
+func (a *App) auraGridPos(i int) int {
+	return max(indexInt(a.auraGrid(), i), 0)
+}
+
+func indexInt(list []int, v int) int {
+	for i, x := range list {
+		if x == v {
+			return i
+		}
+	}
+	return -1
+}
+
+// togglePinnedEffect pins or unpins the focused effect and saves the
+// config. The focus moves with the effect.
+func (a *App) togglePinnedEffect() {
+	i := a.auraGrid()[a.focusIdx]
+	name := auraModes[i]
+	verb := "unpinned"
+	if a.auraPinned(i) {
+		var kept []string
+		for _, p := range a.cfg.PinnedEffects {
+			if !strings.EqualFold(p, name) {
+				kept = append(kept, p)
+			}
+		}
+		a.cfg.PinnedEffects = kept
+	} else {
+		a.cfg.PinnedEffects = append(a.cfg.PinnedEffects, name)
+		verb = "pinned to the top"
+	}
+	a.focusIdx = a.auraGridPos(i)
+	if err := a.cfg.Persist(); err != nil {
+		a.SetStatus(name+" "+verb+" for this session only: "+err.Error(), false)
+		return
+	}
+	a.SetStatus(name+" "+verb, true)
+}
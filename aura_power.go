@@ -0,0 +1,101 @@
+package main
+
+import (
+	"os"
+	"strconv"
+	"strings"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// Aura power — the LEDs besides the keyboard (lightbar, ROG logo, lid,
+// rear glow) switched on and off on the Keyboard tab, one toggle each, so
+// the lid logo can go dark while the keyboard stays lit. The toggles set
+// asusd's "awake" power state with `asusctl aura-power <led> --awake`; the
+// state is read back from the power states in asusd's aura config, which
+// also tells which of the LEDs the laptop has.
+// ═══════════════════════════════════════════════════════════════════════════════
+
+type AuraPowerControl interface {
+	// GetAuraPower lists the laptop's LEDs besides the keyboard, in
+	// auraPowerZones order; nil when asusd's config does not say.
+	GetAuraPower() []AuraPowerLed
+	// SetAuraPower lights zone (an auraPowerZones arg) while awake, or not.
+	SetAuraPower(zone string, on bool) (bool, string)
+}
+
+// AuraPowerLed is one LED group and whether it is lit while awake.
+type AuraPowerLed struct {
+	Zone  string // asusctl's name, e.g. "logo"
+	Label string
+	On    bool
+}
+
+// auraPowerZones are asusd's power zones other than the keyboard: its name
+// in the aura config, asusctl's and ours.
+var auraPowerZones = []struct{ ron, arg, label string }{
+	{"Lightbar", "lightbar", "Lightbar"},
+	{"Logo", "logo", "ROG logo"},
+	{"Lid", "lid", "Lid LEDs"},
+	{"RearGlow", "rear-glow", "Rear glow"},
+}
+
+// parseAuraPower reads the power states in an aura config: one
+// `(zone: Logo, boot: true, awake: true, …)` per LED group.
+func parseAuraPower(ron string) []AuraPowerLed {
+	var leds []AuraPowerLed
+	for _, z := range auraPowerZones {
+		i := strings.Index(ron, "zone: "+z.ron+",")
+		if i < 0 {
+			continue
+		}
+		start := strings.LastIndex(ron[:i], "(")
+		end := strings.Index(ron[i:], ")")
+		if start < 0 || end < 0 {
+			continue
+		}
+		state := ron[start : i+end]
+		leds = append(leds, AuraPowerLed{Zone: z.arg, Label: z.label, On: parseRonField(state, "awake") == "true"})
+	}
+	return leds
+}
+
+func (b *ExecBackend) GetAuraPower() []AuraPowerLed {
+	path, err := auraRonPath()
+	if err != nil {
+		return nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	return parseAuraPower(string(data))
+}
+
+func (b *ExecBackend) SetAuraPower(zone string, on bool) (bool, string) {
+	return b.runOp("aura.power.awake", zone, strconv.FormatBool(on))
+}
+
+// toggleAuraPower switches LED group i of App.auraPower.
+func (a *App) toggleAuraPower(i int) {
+	if a.locked("aura") {
+		return
+	}
+	led := a.auraPower[i]
+	on := !led.On
+	a.submit("aura_power:"+led.Zone, func(b Backend) (bool, string) {
+		return b.SetAuraPower(led.Zone, on)
+	}, func(ok bool, out string, argv []string) {
+		a.logCommand(argv, out, ok)
+		if !ok {
+			a.SetError(out)
+			a.offerElevationFor(argv, out, func() {
+				a.auraPower = a.backend.GetAuraPower()
+			})
+			return
+		}
+		if i < len(a.auraPower) && a.auraPower[i].Zone == led.Zone {
+			a.auraPower[i].On = on
+		}
+		a.SetStatus(led.Label+" → "+strings.ToUpper(onOff(on)), true)
+	})
+}
@@ -0,0 +1,212 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// Aura favourites — effects saved by name in config.toml ([[aura_presets]]).
+// The Aura tab saves the selection as one (p) and steps through them (f);
+// `aura apply <name>` applies one without the UI, for window manager
+// keybindings.
+// ═══════════════════════════════════════════════════════════════════════════════
+
+func (p AuraPreset) Validate() error {
+	if strings.TrimSpace(p.Name) == "" {
+		return errors.New("name: must not be empty")
+	}
+	if indexFold(auraModes, p.Mode) < 0 {
+		return fmt.Errorf("mode: unknown effect %q", p.Mode)
+	}
+	for _, c := range []struct{ key, hex string }{{"colour1", p.Colour1}, {"colour2", p.Colour2}} {
+		if _, _, _, ok := parseHexColour(c.hex); c.hex != "" && !ok {
+			return fmt.Errorf("%s: %q is not an rrggbb colour", c.key, c.hex)
+		}
+	}
+	if p.Speed != "" && indexFold(auraSpeeds, p.Speed) < 0 {
+		return fmt.Errorf("speed: %q must be low, med or high", p.Speed)
+	}
+	if p.Direction != "" && indexFold(auraDirections, p.Direction) < 0 {
+		return fmt.Errorf("direction: %q must be left, right, up or down", p.Direction)
+	}
+	return checkZones(p.Zones)
+}
+
+// args are the SetAuraMode arguments, leaving out what the effect ignores.
+func (p AuraPreset) args() (mode, colour1, colour2, speed, direction string) {
+	mode = auraModes[indexFold(auraModes, p.Mode)]
+	if auraEffectNeedsColour1(mode) {
+		colour1 = strings.ToLower(p.Colour1)
+	}
+	if auraEffectNeedsColour2(mode) {
+		colour2 = strings.ToLower(p.Colour2)
+	}
+	if auraEffectNeedsSpeed(mode) {
+		speed = strings.ToLower(p.Speed)
+	}
+	if auraEffectNeedsDirection(mode) {
+		direction = strings.ToLower(p.Direction)
+	}
+	return mode, colour1, colour2, speed, direction
+}
+
+// Describe is the one-line summary shown by `aura list`.
+func (p AuraPreset) Describe() string {
+	mode, colour1, colour2, speed, direction := p.args()
+	parts := []string{mode}
+	for _, c := range []string{colour1, colour2} {
+		if c != "" {
+			parts = append(parts, "#"+c)
+		}
+	}
+	for _, o := range []string{speed, direction} {
+		if o != "" {
+			parts = append(parts, o)
+		}
+	}
+	if len(p.Zones) > 0 && colour1 != "" {
+		parts = append(parts, "zones #"+strings.Join(p.Zones, " #"))
+	}
+	return strings.Join(parts, " ")
+}
+
+// auraPresetIndex finds a favourite by name, ignoring case; -1 if none.
+func auraPresetIndex(presets []AuraPreset, name string) int {
+	for i, p := range presets {
+		if strings.EqualFold(p.Name, name) {
+			return i
+		}
+	}
+	return -1
+}
+
+// saveAuraPreset stores the Aura tab's selection under name, replacing a
+// favourite of the same name.
+func (a *App) saveAuraPreset(name string) {
+	mode, colour1, colour2, speed, direction := a.auraArgs()
+	p := AuraPreset{Name: name, Mode: mode, Colour1: colour1, Colour2: colour2, Speed: speed, Direction: direction, Zones: a.savedZones()}
+	if i := auraPresetIndex(a.cfg.AuraPresets, name); i >= 0 {
+		a.cfg.AuraPresets[i] = p
+	} else {
+		a.cfg.AuraPresets = append(a.cfg.AuraPresets, p)
+	}
+	a.auraPreset = name
+	if err := a.cfg.Persist(); err != nil {
+		a.SetStatus("Favourite "+name+" not saved: "+err.Error(), false)
+		return
+	}
+	a.SetStatus("Favourite "+name+" saved", true)
+}
+
+// promptAuraPreset asks for a name for the current selection.
+func (a *App) promptAuraPreset() {
+	a.prompt = &Prompt{
+		Title: "Save Aura favourite",
+		Label: "Name for this effect, e.g. night",
+		OnOk: func(name string) {
+			if i := auraPresetIndex(a.cfg.AuraPresets, name); i >= 0 {
+				a.ask(&Confirm{
+					Title: fmt.Sprintf("Replace favourite %s?", a.cfg.AuraPresets[i].Name),
+					Lines: []string{"A favourite with this name already exists."},
+					OnYes: func() { a.saveAuraPreset(name) },
+				})
+				return
+			}
+			a.saveAuraPreset(name)
+		},
+	}
+}
+
+// nextAuraPreset selects the favourite after the last one selected. Like
+// any other change on the tab, it is applied with a.
+func (a *App) nextAuraPreset() {
+	presets := a.cfg.AuraPresets
+	if len(presets) == 0 {
+		a.SetStatus("No favourites yet; press p to save the current effect", false)
+		return
+	}
+	p := presets[(auraPresetIndex(presets, a.auraPreset)+1)%len(presets)]
+	a.selectAuraPreset(p)
+	a.auraEdited()
+	a.auraEnterSection(0)
+	a.SetStatus("Favourite "+p.Name+" selected; press a to apply", true)
+}
+
+// selectAuraPreset puts favourite p in the Aura tab's rows.
+func (a *App) selectAuraPreset(p AuraPreset) {
+	a.auraPreset = p.Name
+	a.auraMode = indexFold(auraModes, p.Mode)
+	for n, hex := range []string{p.Colour1, p.Colour2} {
+		if r, g, b, ok := parseHexColour(hex); ok {
+			a.setAuraColourIndex(n, a.matchAuraColour(n, r, g, b))
+		}
+	}
+	if i := indexFold(auraSpeeds, p.Speed); i >= 0 {
+		a.auraSpeed = i
+	}
+	if i := indexFold(auraDirections, p.Direction); i >= 0 {
+		a.auraDirection = i
+	}
+	a.setZoneColours(p.Zones)
+}
+
+// runAuraCommand runs `aura apply <name>` or `aura list`. A daemon that is
+// down gets the effect in its config file, as on the Aura tab.
+func runAuraCommand(b Backend, cfg *Config, args []string) int {
+	usage := "usage: aura apply <favourite> | aura list"
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, usage)
+		return 2
+	}
+	switch {
+	case args[0] == "list" && len(args) == 1:
+		for _, p := range cfg.AuraPresets {
+			fmt.Fprintf(os.Stdout, "%-16s %s\n", p.Name, p.Describe())
+		}
+		return 0
+	case args[0] == "apply" && len(args) == 2:
+	default:
+		fmt.Fprintln(os.Stderr, usage)
+		return 2
+	}
+
+	i := auraPresetIndex(cfg.AuraPresets, args[1])
+	if i < 0 {
+		fmt.Fprintf(os.Stderr, "no Aura favourite named %q; save one on the Aura tab (p) or see `aura list`\n", args[1])
+		return 1
+	}
+	if cfg.Lockdown.ActionLocked("aura") {
+		fmt.Fprintln(os.Stderr, lockActions["aura"]+" is disabled by your administrator")
+		return 1
+	}
+	p := cfg.AuraPresets[i]
+	mode, colour1, colour2, speed, direction := p.args()
+	// zone colours go zone by zone through asusd; there is no offline write
+	zoned := len(p.Zones) > 0 && colour1 != ""
+	var ok bool
+	var out string
+	if zoned {
+		ok, out = b.SetAuraZones(mode, p.Zones, colour2, speed, direction)
+	} else {
+		ok, out = b.SetAuraMode(mode, colour1, colour2, speed, direction)
+	}
+	if !ok && !zoned && classifyFailure(out).Kind == ErrDaemonDown {
+		ok, out = b.WriteAuraOffline(mode, colour1, colour2, speed, direction)
+		if ok {
+			fmt.Fprintln(os.Stdout, "asusd is not running; "+mode+" saved, it is applied when asusd starts")
+			return 0
+		}
+	}
+	if !ok {
+		e := classifyFailure(out)
+		fmt.Fprintf(os.Stderr, "%s: %s\n", e.Message(), strings.TrimSpace(out))
+		if h := e.Hint(); h != "" {
+			fmt.Fprintln(os.Stderr, h)
+		}
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,182 @@
+package main
+
+import (
+	"math"
+	"time"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// Aura preview — a strip under the effect grid that plays the selected
+// effect with its colours, speed and direction, so it can be judged before
+// a is pressed. It is a rough likeness drawn in truecolour cells, not what
+// asusd sends. While the Aura tab is open a ticker renders a new frame a
+// few times a second; with animations = false the strip stands still.
+// ═══════════════════════════════════════════════════════════════════════════════
+
+const (
+	auraPreviewEvery = 150 * time.Millisecond
+	auraPreviewWidth = 48
+)
+
+type auraPreview struct {
+	stop  chan struct{}
+	start time.Time
+}
+
+// startAuraPreview renders the Aura tab every auraPreviewEvery until
+// stopAuraPreview.
+func (a *App) startAuraPreview() {
+	p := &a.auraAnim
+	if !a.cfg.Animations || p.stop != nil {
+		return
+	}
+	p.stop = make(chan struct{})
+	p.start = time.Now()
+	stop := p.stop
+	go func() {
+		tick := time.NewTicker(auraPreviewEvery)
+		defer tick.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-tick.C:
+				a.RequestRender()
+			}
+		}
+	}()
+}
+
+func (a *App) stopAuraPreview() {
+	if a.auraAnim.stop != nil {
+		close(a.auraAnim.stop)
+		a.auraAnim.stop = nil
+	}
+}
+
+// previewNoise is a fixed pseudo-random number in [0, 1) for i and k, so
+// stars and drops stay put from one frame to the next.
+func previewNoise(i, k int) float64 {
+	h := uint32(i)*374761393 + uint32(k)*668265263
+	h = (h ^ h>>13) * 1274126177
+	return float64(h^h>>16) / (1 << 32)
+}
+
+// auraPreviewCell is the colour of cell i of n at t seconds into the effect.
+// c1 and c2 are the effect's colours; reactive effects (Highlight, Laser,
+// Ripple) are shown answering a key pressed once a second.
+func auraPreviewCell(mode, direction string, c1, c2 Color, i, n int, t float64) Color {
+	black := Color{}
+	x := float64(i) / float64(n)
+	cycle, frac := math.Modf(t)
+	k := int(cycle)
+	press := previewNoise(k, 9) // where this second's key is
+	dist := math.Abs(x - press)
+
+	switch mode {
+	case "Static":
+		return c1
+	case "Breathe":
+		// in and out of c1, then of c2
+		cycle, frac = math.Modf(t / 3)
+		c := c1
+		if int(cycle)%2 == 1 {
+			c = c2
+		}
+		return lerpColor(black, c, math.Sin(math.Pi*frac))
+	case "Rainbow Cycle":
+		return hsvToRGB(int(t*60)%360, 100, 100)
+	case "Rainbow Wave":
+		// a one-row strip shows up and down as the whole row changing
+		switch direction {
+		case "left":
+			x = -x
+		case "up", "down":
+			x = 0
+		}
+		hue := math.Mod((x-t/4)*360, 360)
+		if hue < 0 {
+			hue += 360
+		}
+		return hsvToRGB(int(hue), 100, 100)
+	case "Stars":
+		if previewNoise(i, 1) > 0.35 {
+			return black
+		}
+		c := c1
+		if previewNoise(i, 2) < 0.5 {
+			c = c2
+		}
+		return lerpColor(black, c, math.Pow(math.Max(0, math.Sin(2*math.Pi*(t*0.7+previewNoise(i, 3)))), 3))
+	case "Rain":
+		cycle, frac = math.Modf(t*1.5 + previewNoise(i, 4))
+		if previewNoise(i, int(cycle)+5) > 0.2 {
+			return black
+		}
+		return lerpColor(black, hsvToRGB(int(previewNoise(i, int(cycle)+6)*360), 100, 100), 1-frac)
+	case "Highlight":
+		if dist < 1.5/float64(n) {
+			return lerpColor(black, c1, 1-frac)
+		}
+		return black
+	case "Laser":
+		if math.Abs(dist-frac/2) < 1.5/float64(n) {
+			return c1
+		}
+		return black
+	case "Ripple":
+		if math.Abs(dist-frac/2) < 4/float64(n) {
+			return lerpColor(black, c1, 1-frac)
+		}
+		return black
+	case "Pulse":
+		return lerpColor(black, c1, 0.55+0.45*math.Sin(2*math.Pi*t))
+	case "Comet":
+		behind := frac - x
+		if behind < 0 || behind > 0.25 {
+			return black
+		}
+		return lerpColor(black, c1, 1-behind*4)
+	case "Flash":
+		if frac < 0.5 {
+			return c1
+		}
+		return black
+	}
+	return c1
+}
+
+// renderAuraPreview draws the strip for the selected effect; zones with
+// their own colour show it in their part of the strip.
+func (a *App) renderAuraPreview(y int) {
+	t := a.term
+	cx := a.padX()
+	n := min(auraPreviewWidth, t.Width()-cx-11)
+	if n < 8 {
+		return
+	}
+	mode, _, _, speed, direction := a.auraArgs()
+	elapsed := 1.0 // a still frame without animations
+	if a.auraAnim.stop != nil {
+		elapsed = time.Since(a.auraAnim.start).Seconds()
+	}
+	switch speed {
+	case "low":
+		elapsed /= 2
+	case "high":
+		elapsed *= 2
+	}
+
+	t.Text(cx, y, ColTextDim, "Preview:")
+	t.MoveTo(cx+9, y)
+	c1, c2 := a.selectedAuraColour(0).Rgb, a.selectedAuraColour(1).Rgb
+	zoned := a.auraZoned()
+	for i := 0; i < n; i++ {
+		if zoned {
+			c1 = a.zoneColour(i * a.auraZones / n).Rgb
+		}
+		t.Bg(auraPreviewCell(mode, direction, c1, c2, i, n, elapsed))
+		t.Write(" ")
+	}
+	t.ResetStyle()
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// Aura sharing — e on the Aura tab writes the selected effect (mode,
+// colours, speed, direction) and the keyboard brightness to a small JSON
+// file, i reads one back, so a lighting setup can be passed to another
+// machine or posted with a dotfile. An imported effect is selected like a
+// favourite and applied with a; its brightness is set at once, as the
+// Brightness row does. p keeps it as a favourite.
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// auraShare is the file format. Fields the effect ignores are left out.
+type auraShare struct {
+	Name       string `json:"name,omitempty"`
+	Mode       string `json:"mode"`
+	Colour1    string `json:"colour1,omitempty"`
+	Colour2    string `json:"colour2,omitempty"`
+	Speed      string `json:"speed,omitempty"`
+	Direction  string `json:"direction,omitempty"`
+	Brightness string `json:"brightness,omitempty"`
+}
+
+// expandHome turns a leading ~/ into the home directory.
+func expandHome(path string) string {
+	if rest, ok := strings.CutPrefix(path, "~/"); ok {
+		home, _ := os.UserHomeDir()
+		return filepath.Join(home, rest)
+	}
+	return path
+}
+
+// parseAuraShare reads a shared effect. A file without a name is named
+// fallback.
+func parseAuraShare(data []byte, fallback string) (AuraPreset, string, error) {
+	var s auraShare
+	if err := json.Unmarshal(data, &s); err != nil {
+		return AuraPreset{}, "", fmt.Errorf("not an Aura preset: %w", err)
+	}
+	if s.Name == "" {
+		s.Name = fallback
+	}
+	if s.Mode == "" {
+		return AuraPreset{}, "", errors.New("mode: missing")
+	}
+	p := AuraPreset{Name: s.Name, Mode: s.Mode, Colour1: strings.TrimPrefix(s.Colour1, "#"), Colour2: strings.TrimPrefix(s.Colour2, "#"), Speed: s.Speed, Direction: s.Direction}
+	if err := p.Validate(); err != nil {
+		return AuraPreset{}, "", err
+	}
+	if s.Brightness != "" && indexFold(kbdValues, s.Brightness) < 0 {
+		return AuraPreset{}, "", fmt.Errorf("brightness: %q must be off, low, med or high", s.Brightness)
+	}
+	return p, strings.ToLower(s.Brightness), nil
+}
+
+// shareName is the name written with the selection: the favourite last
+// saved or selected, else the effect.
+func (a *App) shareName() string {
+	if a.auraPreset != "" {
+		return a.auraPreset
+	}
+	return auraModes[a.auraMode]
+}
+
+// exportAura writes the Aura tab's selection to path.
+func (a *App) exportAura(path string) {
+	mode, colour1, colour2, speed, direction := a.auraArgs()
+	data, _ := json.MarshalIndent(auraShare{
+		Name: a.shareName(), Mode: mode, Colour1: colour1, Colour2: colour2,
+		Speed: speed, Direction: direction, Brightness: kbdValues[a.kbdLevel],
+	}, "", "  ")
+	if err := os.WriteFile(expandHome(path), append(data, '\n'), 0o644); err != nil {
+		a.SetStatus("Export: "+err.Error(), false)
+		return
+	}
+	a.SetStatus("Aura "+mode+" exported to "+path, true)
+}
+
+// promptAuraExport asks where to write the selection, offering a file in
+// the home directory named after it.
+func (a *App) promptAuraExport() {
+	slug := strings.ReplaceAll(strings.ToLower(strings.TrimSpace(a.shareName())), " ", "-")
+	a.prompt = &Prompt{
+		Title: "Export Aura effect",
+		Label: "JSON file to write",
+		OnOk: func(path string) {
+			if _, err := os.Stat(expandHome(path)); err == nil {
+				a.ask(&Confirm{
+					Title: "Overwrite " + filepath.Base(path) + "?",
+					Lines: []string{path + " already exists."},
+					OnYes: func() { a.exportAura(path) },
+				})
+				return
+			}
+			a.exportAura(path)
+		},
+	}
+	a.prompt.Input.Set("~/aura-" + slug + ".json")
+}
+
+// promptAuraImport reads a shared effect from a file or pasted JSON and
+// selects it. Like a favourite, it is only applied with a.
+func (a *App) promptAuraImport() {
+	a.prompt = &Prompt{
+		Title: "Import Aura effect",
+		Label: "JSON file path, or the JSON itself",
+		OnOk: func(text string) {
+			data, src := []byte(text), "pasted preset"
+			if !strings.HasPrefix(strings.TrimSpace(text), "{") {
+				path := expandHome(text)
+				var err error
+				if data, err = os.ReadFile(path); err != nil {
+					a.SetStatus("Import: "+err.Error(), false)
+					return
+				}
+				src = filepath.Base(path)
+			}
+			name := strings.TrimSuffix(src, filepath.Ext(src))
+			p, brightness, err := parseAuraShare(data, name)
+			if err != nil {
+				a.SetStatus("Import: "+err.Error(), false)
+				return
+			}
+			if mode := indexFold(auraModes, p.Mode); !a.auraModeOK(mode) {
+				a.SetStatus("Import: "+auraModes[mode]+" is not supported by this keyboard", false)
+				return
+			}
+			a.selectAuraPreset(p)
+			a.auraEdited()
+			a.auraEnterSection(0)
+			if i := indexFold(kbdValues, brightness); i >= 0 && i != a.kbdLevel {
+				a.setKbdLevel(i)
+			}
+			a.SetStatus("Imported "+p.Name+" from "+src+"; press a to apply, p to keep it as a favourite", true)
+		},
+	}
+}
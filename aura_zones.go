@@ -0,0 +1,241 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// Aura zones — on 4-zone keyboards the Aura tab gets a Zones row below the
+// colour: a strip with each zone in its colour, read from asusd's config at
+// start. Enter on a zone paints it with the Colour row's colour and Enter on
+// All puts every zone back on it. While any zone has its own colour,
+// applying sends the effect once per zone with `--zone N`, and scenes and
+// favourites save the zones with the effect.
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// maxAuraZones is the most zones a keyboard has (Key1–Key4 in asusd).
+const maxAuraZones = 4
+
+// parseAuraZones counts the keyboard zones named in asusd's aura config:
+// its multizone block lists them as Key1…Key4. 0 means one zone.
+func parseAuraZones(ron string) int {
+	n := 0
+	for i := 1; i <= maxAuraZones; i++ {
+		if strings.Contains(ron, "Key"+strconv.Itoa(i)) {
+			n = i
+		}
+	}
+	if n < 2 {
+		return 0
+	}
+	return n
+}
+
+// checkZones checks a scene's or favourite's zone colours.
+func checkZones(zones []string) error {
+	if len(zones) > maxAuraZones {
+		return fmt.Errorf("zones: %d colours, a keyboard has at most %d zones", len(zones), maxAuraZones)
+	}
+	for _, hex := range zones {
+		if _, _, _, ok := parseHexColour(hex); !ok {
+			return fmt.Errorf("zones: %q is not an rrggbb colour", hex)
+		}
+	}
+	return nil
+}
+
+// parseAuraRonZones reads the zone colours of mode from asusd's aura
+// config while multizone is on: colour1 of each Key1…Key4 effect, as
+// "rrggbb" in zone order. Nil when the keyboard shows one colour.
+func parseAuraRonZones(ron, mode string) []string {
+	doc, err := parseRon(ron)
+	if err != nil || doc.Field("multizone_on").name() != "true" {
+		return nil
+	}
+	multi := doc.Field("multizone")
+	if multi != nil && multi.Name == "Some" && len(multi.Items) == 1 {
+		multi = multi.Items[0]
+	}
+	var zones [maxAuraZones]string
+	n := 0
+	for _, effect := range multi.Field(mode).items() {
+		i, err := strconv.Atoi(strings.TrimPrefix(effect.Field("zone").name(), "Key"))
+		c := effect.Field("colour1")
+		if err != nil || i < 1 || i > maxAuraZones || c == nil {
+			continue
+		}
+		rgb := make([]any, 3)
+		for j, key := range []string{"r", "g", "b"} {
+			rgb[j], _ = strconv.Atoi(c.Field(key).name())
+		}
+		zones[i-1] = fmt.Sprintf("%02x%02x%02x", rgb...)
+		n = max(n, i)
+	}
+	if n < 2 {
+		return nil
+	}
+	for _, z := range zones[:n] {
+		if z == "" {
+			return nil
+		}
+	}
+	return zones[:n]
+}
+
+func (b *ExecBackend) GetAuraZones() int {
+	ron, ok := readAuraRon()
+	if !ok {
+		return 0
+	}
+	return parseAuraZones(ron)
+}
+
+// SetAuraZones applies mode once per zone, zone i+1 in colours[i], and
+// stops at the first failure.
+func (b *ExecBackend) SetAuraZones(mode string, colours []string, colour2, speed, direction string) (bool, string) {
+	subcmd := strings.ToLower(strings.ReplaceAll(mode, " ", "-"))
+	var outs []string
+	for i, c := range colours {
+		args := append(argsFor(b.major, "aura.effect", subcmd), "--colour", c)
+		if colour2 != "" {
+			args = append(args, "--colour2", colour2)
+		}
+		if speed != "" {
+			args = append(args, "--speed", speed)
+		}
+		args = append(args, auraDirectionArgs(subcmd, direction)...)
+		ok, out := b.run(append(args, "--zone", strconv.Itoa(i+1))...)
+		if out != "" {
+			outs = append(outs, out)
+		}
+		if !ok {
+			return false, strings.Join(outs, "\n")
+		}
+	}
+	return true, strings.Join(outs, "\n")
+}
+
+// auraZoneRow reports whether the Zones row is shown: a multizone keyboard
+// and an effect with a colour.
+func (a *App) auraZoneRow() bool {
+	return a.auraZones > 0 && auraEffectNeedsColour1(auraModes[a.auraMode])
+}
+
+// zoneColour is the colour zone i (from 0) shows: its own, or Colour's.
+func (a *App) zoneColour(i int) AuraColour {
+	if c := a.auraZoneColour[i]; c.Hex != "" {
+		return c
+	}
+	return a.selectedAuraColour(0)
+}
+
+// zoneSwatch is the swatch for colour hex: a palette swatch of that colour,
+// else one named by its hex value.
+func (a *App) zoneSwatch(hex string) (AuraColour, bool) {
+	r, g, b, ok := parseHexColour(hex)
+	if !ok {
+		return AuraColour{}, false
+	}
+	for _, c := range a.basePalette() {
+		if c.Rgb == (Color{r, g, b}) {
+			return c, true
+		}
+	}
+	hex = strings.ToLower(hex)
+	return AuraColour{Name: "#" + hex, Hex: hex, Rgb: Color{r, g, b}}, true
+}
+
+// setZoneColours gives the zones the colours in hexes, in zone order, from
+// the hardware, a scene or a favourite. Zones in the Colour row's colour
+// and zones past the list follow that row; no colours put every zone on it.
+func (a *App) setZoneColours(hexes []string) {
+	a.auraZoneColour = [maxAuraZones]AuraColour{}
+	for i, hex := range hexes[:min(len(hexes), maxAuraZones)] {
+		if c, ok := a.zoneSwatch(hex); ok && c.Rgb != a.selectedAuraColour(0).Rgb {
+			a.auraZoneColour[i] = c
+		}
+	}
+}
+
+// savedZones are the zone colours scenes and favourites keep: one per
+// zone while any zone has its own colour, else none.
+func (a *App) savedZones() []string {
+	if !a.auraZoned() {
+		return nil
+	}
+	return a.zoneColours()
+}
+
+// auraZoned reports whether applying goes zone by zone.
+func (a *App) auraZoned() bool {
+	if !a.auraZoneRow() {
+		return false
+	}
+	for _, c := range a.auraZoneColour[:a.auraZones] {
+		if c.Hex != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// zoneColours are the SetAuraZones colours, one per zone.
+func (a *App) zoneColours() []string {
+	out := make([]string, a.auraZones)
+	for i := range out {
+		out[i] = a.zoneColour(i).Hex
+	}
+	return out
+}
+
+// selectAuraZone paints zone i (from 1) with the Colour row's colour, or
+// with i = 0 puts all zones back on it.
+func (a *App) selectAuraZone(i int) {
+	c := a.selectedAuraColour(0)
+	if i == 0 {
+		a.auraZoneColour = [maxAuraZones]AuraColour{}
+		a.SetStatus("All zones → "+c.Name+"; press a to apply", true)
+	} else {
+		a.auraZoneColour[i-1] = c
+		a.SetStatus("Zone "+strconv.Itoa(i)+" → "+c.Name+"; press a to apply", true)
+	}
+	a.auraEdited()
+}
+
+// renderAuraZones draws the Zones row: All, then each zone in its colour.
+func (a *App) renderAuraZones(y int) {
+	t := a.term
+	cx := a.padX()
+	t.Text(cx, y, ColTextDim, "Zones:")
+	for i := 0; i <= a.auraZones; i++ {
+		focused := a.auraSection == 5 && a.focusIdx == i
+		label := " All "
+		px := cx + 9
+		t.ResetStyle()
+		if i == 0 {
+			t.Fg(ColTextDim)
+			if focused {
+				t.Fg(ColText)
+			}
+		} else {
+			label = "   " + strconv.Itoa(i) + "   "
+			px = cx + 15 + (i-1)*8
+			c := a.zoneColour(i - 1).Rgb
+			t.Bg(c)
+			t.Fg(Color{255, 255, 255})
+			if c.R*3+c.G*6+c.B > 1280 {
+				t.Fg(Color{0, 0, 0})
+			}
+			t.Bold()
+		}
+		t.MoveTo(px, y)
+		if focused {
+			a.writeFocused("▸" + label[1:])
+		} else {
+			t.Write(label)
+		}
+	}
+	t.ResetStyle()
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// multizoneRon is asusd's aura config with Static set zone by zone.
+const multizoneRon = `(
+    current_mode: Static,
+    builtins: {
+        Static: (mode: Static, zone: None, colour1: (r: 255, g: 0, b: 0), colour2: (r: 0, g: 0, b: 0), speed: Med, direction: Right),
+    },
+    multizone: Some({
+        Static: [
+            (mode: Static, zone: Key2, colour1: (r: 0, g: 255, b: 0), colour2: (r: 0, g: 0, b: 0), speed: Med, direction: Right),
+            (mode: Static, zone: Key1, colour1: (r: 255, g: 0, b: 0), colour2: (r: 0, g: 0, b: 0), speed: Med, direction: Right),
+            (mode: Static, zone: Key3, colour1: (r: 0, g: 0, b: 255), colour2: (r: 0, g: 0, b: 0), speed: Med, direction: Right),
+            (mode: Static, zone: Key4, colour1: (r: 16, g: 32, b: 48), colour2: (r: 0, g: 0, b: 0), speed: Med, direction: Right),
+        ],
+    }),
+    multizone_on: true,
+)`
+
+func TestParseAuraRonZones(t *testing.T) {
+	tests := []struct {
+		name string
+		ron  string
+		mode string
+		want []string
+	}{
+		{"zoned", multizoneRon, "Static", []string{"ff0000", "00ff00", "0000ff", "102030"}},
+		{"other mode", multizoneRon, "Breathe", nil},
+		{"multizone off", strings.Replace(multizoneRon, "multizone_on: true", "multizone_on: false", 1), "Static", nil},
+		{"zone missing", strings.Replace(multizoneRon, "zone: Key3", "zone: None", 1), "Static", nil},
+		{"no multizone", auraRonSample, "Static", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseAuraRonZones(tt.ron, tt.mode); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("zones = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestSceneKeepsZones checks a scene saves the zone colours and applying
+// it puts them back on the keyboard and in the Zones strip.
+func TestSceneKeepsZones(t *testing.T) {
+	m := NewMockBackend()
+	a := NewApp(NewFakeTerminal(80, 24, io.Discard), m, DefaultConfig())
+	a.auraZones = maxAuraZones
+	a.auraMode = indexFold(auraModes, "Static")
+	want := []string{"ff0000", "00ff00", "0000ff", "102030"}
+	a.setZoneColours(want)
+	sc := a.captureScene("zones")
+	if !reflect.DeepEqual(sc.Zones, want) {
+		t.Fatalf("captured zones = %v, want %v", sc.Zones, want)
+	}
+	if err := sc.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	a.setZoneColours(nil)
+	a.applyScene(sc, nil)
+	drainQueue(t, a)
+	if got := a.zoneColours(); !reflect.DeepEqual(got, want) {
+		t.Errorf("zone strip = %v, want %v", got, want)
+	}
+	if got := m.GetAuraState().Zones; !reflect.DeepEqual(got, want) {
+		t.Errorf("keyboard zones = %v, want %v", got, want)
+	}
+	if got := a.auraAppliedZones; !reflect.DeepEqual(got, want) {
+		t.Errorf("applied zones = %v, want %v", got, want)
+	}
+}
+
+func TestCheckZones(t *testing.T) {
+	if err := checkZones([]string{"ff0000", "red"}); err == nil || !strings.Contains(err.Error(), "zones:") {
+		t.Errorf("err = %v, want a zones error", err)
+	}
+	if err := checkZones(make([]string, maxAuraZones+1)); err == nil {
+		t.Error("five zones accepted")
+	}
+}
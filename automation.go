@@ -0,0 +1,142 @@
+package main
+
+import "time"
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// Automation — scheduled and event-driven policies on the main loop
+// A ticker posts runAutomation so each policy reads and changes App state
+// like a key handler would; hotplug events are posted the same way. Policies
+// undo their changes in Shutdown. The Automation tab lists them and what
+// they did.
+// ═══════════════════════════════════════════════════════════════════════════════
+
+const (
+	automationEvery     = 30 * time.Second
+	maxAutomationEvents = 100
+)
+
+// automationEvent is one action a policy took, for the Automation tab.
+type automationEvent struct {
+	Time   string
+	What   string
+	Output string
+	Ok     bool
+}
+
+type automationState struct {
+	stop   chan struct{}
+	events []automationEvent
+
+	displays     []string // connected external connectors
+	displaysRead bool     // displays holds a reading to diff against
+	ruleFired    map[int]string
+
+	games        int    // games registered with Feral GameMode
+	gameWatchErr string // why game detection is not running
+
+	auraProfile string // profile last followed or held, see aura_follow.go
+
+	acOnline  bool   // charger connected at the last poll
+	acRead    bool   // acOnline holds a reading to diff against
+	acChanged string // time of the last plug or unplug
+
+	wifiAuto bool   // --daemon: pick the workspace by Wi-Fi network
+	ssid     string // network at the last poll, "" when not connected
+}
+
+// startAutomation evaluates the policies now and then every automationEvery.
+func (a *App) startAutomation() {
+	a.automation.stop = make(chan struct{})
+	stop := a.automation.stop
+	wifi := a.automation.wifiAuto
+	go func() {
+		tick := time.NewTicker(automationEvery)
+		defer tick.Stop()
+		for {
+			a.Post(a.runAutomation)
+			if wifi {
+				// nmcli can take a while; ask it here, not on the main loop
+				ssid, _ := a.backend.GetWifiSSID()
+				a.Post(func() { a.checkWifi(ssid) })
+			}
+			select {
+			case <-stop:
+				return
+			case <-tick.C:
+			}
+		}
+	}()
+	go a.watchPowerSource(stop)
+	go a.watchRules(stop)
+	a.automation.auraProfile = a.profile // the start is no change
+	err := a.backend.WatchDisplays(func() {
+		a.Post(a.checkDisplays)
+	})
+	if err != nil {
+		a.addLog("display hotplug", "polling instead: "+err.Error(), false)
+	}
+	if a.cfg.GameMode.Feral {
+		err := a.backend.WatchGames(func(games int) {
+			a.Post(func() { a.onGamesChanged(games) })
+		})
+		if err != nil {
+			a.automation.gameWatchErr = err.Error()
+			a.addLog("game detection", err.Error(), false)
+		}
+	}
+}
+
+func (a *App) stopAutomation() {
+	if a.automation.stop != nil {
+		close(a.automation.stop)
+		a.automation.stop = nil
+	}
+}
+
+func (a *App) runAutomation() {
+	a.checkQuietHours(time.Now())
+	a.checkDisplays() // catches hotplug when uevents are unavailable
+}
+
+// automationProfile queues a switch to p on behalf of a policy, unless
+// quiet hours or lockdown hold the profile. then, if set, runs once the
+// switch is made.
+func (a *App) automationProfile(what, p string, then func()) {
+	switch {
+	case a.quiet.active:
+		a.automationLog(what+": profile "+p, "held back by quiet hours", false)
+	case a.cfg.Lockdown.ActionLocked("profile"):
+		a.automationLog(what+": profile "+p, lockActions["profile"]+" is disabled by your administrator", false)
+	default:
+		a.submit("profile", func(b Backend) (bool, string) {
+			return b.SetProfile(p)
+		}, func(ok bool, out string, argv []string) {
+			a.automationLog(what+": profile "+p, out, ok)
+			if !ok {
+				return
+			}
+			a.profile = p
+			a.loadFanCurves()
+			a.followProfile()
+			if then != nil {
+				then()
+			}
+		})
+	}
+}
+
+// automationLog records what a policy did in the console and the
+// Automation tab.
+func (a *App) automationLog(what, out string, ok bool) {
+	a.addLog(what, out, ok)
+	au := &a.automation
+	au.events = append(au.events, automationEvent{
+		Time:   time.Now().Format("15:04:05"),
+		What:   what,
+		Output: out,
+		Ok:     ok,
+	})
+	if len(au.events) > maxAutomationEvents {
+		au.events = au.events[len(au.events)-maxAutomationEvents:]
+	}
+}
@@ -0,0 +1,175 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// Page: Automation
+// ═══════════════════════════════════════════════════════════════════════════════
+
+func (a *App) renderAutomation(y, h int) {
+	t := a.term
+	W := t.Width()
+	cx := a.padX()
+	au := &a.automation
+
+	t.TextBold(cx, y+1, ColText, "Automation")
+	t.Text(cx, y+2, ColTextDim, "Policies from config.toml, enforced while the app runs (or headless with --daemon)")
+
+	// Quiet hours
+	row := y + 4
+	t.TextBold(cx, row, a.accent(), "Quiet Hours")
+	qh := a.cfg.QuietHours
+	if !qh.Enabled() {
+		t.Text(cx+2, row+1, ColTextMut, "Not configured — add [quiet_hours] start and end")
+	} else {
+		t.Text(cx+2, row+1, ColText, fmt.Sprintf("%s–%s · %s profile, fans ≤ %d%%", qh.Start, qh.End, quietProfile, qh.MaxFan))
+		st, col := "waiting", ColTextMut
+		switch {
+		case a.quiet.active:
+			st, col = "● active", ColWarning
+		case a.quiet.overridden:
+			st, col = "overridden", ColTextDim
+		}
+		t.Text(cx+60, row+1, col, st)
+	}
+
+	// Game detection
+	row += 3
+	t.TextBold(cx, row, a.accent(), "Game Detection")
+	gm := a.cfg.GameMode
+	switch {
+	case !gm.Feral:
+		t.Text(cx+2, row+1, ColTextMut, "Off — set feral_gamemode = true under [game_mode]")
+	default:
+		what := "Feral GameMode game → game mode"
+		if p := matchProfile(gm.Profile); p != "" {
+			what += ", " + p + " profile"
+		}
+		t.Text(cx+2, row+1, ColText, what)
+		st, col := "no games", ColTextMut
+		switch {
+		case a.automation.gameWatchErr != "":
+			st, col = "unavailable", ColError
+		case a.automation.games > 0:
+			st, col = fmt.Sprintf("● %d running", a.automation.games), ColWarning
+		}
+		t.Text(cx+60, row+1, col, st)
+	}
+
+	// Power source
+	row += 3
+	t.TextBold(cx, row, a.accent(), "Power Source")
+	ps := a.cfg.PowerSource
+	if ps.ACProfile == "" && ps.BatteryProfile == "" {
+		t.Text(cx+2, row+1, ColTextMut, "Notify only — set ac_profile or battery_profile under [power_source]")
+	} else {
+		t.Text(cx+2, row+1, ColText, fmt.Sprintf("charger in → %s, on battery → %s",
+			orDash(matchProfile(ps.ACProfile)), orDash(matchProfile(ps.BatteryProfile))))
+	}
+	st, col := "unknown", ColTextMut
+	switch {
+	case au.acRead && au.acOnline:
+		st, col = "● on AC", ColSuccess
+	case au.acRead:
+		st, col = "on battery", ColTextDim
+	}
+	if au.acChanged != "" {
+		st += " since " + au.acChanged
+	}
+	t.Text(cx+60, row+1, col, st)
+
+	// Aura follows profile
+	row += 3
+	t.TextBold(cx, row, a.accent(), "Aura Follows Profile")
+	if ap := a.cfg.AuraProfiles; !ap.Enabled() {
+		t.Text(cx+2, row+1, ColTextMut, "Off — name an Aura favourite per profile under [aura_profiles]")
+	} else {
+		var parts []string
+		for _, p := range profileNames {
+			parts = append(parts, p+" → "+orDash(ap.For(p)))
+		}
+		t.Text(cx+2, row+1, ColText, strings.Join(parts, ", "))
+	}
+
+	// Rules
+	row += 3
+	t.TextBold(cx, row, a.accent(), "Rules")
+	if len(a.cfg.Workspaces) > 0 {
+		ws := "workspace: " + orDash(a.workspace) + " (Ctrl-P to switch)"
+		if a.automation.wifiAuto {
+			ws += " · Wi-Fi " + orDash(a.automation.ssid)
+		}
+		t.Text(cx+16, row, ColTextDim, ws)
+	}
+	if len(a.cfg.Rules) == 0 {
+		t.Text(cx+2, row+1, ColTextMut, "None — add [[rules]] with when = \"battery\", \"ac\", \"profile\" or \"battery_below\"")
+		row++
+	}
+	for i, r := range a.cfg.Rules {
+		row++
+		if !a.ruleActive(r) {
+			t.Text(cx+2, row, ColTextMut, r.Describe()+" ("+r.Workspace+" only)")
+			continue
+		}
+		t.Text(cx+2, row, ColText, r.Describe())
+		if at, ok := a.rules.fired[i]; ok {
+			t.Text(cx+60, row, ColTextDim, "last "+at)
+		}
+	}
+
+	// Display rules
+	row += 2
+	t.TextBold(cx, row, a.accent(), "Display Rules")
+	conn := "none"
+	if len(au.displays) > 0 {
+		conn = strings.Join(au.displays, ", ")
+	}
+	t.Text(cx+16, row, ColTextDim, "external displays: "+conn)
+	if len(a.cfg.DisplayRules) == 0 {
+		t.Text(cx+2, row+1, ColTextMut, "None — add [[display_rules]] with on = \"attach\" or \"detach\"")
+		row += 2
+	}
+	for i, r := range a.cfg.DisplayRules {
+		row++
+		t.Text(cx+2, row, ColText, r.Describe())
+		if at, ok := au.ruleFired[i]; ok {
+			t.Text(cx+60, row, ColTextDim, "last "+at)
+		}
+	}
+	if len(a.cfg.DisplayRules) > 0 {
+		row++
+	}
+
+	// Activity, newest first
+	row += 1
+	t.TextBold(cx, row, a.accent(), "Activity")
+	t.HLine(cx, row+1, min(W-6, 70), ColBorder)
+	listY := row + 2
+	listH := max(y+h-1-listY, 1)
+	if len(au.events) == 0 {
+		t.Text(cx, listY, ColTextMut, "Nothing has run yet")
+	}
+	for i := 0; i < listH && i < len(au.events); i++ {
+		e := au.events[len(au.events)-1-i]
+		mark, col := "✓", ColSuccess
+		if !e.Ok {
+			mark, col = "✗", ColError
+		}
+		line := e.Time + " " + mark + " " + e.What
+		if e.Output != "" {
+			line += " — " + e.Output
+		}
+		t.Text(cx, listY+i, col, pad(line, W-cx-2))
+	}
+	t.Text(cx, y+h-1, ColTextMut, "Ctrl-O override quiet hours  c clear activity")
+}
+
+func (a *App) handleAutomation(key KeyEvent) {
+	if key.Type == KeyChar && key.Char == 'c' {
+		a.automation.events = nil
+		a.SetStatus("Automation activity cleared", true)
+	}
+}
@@ -1,12 +1,14 @@
 package main
 
 import (
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
 	"os/exec"
-	"path/filepath"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -14,19 +16,337 @@ import (
 // AsusCtl Backend — wraps the asusctl CLI
 // ═══════════════════════════════════════════════════════════════════════════════
 
-type Backend struct{}
+// Backend is everything the UI needs from the hardware layer, composed of
+// one interface per feature area. ExecBackend shells out to asusctl;
+// MockBackend simulates a laptop. Providers are picked at runtime by name
+// (see backendProviders), so further implementations — D-Bus, sysfs
+// fallbacks, test mocks — can coexist.
+type Backend interface {
+	IsInstalled() bool
+	Version() string
+
+	ProfileControl
+	KeyboardControl
+	BatteryControl
+	AuraControl
+	AuraPowerControl
+	FanControl
+	ArmouryControl
+	GpuControl
+	MatrixControl
+	PlatformControl
+	ServiceControl
+	LogControl
+	ThermalControl
+	DisplayControl
+	SensorControl
+	HotkeyControl
+	GameWatcher
+	InhibitControl
+	NetworkControl
+	RawControl
+	ChangeWatcher
+}
+
+type ProfileControl interface {
+	GetProfileInfo() (ProfileInfo, error)
+	GetProfile() string
+	SetProfile(p string) (bool, string)
+	NextProfile() (bool, string)
+	ListProfiles() (bool, string)
+	// ProfileSource names the service behind the profile calls.
+	ProfileSource() string
+}
+
+type KeyboardControl interface {
+	GetLedState() (LedState, error)
+	GetKbdBrightness() string
+	SetKbdBrightness(level string) (bool, string)
+	NextKbdBrightness() (bool, string)
+	PrevKbdBrightness() (bool, string)
+	SetKbdSleepLighting(on bool) (bool, string)
+}
+
+type BatteryControl interface {
+	GetBatteryInfo() (BatteryInfo, error)
+	GetChargeLimit() int
+	SetChargeLimit(pct int) (bool, string)
+	ToggleOneShotCharge() (bool, string)
+	// GetBatteryStatus reads the battery's live state from sysfs.
+	GetBatteryStatus() BatteryStatus
+	// GetChargeThreshold reads the kernel's charge_control_end_threshold,
+	// which asusd raises to 100 while a one-shot charge is armed.
+	GetChargeThreshold() (pct int, ok bool)
+	// GetACOnline reports whether the charger is connected; ok is false
+	// when there is no AC adapter in sysfs.
+	GetACOnline() (online, ok bool)
+}
+
+type AuraControl interface {
+	GetAuraState() *AuraState
+	// SetAuraMode applies an effect; options it does not use are empty.
+	SetAuraMode(mode, colour1, colour2, speed, direction string) (bool, string)
+	NextAuraMode() (bool, string)
+	PrevAuraMode() (bool, string)
+	// GetAuraModes lists the effects the keyboard supports, as auraModes
+	// names; nil when that cannot be found out.
+	GetAuraModes() []string
+	// GetAuraZones is the number of zones the keyboard is lit in, 0 when
+	// it is one. See aura_zones.go.
+	GetAuraZones() int
+	// SetAuraZones applies mode with one colour per zone, in zone order.
+	SetAuraZones(mode string, colours []string, colour2, speed, direction string) (bool, string)
+	// SetAuraPerKey lights each key in its own colour, one "rrggbb" per
+	// key in perKeyLayout order. See aura_perkey.go.
+	SetAuraPerKey(colours []string) (bool, string)
+	// WriteAuraOffline edits asusd's aura config file directly, for when
+	// asusd is not running; it takes effect on asusd's next start.
+	WriteAuraOffline(mode, colour1, colour2, speed, direction string) (bool, string)
+}
+
+type FanControl interface {
+	GetFanCurves(profile string) (bool, string)
+	SetFanCurve(fan, profile, data string) (bool, string)
+	EnableFanCurves(profile string, enable bool) (bool, string)
+	GetFanEnabled() bool
+	ReadFanCurves(profile string) (FanCurves, error)
+}
+
+// ArmouryControl covers firmware attributes stored in UEFI variables.
+type ArmouryControl interface {
+	// ListArmoury runs `asusctl armoury list`; see parseArmouryList.
+	ListArmoury() (bool, string)
+	GetArmoury(attr string) (bool, string)
+	SetArmoury(attr, value string) (bool, string)
+	GetPanelOverdrive() (bool, string)
+	SetPanelOverdrive(on bool) (bool, string)
+	GetGpuMux() (bool, string)
+	SetGpuMux(dedicated bool) (bool, string)
+}
+
+// MatrixControl covers the AniMe and Slash lid displays.
+type MatrixControl interface {
+	SetAnimeEnable(on bool) (bool, string)
+	// SetAnimeImage shows a PNG on the AniMe display, one pixel per LED.
+	SetAnimeImage(path string) (bool, string)
+	ClearAnime() (bool, string)
+	SetSlashEnable(on bool) (bool, string)
+	// GetSlashState reads asusd's saved Slash settings; Present is false on
+	// laptops without a Slash bar.
+	GetSlashState() SlashState
+	SetSlashBrightness(level int) (bool, string)
+	SetSlashInterval(interval int) (bool, string)
+	SetSlashMode(mode string) (bool, string)
+	// SlashHelp returns `asusctl slash --help`, which lists the built-in modes.
+	SlashHelp() (bool, string)
+}
+
+type RawControl interface {
+	GetSupported() (bool, string)
+	RunRaw(args string) (bool, string)
+
+	// LastCommand and RunElevated let the UI retry a command that asusd
+	// refused through pkexec. Both use the full argv, binary first.
+	LastCommand() []string
+	RunElevated(argv ...string) (bool, string)
+	// Session returns the same backend with a LastCommand of its own, so a
+	// goroutine can read back what its calls ran while others use the
+	// backend too.
+	Session() Backend
+}
+
+// backendProviders lists the selectable Backend implementations.
+var backendProviders = []struct {
+	Name string
+	Desc string
+	New  func(o BackendOptions) Backend
+}{
+	{"asusctl", "run the asusctl CLI (default)", func(o BackendOptions) Backend {
+		c := NewCachedBackend(NewBackendWithOptions(o))
+		c.force = o.Force
+		return c
+	}},
+	{"mock", "simulated laptop, no hardware needed", func(BackendOptions) Backend { return NewMockBackend() }},
+}
+
+// BackendOptions are the user-tunable settings passed to a provider.
+type BackendOptions struct {
+	Policy CommandPolicy
+	// AsusctlBin is the asusctl executable: a name looked up on PATH or a path
+	AsusctlBin string
+	// Force writes every setting even when it was just written with the
+	// same value (--force)
+	Force bool
+}
+
+// asusctlBinEnv overrides the asusctl executable when --asusctl-bin is unset.
+const asusctlBinEnv = "ASUSCTL_BIN"
+
+// NewBackendByName returns the provider registered under name.
+func NewBackendByName(name string, opts BackendOptions) (Backend, error) {
+	var names []string
+	for _, p := range backendProviders {
+		if p.Name == name {
+			return p.New(opts), nil
+		}
+		names = append(names, p.Name)
+	}
+	return nil, fmt.Errorf("unknown backend %q (available: %s)", name, strings.Join(names, ", "))
+}
+
+// CommandPolicy controls how long an asusctl call may take and how
+// transient failures (asusd restarting, a hung call) are retried.
+type CommandPolicy struct {
+	Timeout time.Duration
+	Retries int           // extra attempts after a transient failure
+	Backoff time.Duration // wait before the first retry, doubled after each
+}
+
+var defaultCommandPolicy = CommandPolicy{
+	Timeout: 5 * time.Second,
+	Retries: 1,
+	Backoff: 500 * time.Millisecond,
+}
+
+// ExecBackend runs the asusctl binary for every call.
+type ExecBackend struct {
+	*execState
+	rec *commandRecord // per session, see Session
+}
+
+// execState is what an ExecBackend's sessions share.
+type execState struct {
+	version string // raw `asusctl --version` output
+	major   int    // detected major version, 0 if unknown
+	policy  CommandPolicy
+	bin     string // asusctl executable
+
+	ppdOnce sync.Once
+	ppd     bool // profiles go through power-profiles-daemon
+
+	// private directory staging the last offline Aura write, kept after
+	// a failed copy so a pkexec retry can find the file; see aura_offline.go
+	auraStage string
+}
+
+// commandRecord holds the argv LastCommand reports.
+type commandRecord struct {
+	mu   sync.Mutex // startup reads run concurrently
+	last []string
+}
+
+func (r *commandRecord) set(argv []string) {
+	r.mu.Lock()
+	r.last = argv
+	r.mu.Unlock()
+}
+
+func (r *commandRecord) get() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.last
+}
+
+func NewBackend() *ExecBackend {
+	return NewBackendWithOptions(BackendOptions{Policy: defaultCommandPolicy})
+}
+
+func NewBackendWithOptions(o BackendOptions) *ExecBackend {
+	b := &ExecBackend{execState: &execState{policy: o.Policy, bin: o.AsusctlBin}, rec: &commandRecord{}}
+	if b.bin == "" {
+		b.bin = os.Getenv(asusctlBinEnv)
+	}
+	if b.bin == "" {
+		b.bin = "asusctl"
+	}
+	b.detectVersion()
+	return b
+}
+
+// detectVersion probes the installed asusctl so argsFor can pick the right
+// CLI syntax for this version.
+func (b *ExecBackend) detectVersion() {
+	if !b.IsInstalled() {
+		return
+	}
+	if ok, out := b.run("--version"); ok {
+		b.version = out
+		b.major = parseCliVersion(out)
+	}
+}
+
+func (b *ExecBackend) Version() string {
+	if b.version == "" {
+		return "unknown"
+	}
+	return b.version
+}
+
+// steppingOps move the state on from wherever it is rather than set it, so
+// running one twice is not the same as once. A call that timed out may
+// still have landed; these are never retried.
+var steppingOps = map[string]bool{
+	"profile.next":    true,
+	"leds.next":       true,
+	"leds.prev":       true,
+	"battery.oneshot": true,
+	"aura.next":       true,
+	"aura.prev":       true,
+}
+
+// runOp runs a versioned operation from cliArgTable.
+func (b *ExecBackend) runOp(op string, params ...string) (bool, string) {
+	args := argsFor(b.major, op, params...)
+	if steppingOps[op] {
+		return b.runTries(0, b.bin, args...)
+	}
+	return b.run(args...)
+}
+
+func (b *ExecBackend) run(args ...string) (bool, string) {
+	return b.runBin(b.bin, args...)
+}
 
-func NewBackend() *Backend {
-	return &Backend{}
+// runBin runs any helper binary under the command policy and records it
+// for LastCommand.
+func (b *ExecBackend) runBin(bin string, args ...string) (bool, string) {
+	return b.runTries(b.policy.Retries, bin, args...)
 }
 
-func (b *Backend) run(args ...string) (bool, string) {
-	cmd := exec.Command("asusctl", args...)
+// runTries is runBin with at most retries retries after a transient
+// failure, 0 for commands that must not run twice.
+func (b *ExecBackend) runTries(retries int, bin string, args ...string) (bool, string) {
+	b.rec.set(append([]string{bin}, args...))
+
+	backoff := b.policy.Backoff
+	for attempt := 0; ; attempt++ {
+		ok, out := execWithTimeout(hostCommand(bin, args...), b.policy.Timeout)
+		if ok || attempt >= retries || !isTransient(out) {
+			return ok, out
+		}
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+}
+
+// isTransient reports whether a failure is worth retrying: the daemon may
+// be restarting, or a single call hung.
+func isTransient(out string) bool {
+	switch classifyFailure(out).Kind {
+	case ErrDaemonDown, ErrTimeout:
+		return true
+	}
+	return false
+}
+
+// execWithTimeout runs cmd, killing it if it does not finish in time.
+func execWithTimeout(cmd *exec.Cmd, timeout time.Duration) (bool, string) {
 	done := make(chan struct {
 		out []byte
 		err error
 	}, 1)
 
+	start := time.Now()
 	go func() {
 		out, err := cmd.CombinedOutput()
 		done <- struct {
@@ -38,125 +358,189 @@ func (b *Backend) run(args ...string) (bool, string) {
 	select {
 	case r := <-done:
 		output := strings.TrimSpace(string(r.out))
+		if r.err != nil && output == "" {
+			output = r.err.Error()
+		}
+		// A binary given by path fails to start with a bare ENOENT; word it
+		// like the PATH lookup so only this maps to ErrNotInstalled.
+		if cmd.Process == nil && errors.Is(r.err, fs.ErrNotExist) {
+			output = cmd.Path + ": executable file not found"
+		}
+		traceCommand(cmd, start, exitStatus(cmd, r.err), output)
 		return r.err == nil, output
-	case <-time.After(5 * time.Second):
+	case <-time.After(timeout):
 		if cmd.Process != nil {
 			cmd.Process.Kill()
 		}
-		return false, "command timed out"
+		traceCommand(cmd, start, "killed after "+timeout.String(), "")
+		return false, "command timed out after " + timeout.String()
+	}
+}
+
+// LastCommand returns the most recent command line, binary first.
+func (b *ExecBackend) LastCommand() []string {
+	return b.rec.get()
+}
+
+// record sets LastCommand without running anything; see writeOnce.
+func (b *ExecBackend) record(argv ...string) { b.rec.set(argv) }
+
+func (b *ExecBackend) Session() Backend {
+	return &ExecBackend{execState: b.execState, rec: &commandRecord{}}
+}
+
+// RunElevated re-runs a command through pkexec. The generous timeout leaves
+// room for the user to answer the polkit authentication prompt.
+func (b *ExecBackend) RunElevated(argv ...string) (bool, string) {
+	if len(argv) == 0 {
+		return false, "no command to elevate"
+	}
+	if _, err := hostLookPath("pkexec"); err != nil {
+		return false, "pkexec not found"
+	}
+	// pkexec wants an absolute path
+	bin, err := hostLookPath(argv[0])
+	if err != nil {
+		return false, err.Error()
 	}
+	cmd := hostCommand("pkexec", append([]string{bin}, argv[1:]...)...)
+	cmd.Stdin = os.Stdin
+	return execWithTimeout(cmd, 2*time.Minute)
 }
 
-func (b *Backend) IsInstalled() bool {
-	_, err := exec.LookPath("asusctl")
+func (b *ExecBackend) IsInstalled() bool {
+	_, err := hostLookPath(b.bin)
 	return err == nil
 }
 
 // ─── Profile ─────────────────────────────────────────────────────────────────
 
-func (b *Backend) GetProfile() string {
-	ok, out := b.run("profile", "get")
-	if ok {
-		lo := strings.ToLower(out)
-		if strings.Contains(lo, "performance") {
-			return "Performance"
-		} else if strings.Contains(lo, "balanced") {
-			return "Balanced"
-		} else if strings.Contains(lo, "quiet") {
-			return "Quiet"
-		}
-		return out
+// GetProfileInfo returns the parsed `profile get` output.
+func (b *ExecBackend) GetProfileInfo() (ProfileInfo, error) {
+	if b.usePpd() {
+		return b.ppdGet()
+	}
+	ok, out := b.runOp("profile.get")
+	if !ok {
+		return ProfileInfo{}, classifyFailure(out)
+	}
+	return ParseProfileInfo(out)
+}
+
+func (b *ExecBackend) GetProfile() string {
+	info, err := b.GetProfileInfo()
+	if err != nil {
+		return "Unknown"
 	}
-	return "Unknown"
+	return info.Active
 }
 
-func (b *Backend) SetProfile(p string) (bool, string) {
-	return b.run("profile", "set", p)
+func (b *ExecBackend) SetProfile(p string) (bool, string) {
+	if b.usePpd() {
+		return b.ppdSet(p)
+	}
+	return b.runOp("profile.set", p)
 }
 
-func (b *Backend) NextProfile() (bool, string) {
-	ok, out := b.run("profile", "next")
+func (b *ExecBackend) NextProfile() (bool, string) {
+	if b.usePpd() {
+		return b.ppdNext()
+	}
+	ok, out := b.runOp("profile.next")
 	if ok {
 		return true, b.GetProfile()
 	}
 	return false, out
 }
 
-func (b *Backend) ListProfiles() (bool, string) {
-	return b.run("profile", "list")
+func (b *ExecBackend) ListProfiles() (bool, string) {
+	if b.usePpd() {
+		return b.ppdList()
+	}
+	return b.runOp("profile.list")
 }
 
 // ─── Keyboard Brightness ─────────────────────────────────────────────────────
 
-func (b *Backend) GetKbdBrightness() string {
-	ok, out := b.run("leds", "get")
-	if ok {
-		lo := strings.ToLower(out)
-		for _, level := range []string{"off", "low", "med", "high"} {
-			if strings.Contains(lo, level) {
-				return level
-			}
-		}
+// GetLedState returns the parsed `leds get` output.
+func (b *ExecBackend) GetLedState() (LedState, error) {
+	ok, out := b.runOp("leds.get")
+	if !ok {
+		return LedState{}, classifyFailure(out)
 	}
-	return "med"
+	return ParseLedState(out)
 }
 
-func (b *Backend) SetKbdBrightness(level string) (bool, string) {
-	return b.run("leds", "set", level)
+func (b *ExecBackend) GetKbdBrightness() string {
+	st, err := b.GetLedState()
+	if err != nil {
+		return "med"
+	}
+	return st.Brightness
 }
 
-func (b *Backend) NextKbdBrightness() (bool, string) {
-	return b.run("leds", "next")
+func (b *ExecBackend) SetKbdBrightness(level string) (bool, string) {
+	return b.runOp("leds.set", level)
 }
 
-func (b *Backend) PrevKbdBrightness() (bool, string) {
-	return b.run("leds", "prev")
+func (b *ExecBackend) NextKbdBrightness() (bool, string) {
+	return b.runOp("leds.next")
+}
+
+func (b *ExecBackend) PrevKbdBrightness() (bool, string) {
+	return b.runOp("leds.prev")
+}
+
+// SetKbdSleepLighting keeps the keyboard lit (and able to show wake
+// effects) while the laptop is suspended.
+func (b *ExecBackend) SetKbdSleepLighting(on bool) (bool, string) {
+	return b.runOp("aura.power.sleep", strconv.FormatBool(on))
 }
 
 // ─── Battery ─────────────────────────────────────────────────────────────────
 
-func (b *Backend) GetChargeLimit() int {
-	ok, out := b.run("battery", "info")
-	if ok {
-		// "Current battery charge limit: 70%"
-		for _, field := range strings.Fields(out) {
-			field = strings.TrimSuffix(field, "%")
-			if v, err := strconv.Atoi(field); err == nil && v >= 20 && v <= 100 {
-				return v
-			}
-		}
+// GetBatteryInfo returns the parsed `battery info` output.
+func (b *ExecBackend) GetBatteryInfo() (BatteryInfo, error) {
+	ok, out := b.runOp("battery.info")
+	if !ok {
+		return BatteryInfo{}, classifyFailure(out)
 	}
-	return 80
+	return ParseBatteryInfo(out)
 }
 
-func (b *Backend) SetChargeLimit(pct int) (bool, string) {
+func (b *ExecBackend) GetChargeLimit() int {
+	info, err := b.GetBatteryInfo()
+	if err != nil {
+		return 80
+	}
+	return info.ChargeLimit
+}
+
+func (b *ExecBackend) SetChargeLimit(pct int) (bool, string) {
 	pct = clamp(pct, 20, 100)
-	return b.run("battery", "limit", strconv.Itoa(pct))
+	return b.runOp("battery.limit", strconv.Itoa(pct))
 }
 
-func (b *Backend) ToggleOneShotCharge() (bool, string) {
-	return b.run("battery", "oneshot")
+func (b *ExecBackend) ToggleOneShotCharge() (bool, string) {
+	return b.runOp("battery.oneshot")
 }
 
 // ─── Aura RGB ────────────────────────────────────────────────────────────────
 
 type AuraState struct {
-	Mode    string // e.g. "Static", "Breathe"
+	Mode       string // e.g. "Static", "Breathe"
 	R1, G1, B1 int
 	R2, G2, B2 int
-	Speed   string // "Low", "Med", "High"
+	Speed      string   // "Low", "Med", "High"
+	Direction  string   // "Left", "Right", "Up", "Down"; Rainbow Wave
+	Zones      []string // per-zone colours as "rrggbb" while multizone is on
 }
 
-func (b *Backend) GetAuraState() *AuraState {
-	configs, _ := filepath.Glob("/etc/asusd/aura_*.ron")
-	if len(configs) == 0 {
-		return nil
-	}
-	data, err := os.ReadFile(configs[0])
-	if err != nil {
+func (b *ExecBackend) GetAuraState() *AuraState {
+	content, ok := readAuraRon()
+	if !ok {
 		return nil
 	}
-	content := string(data)
 
 	// Parse current_mode
 	mode := parseRonField(content, "current_mode")
@@ -205,12 +589,16 @@ func (b *Backend) GetAuraState() *AuraState {
 	r1, g1, b1 := parseRonColour(block, "colour1")
 	r2, g2, b2 := parseRonColour(block, "colour2")
 	speed := parseRonField(block, "speed")
+	direction := parseRonField(block, "direction")
 
 	return &AuraState{
-		Mode: mode,
+		Mode:      mode,
+		Speed:     speed,
+		Direction: direction,
+		Zones:     parseAuraRonZones(content, mode),
+
 		R1: r1, G1: g1, B1: b1,
 		R2: r2, G2: g2, B2: b2,
-		Speed: speed,
 	}
 }
 
@@ -251,10 +639,10 @@ func parseRonColour(block, name string) (int, int, int) {
 	return r, g, b
 }
 
-func (b *Backend) SetAuraMode(mode, colour1, colour2, speed string) (bool, string) {
+func (b *ExecBackend) SetAuraMode(mode, colour1, colour2, speed, direction string) (bool, string) {
 	// Convert display name to CLI subcommand: "Rainbow Cycle" → "rainbow-cycle"
 	subcmd := strings.ToLower(strings.ReplaceAll(mode, " ", "-"))
-	args := []string{"aura", "effect", subcmd}
+	args := argsFor(b.major, "aura.effect", subcmd)
 	if colour1 != "" {
 		args = append(args, "--colour", colour1)
 	}
@@ -264,27 +652,74 @@ func (b *Backend) SetAuraMode(mode, colour1, colour2, speed string) (bool, strin
 	if speed != "" {
 		args = append(args, "--speed", speed)
 	}
-	if subcmd == "rainbow-wave" {
-		args = append(args, "--direction", "right")
+	return b.run(append(args, auraDirectionArgs(subcmd, direction)...)...)
+}
+
+// auraDirectionArgs is the --direction option: rainbow-wave needs one and
+// is sent right when none was picked, as before the Direction row.
+func auraDirectionArgs(subcmd, direction string) []string {
+	switch {
+	case direction != "":
+		return []string{"--direction", direction}
+	case subcmd == "rainbow-wave":
+		return []string{"--direction", "right"}
 	}
-	return b.run(args...)
+	return nil
 }
 
-func (b *Backend) NextAuraMode() (bool, string) {
-	return b.run("aura", "effect", "--next-mode")
+// GetAuraModes reads the builtins asusd wrote to its aura config for this
+// keyboard. `asusctl aura --help` is no fallback: it names every effect the
+// CLI knows, whatever the keyboard supports.
+func (b *ExecBackend) GetAuraModes() []string {
+	if ron, ok := readAuraRon(); ok {
+		if modes := ParseAuraModes(ronBlock(ron, "builtins")); len(modes) > 0 {
+			return modes
+		}
+	}
+	return nil
 }
 
-func (b *Backend) PrevAuraMode() (bool, string) {
-	return b.run("aura", "effect", "--prev-mode")
+// ronBlock returns the bracketed value of field in a RON document, or ""
+// when the field is missing.
+func ronBlock(s, field string) string {
+	idx := strings.Index(s, field+":")
+	if idx < 0 {
+		return ""
+	}
+	s = s[idx:]
+	depth, start := 0, -1
+	for i, ch := range s {
+		switch ch {
+		case '{', '(', '[':
+			if depth == 0 {
+				start = i
+			}
+			depth++
+		case '}', ')', ']':
+			depth--
+			if depth == 0 && start >= 0 {
+				return s[start : i+1]
+			}
+		}
+	}
+	return ""
+}
+
+func (b *ExecBackend) NextAuraMode() (bool, string) {
+	return b.runOp("aura.next")
+}
+
+func (b *ExecBackend) PrevAuraMode() (bool, string) {
+	return b.runOp("aura.prev")
 }
 
 // ─── Fan Curves ──────────────────────────────────────────────────────────────
 
-func (b *Backend) GetFanCurves(profile string) (bool, string) {
+func (b *ExecBackend) GetFanCurves(profile string) (bool, string) {
 	return b.run("fan-curve", "--mod-profile", profile)
 }
 
-func (b *Backend) SetFanCurve(fan, profile, data string) (bool, string) {
+func (b *ExecBackend) SetFanCurve(fan, profile, data string) (bool, string) {
 	args := []string{"fan-curve"}
 	if profile != "" {
 		args = append(args, "--mod-profile", profile)
@@ -298,12 +733,12 @@ func (b *Backend) SetFanCurve(fan, profile, data string) (bool, string) {
 	return b.run(args...)
 }
 
-func (b *Backend) EnableFanCurves(profile string, enable bool) (bool, string) {
+func (b *ExecBackend) EnableFanCurves(profile string, enable bool) (bool, string) {
 	return b.run("fan-curve", "--mod-profile", profile, "--enable-fan-curves", fmt.Sprintf("%v", enable))
 }
 
 // GetFanEnabled checks if any fan curve is enabled for the active profile.
-func (b *Backend) GetFanEnabled() bool {
+func (b *ExecBackend) GetFanEnabled() bool {
 	ok, out := b.run("fan-curve", "--get-enabled")
 	if !ok {
 		return false
@@ -311,40 +746,27 @@ func (b *Backend) GetFanEnabled() bool {
 	return strings.Contains(out, "enabled: true")
 }
 
-// ParseFanCurveSpeeds parses pwm values from --mod-profile output and returns
-// CPU speeds and GPU speeds as percentages (0-100).
-func (b *Backend) ParseFanCurveSpeeds(profile string) (cpu [8]int, gpu [8]int) {
+const fanCurvesRon = "/etc/asusd/fan_curves.ron"
+
+// ReadFanCurves returns the curves asusd holds for profile. When the CLI
+// output cannot be parsed it falls back to asusd's own config file.
+func (b *ExecBackend) ReadFanCurves(profile string) (FanCurves, error) {
 	ok, out := b.GetFanCurves(profile)
-	if !ok {
-		return
-	}
-	// Parse pwm lines: "pwm: (38, 64, ...)"
-	fans := []*[8]int{&cpu, &gpu}
-	fanIdx := 0
-	for _, line := range strings.Split(out, "\n") {
-		line = strings.TrimSpace(line)
-		if !strings.HasPrefix(line, "pwm:") {
-			continue
+	fc, err := ParseFanCurves(out)
+	if ok && err == nil {
+		return fc, nil
+	}
+	data, rerr := os.ReadFile(fanCurvesRon)
+	if rerr != nil {
+		if err == nil {
+			err = &ParseError{What: "fan curves", Output: out}
 		}
-		if fanIdx >= 2 {
-			break
-		}
-		// Extract values between ( and )
-		start := strings.Index(line, "(")
-		end := strings.Index(line, ")")
-		if start < 0 || end < 0 {
-			continue
-		}
-		parts := strings.Split(line[start+1:end], ",")
-		for i := 0; i < 8 && i < len(parts); i++ {
-			v, err := strconv.Atoi(strings.TrimSpace(parts[i]))
-			if err == nil {
-				fans[fanIdx][i] = v * 100 / 255 // pwm 0-255 → percent 0-100
-			}
-		}
-		fanIdx++
+		return fc, err
 	}
-	return
+	if fc, rerr := ParseFanCurves(fanCurvesRonSection(string(data), profile)); rerr == nil {
+		return fc, nil
+	}
+	return fc, err
 }
 
 func FormatFanCurve(temps []int, speeds []int) string {
@@ -355,13 +777,108 @@ func FormatFanCurve(temps []int, speeds []int) string {
 	return strings.Join(parts, ",")
 }
 
+// ParseFanCurveData reads the 8 points of a fan curve: a FormatFanCurve
+// string, or the looser forms curves are shared in (see splitCurvePoints,
+// parseCurvePoint and curveUnit). Errors name the offending point.
+func ParseFanCurveData(s string) (temps, speeds [8]int, err error) {
+	points := splitCurvePoints(s)
+	if len(points) != 8 {
+		return temps, speeds, fmt.Errorf("fan curve needs 8 points, got %d", len(points))
+	}
+	var degs [8]float64
+	var units [8]string
+	for i, pt := range points {
+		deg, unit, sp, err := parseCurvePoint(pt)
+		if err != nil {
+			return temps, speeds, fmt.Errorf("point %d %q: %w", i+1, pt, err)
+		}
+		degs[i], units[i], speeds[i] = deg, unit, sp
+	}
+	unit, err := curveUnit(degs[:], units[:])
+	if err != nil {
+		return temps, speeds, err
+	}
+	for i, pt := range points {
+		t, err := curveTempC(degs[i], unit)
+		if err == nil && i > 0 && t < temps[i-1] {
+			err = fmt.Errorf("temperature is below point %d's %s", i, formatTempLimit(temps[i-1]))
+		}
+		if err != nil {
+			return temps, speeds, fmt.Errorf("point %d %q: %w", i+1, pt, err)
+		}
+		temps[i] = t
+	}
+	return temps, speeds, nil
+}
+
 // ─── BIOS ────────────────────────────────────────────────────────────────────
 
-func (b *Backend) GetPanelOverdrive() (bool, string) {
+func (b *ExecBackend) ListArmoury() (bool, string) {
+	return b.run("armoury", "list")
+}
+
+// GetArmoury reads a firmware attribute; use parseArmouryValue on the output.
+func (b *ExecBackend) GetArmoury(attr string) (bool, string) {
+	return b.run("armoury", "get", attr)
+}
+
+func (b *ExecBackend) SetArmoury(attr, value string) (bool, string) {
+	return b.run("armoury", "set", attr, value)
+}
+
+// parseArmouryValue extracts the current value from `armoury get` output,
+// e.g. "panel_od: 1" or "current_value: 1" → "1".
+func parseArmouryValue(out string) string {
+	val := strings.TrimSpace(out)
+	for _, line := range strings.Split(out, "\n") {
+		key, v, ok := strings.Cut(strings.TrimSpace(line), ":")
+		if !ok {
+			continue
+		}
+		v = strings.TrimSpace(v)
+		if key == "current_value" {
+			return v
+		}
+		val = v
+	}
+	return val
+}
+
+// parseArmouryOptions extracts the allowed values from `armoury get`
+// output: "possible_values: [0, 1, 2]" (or "0;1;2"), else a min_value to
+// max_value range. nil when the output lists neither.
+func parseArmouryOptions(out string) []string {
+	lo, hi := -1, -1
+	for _, line := range strings.Split(out, "\n") {
+		key, v, ok := strings.Cut(strings.TrimSpace(line), ":")
+		if !ok {
+			continue
+		}
+		v = strings.Trim(strings.TrimSpace(v), "[]")
+		switch key {
+		case "possible_values":
+			return strings.FieldsFunc(v, func(r rune) bool { return r == ',' || r == ';' || r == ' ' })
+		case "min_value":
+			lo, _ = strconv.Atoi(v)
+		case "max_value":
+			hi, _ = strconv.Atoi(v)
+		}
+	}
+	if lo < 0 || hi < lo {
+		return nil
+	}
+	var opts []string
+	for v := lo; v <= hi; v++ {
+		opts = append(opts, strconv.Itoa(v))
+	}
+	return opts
+}
+
+func (b *ExecBackend) GetPanelOverdrive() (bool, string) {
 	return b.run("armoury", "get", "panel_od")
 }
 
-func (b *Backend) SetPanelOverdrive(on bool) (bool, string) {
+func (b *ExecBackend) SetPanelOverdrive(on bool) (bool, string) {
 	val := "0"
 	if on {
 		val = "1"
@@ -369,11 +886,11 @@ func (b *Backend) SetPanelOverdrive(on bool) (bool, string) {
 	return b.run("armoury", "set", "panel_od", val)
 }
 
-func (b *Backend) GetGpuMux() (bool, string) {
+func (b *ExecBackend) GetGpuMux() (bool, string) {
 	return b.run("armoury", "get", "gpu_mux_mode")
 }
 
-func (b *Backend) SetGpuMux(dedicated bool) (bool, string) {
+func (b *ExecBackend) SetGpuMux(dedicated bool) (bool, string) {
 	val := "0"
 	if dedicated {
 		val = "1"
@@ -383,11 +900,19 @@ func (b *Backend) SetGpuMux(dedicated bool) (bool, string) {
 
 // ─── Anime / Slash ───────────────────────────────────────────────────────────
 
-func (b *Backend) SetAnimeEnable(on bool) (bool, string) {
+func (b *ExecBackend) SetAnimeEnable(on bool) (bool, string) {
 	return b.run("anime", "--enable-display", fmt.Sprintf("%v", on))
 }
 
-func (b *Backend) SetSlashEnable(on bool) (bool, string) {
+func (b *ExecBackend) SetAnimeImage(path string) (bool, string) {
+	return b.run("anime", "pixel-image", "--path", path)
+}
+
+func (b *ExecBackend) ClearAnime() (bool, string) {
+	return b.run("anime", "clear")
+}
+
+func (b *ExecBackend) SetSlashEnable(on bool) (bool, string) {
 	if on {
 		return b.run("slash", "--enable")
 	}
@@ -396,16 +921,17 @@ func (b *Backend) SetSlashEnable(on bool) (bool, string) {
 
 // ─── Supported ───────────────────────────────────────────────────────────────
 
-func (b *Backend) GetSupported() (bool, string) {
+func (b *ExecBackend) GetSupported() (bool, string) {
 	return b.run("info", "--show-supported")
 }
 
 // ─── Raw ─────────────────────────────────────────────────────────────────────
 
-func (b *Backend) RunRaw(args string) (bool, string) {
+func (b *ExecBackend) RunRaw(args string) (bool, string) {
 	parts := strings.Fields(args)
 	if len(parts) == 0 {
 		return false, "no arguments"
 	}
-	return b.run(parts...)
+	// anything can be typed here, toggles included
+	return b.runTries(0, b.bin, parts...)
 }
@@ -0,0 +1,211 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// Battery sysfs — live charge, draw and capacity from /sys/class/power_supply
+// asusctl only knows the charge limit; everything else comes from the kernel.
+// Drivers report either energy (µWh, µW) or charge (µAh, µA) plus voltage.
+// ═══════════════════════════════════════════════════════════════════════════════
+
+const powerSupplyDir = "/sys/class/power_supply"
+
+// BatteryStatus is a snapshot of the system battery.
+type BatteryStatus struct {
+	Present  bool
+	Status   string  // Charging, Discharging, Full, Not charging
+	Percent  int     // state of charge
+	FullWh   float64 // capacity when full, as the battery reports it now
+	DesignWh float64 // capacity when new, 0 if not reported
+	PowerW   float64 // current draw or charge rate, always positive
+	VoltageV float64
+	Cycles   int // charge cycles, 0 if not reported
+}
+
+func (s BatteryStatus) Discharging() bool { return s.Status == "Discharging" }
+
+// Health is the full capacity as a percentage of the design capacity, or 0.
+func (s BatteryStatus) Health() float64 {
+	if s.DesignWh <= 0 {
+		return 0
+	}
+	return s.FullWh / s.DesignWh * 100
+}
+
+// findBattery returns the sysfs directory of the system battery (not a
+// mouse or other "Device"-scoped supply).
+func findBattery() string {
+	dirs, _ := filepath.Glob(filepath.Join(powerSupplyDir, "*"))
+	for _, d := range dirs {
+		if readSysfsString(filepath.Join(d, "type")) != "Battery" {
+			continue
+		}
+		if readSysfsString(filepath.Join(d, "scope")) == "Device" {
+			continue
+		}
+		return d
+	}
+	return ""
+}
+
+func readSysfsString(path string) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
+
+// readSysfsMicro reads a µ-unit attribute; ok is false when it is missing.
+func readSysfsMicro(path string) (float64, bool) {
+	n, err := strconv.ParseInt(readSysfsString(path), 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return float64(n) / 1e6, true
+}
+
+func readBatteryStatus(dir string) BatteryStatus {
+	if dir == "" {
+		return BatteryStatus{}
+	}
+	attr := func(name string) string { return filepath.Join(dir, name) }
+	st := BatteryStatus{Present: readSysfsString(attr("present")) != "0"}
+	st.Status = readSysfsString(attr("status"))
+	st.Percent, _ = strconv.Atoi(readSysfsString(attr("capacity")))
+
+	st.VoltageV, _ = readSysfsMicro(attr("voltage_now"))
+	st.Cycles, _ = strconv.Atoi(readSysfsString(attr("cycle_count")))
+
+	// charge-based drivers: µAh times the nominal voltage
+	vDesign, _ := readSysfsMicro(attr("voltage_min_design"))
+	if full, ok := readSysfsMicro(attr("energy_full")); ok {
+		st.FullWh = full
+	} else if ah, ok := readSysfsMicro(attr("charge_full")); ok {
+		st.FullWh = ah * vDesign
+	}
+	if design, ok := readSysfsMicro(attr("energy_full_design")); ok {
+		st.DesignWh = design
+	} else if ah, ok := readSysfsMicro(attr("charge_full_design")); ok {
+		st.DesignWh = ah * vDesign
+	}
+	if w, ok := readSysfsMicro(attr("power_now")); ok {
+		st.PowerW = w
+	} else if a, ok := readSysfsMicro(attr("current_now")); ok {
+		st.PowerW = a * st.VoltageV
+	}
+	if st.PowerW < 0 { // some drivers sign the discharge rate
+		st.PowerW = -st.PowerW
+	}
+	return st
+}
+
+func (b *ExecBackend) GetBatteryStatus() BatteryStatus {
+	return readBatteryStatus(findBattery())
+}
+
+func (b *ExecBackend) GetChargeThreshold() (int, bool) {
+	dir := findBattery()
+	if dir == "" {
+		return 0, false
+	}
+	v, err := strconv.Atoi(readSysfsString(filepath.Join(dir, "charge_control_end_threshold")))
+	return v, err == nil
+}
+
+// renderBatteryLive draws the sysfs readings on two rows at x,y: charge and
+// state, then health and cycles.
+func (a *App) renderBatteryLive(x, y int) {
+	t := a.term
+	bat := a.planner.battery
+	if !bat.Present {
+		t.Text(x, y, ColTextMut, "No battery reported by the kernel")
+		return
+	}
+	col := ColSuccess
+	switch {
+	case bat.Percent <= 15:
+		col = ColError
+	case bat.Percent <= 35:
+		col = ColWarning
+	}
+	t.TextBold(x, y, col, fmt.Sprintf("%d%%", bat.Percent))
+	state := orDash(bat.Status)
+	if bat.PowerW > 0 {
+		state += fmt.Sprintf(" · %.1f W", bat.PowerW)
+	}
+	if bat.VoltageV > 0 {
+		state += fmt.Sprintf(" · %.2f V", bat.VoltageV)
+	}
+	t.Text(x+5, y, ColText, state)
+
+	health := "Health —"
+	if h := bat.Health(); h > 0 {
+		health = fmt.Sprintf("Health %.0f%% (%.1f of %.1f Wh)", h, bat.FullWh, bat.DesignWh)
+	}
+	cycles := "cycles —"
+	if bat.Cycles > 0 {
+		cycles = fmt.Sprintf("%d cycles", bat.Cycles)
+	}
+	t.Text(x, y+1, ColTextDim, health+" · "+cycles)
+}
+
+// ─── One-shot charge ─────────────────────────────────────────────────────────
+
+// readOneShot reads back whether a one-shot charge is armed: the kernel
+// threshold is at 100 while the limit asusd holds is lower. The slider's
+// value may not be applied yet, so it says nothing.
+func (a *App) readOneShot() {
+	pct, ok := a.backend.GetChargeThreshold()
+	a.setOneShot(pct, ok)
+}
+
+func (a *App) setOneShot(threshold int, ok bool) {
+	a.oneShotKnown = ok
+	a.oneShotCharge = ok && threshold == 100 && a.chargeLimitSet < 100
+}
+
+// oneShotProgress is the readout while a one-shot charge runs, e.g.
+// "Charging to 100%, currently 87%, ~22 min".
+func oneShotProgress(bat BatteryStatus) string {
+	msg := fmt.Sprintf("Charging to 100%%, currently %d%%", bat.Percent)
+	switch {
+	case !bat.Present:
+		return "Charging to 100%"
+	case bat.Discharging():
+		return msg + " — connect the charger"
+	case bat.Status != "Charging" || bat.PowerW <= 0 || bat.FullWh <= 0:
+		return msg
+	}
+	h := bat.FullWh * float64(100-bat.Percent) / 100 / bat.PowerW
+	if m := int(h*60 + 0.5); m < 60 {
+		return msg + fmt.Sprintf(", ~%d min", max(m, 1))
+	}
+	return msg + ", ~" + formatRuntime(h)
+}
+
+func (a *App) renderOneShotProgress(x, y int) {
+	bat := a.planner.battery
+	a.term.Text(x, y, ColSuccess, oneShotProgress(bat))
+	if bat.Present {
+		a.term.DrawBar(x, y+1, 28, float64(bat.Percent)/100, ColSuccess, ColInput)
+	}
+}
+
+// checkOneShot clears the one-shot indicator once the battery is full; the
+// charge limit applies again from then on.
+func (a *App) checkOneShot() {
+	bat := a.planner.battery
+	if !a.oneShotCharge || !bat.Present || (bat.Status != "Full" && bat.Percent < 100) {
+		return
+	}
+	a.oneShotCharge = false
+	a.addLog("one-shot charge", "complete", true)
+	a.SetStatus(fmt.Sprintf("One-shot charge complete — back to the %d%% limit", a.chargeLimit), true)
+}
@@ -0,0 +1,22 @@
+package main
+
+import (
+	"io"
+	"testing"
+)
+
+// TestOneShotUsesAppliedLimit checks the one-shot readout compares the
+// kernel threshold with the limit asusd holds, not the slider.
+func TestOneShotUsesAppliedLimit(t *testing.T) {
+	a := NewApp(NewFakeTerminal(80, 24, io.Discard), NewMockBackend(), DefaultConfig())
+	a.chargeLimit, a.chargeLimitSet = 100, 80 // slider moved, not applied
+	a.setOneShot(100, true)
+	if !a.oneShotCharge {
+		t.Error("threshold 100 over an applied 80% limit is not read as armed")
+	}
+	a.chargeLimit, a.chargeLimitSet = 60, 100
+	a.setOneShot(100, true)
+	if a.oneShotCharge {
+		t.Error("an applied 100% limit is read as armed")
+	}
+}
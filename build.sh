@@ -10,6 +10,13 @@ if ! command -v go &>/dev/null; then
     exit 1
 fi
 
+# ./build.sh bench — run the renderer benchmarks before a release and keep
+# the results in bench_output.txt for comparison with the previous run.
+if [[ "${1:-}" == "bench" ]]; then
+    go test -run '^$' -bench . -benchmem -count 5 . | tee bench_output.txt
+    exit 0
+fi
+
 BUILD_VERSION=$(git rev-parse --short HEAD 2>/dev/null || echo "unknown")
 go build -ldflags="-s -w -X main.BuildVersion=${BUILD_VERSION}" -o "${APP}" .
 echo "Built ./${APP} ($(du -h ${APP} | cut -f1))"
@@ -0,0 +1,389 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// CachedBackend — TTL cache in front of the slow getters
+// Every asusctl read spawns a process; repeated reads within a field's TTL are
+// served from memory. Setters and external changes drop the affected field.
+// It also remembers what each setter last wrote and skips writing the same
+// value again (unless --force): a second Enter on the BIOS tab should not
+// cost another UEFI NVRAM write.
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// Cache keys, one per cached getter.
+const (
+	cacheProfile = "profile"
+	cacheKbd     = "kbd"
+	cacheCharge  = "charge"
+	cacheAura    = "aura"
+)
+
+// cacheTTL is how long each field stays fresh. Fields that the Fn keys can
+// change get short TTLs; the charge limit only changes through us.
+var cacheTTL = map[string]time.Duration{
+	cacheProfile: 2 * time.Second,
+	cacheKbd:     2 * time.Second,
+	cacheCharge:  30 * time.Second,
+	cacheAura:    10 * time.Second,
+}
+
+// writeTTL is how long a written value is trusted for fields the Fn keys
+// (or other tools) can change behind our back. The rest mostly change only
+// through us and are trusted for writeTrust, or until a change event drops
+// them.
+var writeTTL = map[string]time.Duration{
+	cacheProfile: 2 * time.Second,
+	cacheKbd:     2 * time.Second,
+	cacheAura:    10 * time.Second,
+}
+
+const writeTrust = 10 * time.Minute
+
+// unchangedOut is the output of a write that was skipped.
+const unchangedOut = "unchanged since the last write; not written again (--force writes anyway)"
+
+// changeAreaKeys lists the fields to drop when asusd reports a change.
+// asusd.ron, whose rewrites count as ChangeProfile, also keeps the
+// firmware attributes.
+var changeAreaKeys = [ChangeAreaCount][]string{
+	ChangeProfile:  {cacheProfile, "ppt:", "armoury:"},
+	ChangeKeyboard: {cacheKbd},
+	ChangeAura:     {cacheAura},
+	ChangeFans:     {"fan:", "fan-enabled:"},
+}
+
+type cacheEntry struct {
+	val  any
+	at   time.Time
+	argv []string // written values: the command that wrote it
+}
+
+// CachedBackend wraps a Backend; methods it does not override pass through.
+// Safe for concurrent use as long as the wrapped getters are.
+type CachedBackend struct {
+	Backend
+	*cacheState
+}
+
+// cacheState is what a CachedBackend's sessions share.
+type cacheState struct {
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+	written map[string]cacheEntry // key → value last written, see writeOnce
+	force   bool                  // --force: never skip a write
+}
+
+func NewCachedBackend(b Backend) *CachedBackend {
+	return &CachedBackend{Backend: b, cacheState: &cacheState{entries: map[string]cacheEntry{}, written: map[string]cacheEntry{}}}
+}
+
+func (c *CachedBackend) Session() Backend {
+	return &CachedBackend{Backend: c.Backend.Session(), cacheState: c.cacheState}
+}
+
+// cached returns the fresh value under key or calls fetch and stores it.
+// fetch runs without the lock held so different fields load in parallel.
+func cached[T any](c *CachedBackend, key string, fetch func() T) T {
+	c.mu.Lock()
+	e, ok := c.entries[key]
+	c.mu.Unlock()
+	if ok && time.Since(e.at) < cacheTTL[key] {
+		return e.val.(T)
+	}
+	v := fetch()
+	c.mu.Lock()
+	c.entries[key] = cacheEntry{val: v, at: time.Now()}
+	c.mu.Unlock()
+	return v
+}
+
+// Invalidate drops the given fields, read and written. A key ending in ':'
+// drops every key it prefixes ("fan:" drops all fan curves).
+func (c *CachedBackend) Invalidate(keys ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, k := range keys {
+		if strings.HasSuffix(k, ":") {
+			for _, m := range []map[string]cacheEntry{c.entries, c.written} {
+				for key := range m {
+					if strings.HasPrefix(key, k) {
+						delete(m, key)
+					}
+				}
+			}
+			continue
+		}
+		delete(c.entries, k)
+		delete(c.written, k)
+	}
+}
+
+// InvalidateAll drops every field, read and written, after a command that
+// can change anything.
+func (c *CachedBackend) InvalidateAll() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = map[string]cacheEntry{}
+	c.written = map[string]cacheEntry{}
+}
+
+// commandRecorder is a backend whose LastCommand can be set without running
+// anything, for the command a skipped write stood in for.
+type commandRecorder interface {
+	record(argv ...string)
+}
+
+// writeOnce runs write unless key was last written with val (within its
+// writeTTL, or writeTrust). A skipped write reports the command that wrote
+// the value as its LastCommand. After a successful write the invalidate
+// fields are dropped and val is recorded with the command that ran.
+func (c *CachedBackend) writeOnce(key, val string, write func() (bool, string), invalidate ...string) (bool, string) {
+	ttl := writeTTL[key]
+	if ttl == 0 {
+		ttl = writeTrust
+	}
+	c.mu.Lock()
+	e, ok := c.written[key]
+	c.mu.Unlock()
+	if ok && e.val == val && time.Since(e.at) < ttl && !c.force {
+		if r, ok := c.Backend.(commandRecorder); ok {
+			r.record(e.argv...)
+		}
+		return true, unchangedOut
+	}
+	ok, out := write()
+	if !ok {
+		c.Invalidate(key)
+		return ok, out
+	}
+	c.Invalidate(invalidate...)
+	c.mu.Lock()
+	c.written[key] = cacheEntry{val: val, at: time.Now(), argv: c.Backend.LastCommand()}
+	c.mu.Unlock()
+	return ok, out
+}
+
+// invalidateOnOk drops keys after a successful write and passes the result on.
+func (c *CachedBackend) invalidateOnOk(ok bool, out string, keys ...string) (bool, string) {
+	if ok {
+		c.Invalidate(keys...)
+	}
+	return ok, out
+}
+
+// ─── Cached getters ──────────────────────────────────────────────────────────
+
+func (c *CachedBackend) GetProfile() string {
+	return cached(c, cacheProfile, c.Backend.GetProfile)
+}
+
+func (c *CachedBackend) GetKbdBrightness() string {
+	return cached(c, cacheKbd, c.Backend.GetKbdBrightness)
+}
+
+func (c *CachedBackend) GetChargeLimit() int {
+	return cached(c, cacheCharge, c.Backend.GetChargeLimit)
+}
+
+func (c *CachedBackend) GetAuraState() *AuraState {
+	s := cached(c, cacheAura, c.Backend.GetAuraState)
+	if s == nil {
+		return nil
+	}
+	cp := *s // callers may keep the pointer; don't hand out the cached copy
+	cp.Zones = append([]string(nil), s.Zones...)
+	return &cp
+}
+
+// ─── Reads that check the written values ─────────────────────────────────────
+
+// seen drops the value written under key when a read finds another one:
+// something else changed it, so writing ours again is no repeat.
+func (c *CachedBackend) seen(key, val string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.written[key]; ok && e.val != val {
+		delete(c.written, key)
+	}
+}
+
+func (c *CachedBackend) ListArmoury() (bool, string) {
+	ok, out := c.Backend.ListArmoury()
+	if ok {
+		for _, at := range parseArmouryList(out) {
+			c.seen(armouryKey(at.Name), at.Value)
+		}
+	}
+	return ok, out
+}
+
+func (c *CachedBackend) GetArmoury(attr string) (bool, string) {
+	ok, out := c.Backend.GetArmoury(attr)
+	if ok {
+		c.seen(armouryKey(attr), parseArmouryValue(out))
+	}
+	return ok, out
+}
+
+func (c *CachedBackend) GetPanelOverdrive() (bool, string) {
+	ok, out := c.Backend.GetPanelOverdrive()
+	if ok {
+		c.seen("armoury:panel_od", parseArmouryValue(out))
+	}
+	return ok, out
+}
+
+func (c *CachedBackend) GetGpuMux() (bool, string) {
+	ok, out := c.Backend.GetGpuMux()
+	if ok {
+		c.seen("armoury:gpu_mux_mode", parseArmouryValue(out))
+	}
+	return ok, out
+}
+
+// ─── Invalidating setters ────────────────────────────────────────────────────
+
+func (c *CachedBackend) SetProfile(p string) (bool, string) {
+	return c.writeOnce(cacheProfile, p, func() (bool, string) {
+		return c.Backend.SetProfile(p)
+	}, cacheProfile, "ppt:")
+}
+
+func (c *CachedBackend) NextProfile() (bool, string) {
+	ok, out := c.Backend.NextProfile()
+	return c.invalidateOnOk(ok, out, cacheProfile, "ppt:")
+}
+
+func (c *CachedBackend) SetKbdBrightness(level string) (bool, string) {
+	return c.writeOnce(cacheKbd, level, func() (bool, string) {
+		return c.Backend.SetKbdBrightness(level)
+	}, cacheKbd)
+}
+
+func (c *CachedBackend) NextKbdBrightness() (bool, string) {
+	ok, out := c.Backend.NextKbdBrightness()
+	return c.invalidateOnOk(ok, out, cacheKbd)
+}
+
+func (c *CachedBackend) PrevKbdBrightness() (bool, string) {
+	ok, out := c.Backend.PrevKbdBrightness()
+	return c.invalidateOnOk(ok, out, cacheKbd)
+}
+
+func (c *CachedBackend) SetChargeLimit(pct int) (bool, string) {
+	v := strconv.Itoa(pct)
+	return c.writeOnce(cacheCharge, v, func() (bool, string) {
+		return c.Backend.SetChargeLimit(pct)
+	}, cacheCharge)
+}
+
+func (c *CachedBackend) SetAuraMode(mode, colour1, colour2, speed, direction string) (bool, string) {
+	return c.writeOnce(cacheAura, strings.Join([]string{mode, colour1, colour2, speed, direction}, " "),
+		func() (bool, string) {
+			return c.Backend.SetAuraMode(mode, colour1, colour2, speed, direction)
+		}, cacheAura)
+}
+
+func (c *CachedBackend) SetAuraZones(mode string, colours []string, colour2, speed, direction string) (bool, string) {
+	return c.writeOnce(cacheAura, strings.Join([]string{mode, strings.Join(colours, ","), colour2, speed, direction}, " "),
+		func() (bool, string) {
+			return c.Backend.SetAuraZones(mode, colours, colour2, speed, direction)
+		}, cacheAura)
+}
+
+func (c *CachedBackend) SetAuraPerKey(colours []string) (bool, string) {
+	return c.writeOnce(cacheAura, "per-key "+strings.Join(colours, ","),
+		func() (bool, string) {
+			return c.Backend.SetAuraPerKey(colours)
+		}, cacheAura)
+}
+
+// ─── Skipped repeat writes ───────────────────────────────────────────────────
+
+// armouryKey is attr's written key. asusd keeps the power limits per
+// profile, so they go under "ppt:", which every profile switch drops.
+func armouryKey(attr string) string {
+	if indexFold(pptAttrs, attr) >= 0 || strings.HasPrefix(attr, "nv_") {
+		return "ppt:" + attr
+	}
+	return "armoury:" + attr
+}
+
+// Armoury attributes are UEFI variables; every write wears NVRAM.
+func (c *CachedBackend) SetArmoury(attr, value string) (bool, string) {
+	return c.writeOnce(armouryKey(attr), value, func() (bool, string) {
+		return c.Backend.SetArmoury(attr, value)
+	})
+}
+
+func (c *CachedBackend) SetPanelOverdrive(on bool) (bool, string) {
+	v := strconv.Itoa(boolInt(on))
+	return c.writeOnce("armoury:panel_od", v, func() (bool, string) {
+		return c.Backend.SetPanelOverdrive(on)
+	})
+}
+
+func (c *CachedBackend) SetGpuMux(dedicated bool) (bool, string) {
+	v := strconv.Itoa(boolInt(dedicated))
+	return c.writeOnce("armoury:gpu_mux_mode", v, func() (bool, string) {
+		return c.Backend.SetGpuMux(dedicated)
+	})
+}
+
+func (c *CachedBackend) SetFanCurve(fan, profile, data string) (bool, string) {
+	return c.writeOnce("fan:"+profile+":"+fan, data, func() (bool, string) {
+		return c.Backend.SetFanCurve(fan, profile, data)
+	})
+}
+
+func (c *CachedBackend) EnableFanCurves(profile string, enable bool) (bool, string) {
+	v := strconv.FormatBool(enable)
+	return c.writeOnce("fan-enabled:"+profile, v, func() (bool, string) {
+		return c.Backend.EnableFanCurves(profile, enable)
+	})
+}
+
+func (c *CachedBackend) NextAuraMode() (bool, string) {
+	ok, out := c.Backend.NextAuraMode()
+	return c.invalidateOnOk(ok, out, cacheAura)
+}
+
+func (c *CachedBackend) PrevAuraMode() (bool, string) {
+	ok, out := c.Backend.PrevAuraMode()
+	return c.invalidateOnOk(ok, out, cacheAura)
+}
+
+// Raw and elevated commands can change anything.
+func (c *CachedBackend) RunRaw(args string) (bool, string) {
+	ok, out := c.Backend.RunRaw(args)
+	c.InvalidateAll()
+	return ok, out
+}
+
+func (c *CachedBackend) RunElevated(args ...string) (bool, string) {
+	ok, out := c.Backend.RunElevated(args...)
+	c.InvalidateAll()
+	return ok, out
+}
+
+func (c *CachedBackend) RestartDaemon() (bool, string) {
+	ok, out := c.Backend.RestartDaemon()
+	c.InvalidateAll()
+	return ok, out
+}
+
+// WatchChanges drops the changed field before the UI re-reads it.
+func (c *CachedBackend) WatchChanges(onChange func(ChangeArea)) error {
+	return c.Backend.WatchChanges(func(area ChangeArea) {
+		if keys := changeAreaKeys[area]; len(keys) > 0 {
+			c.Invalidate(keys...)
+		}
+		onChange(area)
+	})
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWriteOnceSkipsRepeats(t *testing.T) {
+	m := NewMockBackend()
+	c := NewCachedBackend(m)
+	if ok, out := c.SetChargeLimit(70); !ok || out == unchangedOut {
+		t.Fatalf("first write: %v %q", ok, out)
+	}
+	wrote := strings.Join(c.LastCommand(), " ")
+	m.SetKbdBrightness("low") // another command runs in between
+	if ok, out := c.SetChargeLimit(70); !ok || out != unchangedOut {
+		t.Fatalf("repeat: %v %q, want it skipped", ok, out)
+	}
+	if got := strings.Join(c.LastCommand(), " "); got != wrote {
+		t.Errorf("skipped write's LastCommand = %q, want %q", got, wrote)
+	}
+	if _, out := c.SetChargeLimit(75); out == unchangedOut {
+		t.Error("a new value was skipped")
+	}
+	c.force = true
+	if _, out := c.SetChargeLimit(75); out == unchangedOut {
+		t.Error("--force skipped a write")
+	}
+}
+
+func TestInvalidate(t *testing.T) {
+	c := NewCachedBackend(NewMockBackend())
+	c.SetFanCurve("cpu", "Quiet", "30c:0%")
+	c.SetFanCurve("gpu", "Quiet", "30c:0%")
+	c.SetChargeLimit(70)
+
+	c.Invalidate()
+	if _, out := c.SetChargeLimit(70); out != unchangedOut {
+		t.Error("Invalidate with no keys dropped the charge limit")
+	}
+	c.Invalidate("fan:")
+	if _, out := c.SetFanCurve("gpu", "Quiet", "30c:0%"); out == unchangedOut {
+		t.Error(`Invalidate("fan:") kept the GPU curve`)
+	}
+	if _, out := c.SetChargeLimit(70); out != unchangedOut {
+		t.Error(`Invalidate("fan:") dropped the charge limit`)
+	}
+	c.InvalidateAll()
+	if _, out := c.SetChargeLimit(70); out == unchangedOut {
+		t.Error("InvalidateAll kept the charge limit")
+	}
+}
+
+// TestPowerLimitPerProfile checks a power limit written under one profile
+// is written again under the next: asusd keeps a set per profile.
+func TestPowerLimitPerProfile(t *testing.T) {
+	m := NewMockBackend()
+	c := NewCachedBackend(m)
+	c.SetProfile("Balanced")
+	if ok, out := c.SetArmoury("ppt_pl1_spl", "60"); !ok || out == unchangedOut {
+		t.Fatalf("Balanced: %v %q", ok, out)
+	}
+	c.SetProfile("Performance")
+	if ok, out := c.SetArmoury("ppt_pl1_spl", "60"); !ok || out == unchangedOut {
+		t.Fatalf("Performance: %v %q, want it written", ok, out)
+	}
+	if _, out := m.GetArmoury("ppt_pl1_spl"); parseArmouryValue(out) != "60" {
+		t.Errorf("Performance's limit = %s, want 60", out)
+	}
+	if _, out := c.SetArmoury("ppt_pl1_spl", "60"); out != unchangedOut {
+		t.Error("a repeat within the same profile was written")
+	}
+}
+
+// TestArmouryChangedElsewhere checks a firmware attribute changed outside
+// the app is written again, once a read or a change event has seen it.
+func TestArmouryChangedElsewhere(t *testing.T) {
+	tests := []struct {
+		name   string
+		notice func(c *CachedBackend)
+	}{
+		{"BIOS tab read", func(c *CachedBackend) {
+			v := readArmouryView(c)
+			if at, _ := v.attr("panel_od"); at.Value != "0" {
+				t.Fatalf("read panel_od = %q, want 0", at.Value)
+			}
+		}},
+		{"attribute read", func(c *CachedBackend) { c.GetArmoury("panel_od") }},
+		{"Display tab read", func(c *CachedBackend) { c.GetPanelOverdrive() }},
+		{"asusd.ron rewritten", func(c *CachedBackend) { c.Invalidate(changeAreaKeys[ChangeProfile]...) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewMockBackend()
+			c := NewCachedBackend(m)
+			c.SetArmoury("panel_od", "1")
+			m.SetArmoury("panel_od", "0") // another tool
+			tt.notice(c)
+			if ok, out := c.SetArmoury("panel_od", "1"); !ok || out == unchangedOut {
+				t.Fatalf("rewrite: %v %q, want it written", ok, out)
+			}
+			if _, out := m.GetArmoury("panel_od"); parseArmouryValue(out) != "1" {
+				t.Errorf("panel_od = %s, want 1", out)
+			}
+		})
+	}
+	c := NewCachedBackend(NewMockBackend())
+	c.SetArmoury("panel_od", "1")
+	c.ListArmoury()
+	if _, out := c.SetArmoury("panel_od", "1"); out != unchangedOut {
+		t.Error("a read that found the written value dropped it")
+	}
+}
@@ -0,0 +1,219 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// Changes since launch — D opens an overlay listing every setting whose
+// value differs from the one read at startup: the scene settings (profile,
+// keyboard, Aura, charge limit, fan curves) and the firmware attributes.
+// The launch state is a scene snapshot like the Scenes tab saves, and a
+// revert applies the one setting through applyScene (or setArmoury), so it
+// honours lockdown and quiet hours like any other write. A setting shows as
+// reverted once its write succeeded.
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// launchState is the hardware state once the startup reads came back.
+type launchState struct {
+	at    time.Time
+	scene Scene
+	bios  []ArmouryAttr
+}
+
+// stateChange is one setting that differs from launch.
+type stateChange struct {
+	label, was, now string
+	revert          func(done func(ok bool))
+	reverted        bool
+}
+
+type changesView struct {
+	items []stateChange
+	sel   int
+}
+
+// captureLaunch snapshots the state applyInitialState just set, the way a
+// scene is saved.
+func (a *App) captureLaunch() {
+	sc := a.captureScene("launch")
+	a.launch = launchState{at: time.Now(), scene: sc, bios: append([]ArmouryAttr(nil), a.bios.attrs...)}
+}
+
+// auraSummary describes a scene's Aura settings for the overlay, leaving
+// out what the effect ignores.
+func auraSummary(sc Scene) string {
+	return AuraPreset{Mode: sc.AuraMode, Colour1: sc.Colour1, Colour2: sc.Colour2, Speed: sc.AuraSpeed, Zones: sc.Zones}.Describe()
+}
+
+// changesSinceLaunch compares the current state with the launch snapshot.
+// Fan curves and power limits belong to a profile, so they are compared
+// only while the launch profile is active.
+func (a *App) changesSinceLaunch() []stateChange {
+	was := a.launch.scene
+	now := a.captureScene("now")
+	revert := func(sc Scene) func(func(bool)) {
+		sc.Name = "launch"
+		return func(done func(bool)) { a.applyScene(sc, done) }
+	}
+	var items []stateChange
+	add := func(label, w, n string, fn func(func(bool))) {
+		if w != n {
+			items = append(items, stateChange{label: label, was: w, now: n, revert: fn})
+		}
+	}
+	add("Profile", was.Profile, now.Profile, revert(Scene{Profile: was.Profile}))
+	add("Keyboard", was.Keyboard, now.Keyboard, revert(Scene{Keyboard: was.Keyboard}))
+	add("Aura", auraSummary(was), auraSummary(now),
+		revert(Scene{AuraMode: was.AuraMode, Colour1: was.Colour1, Colour2: was.Colour2, AuraSpeed: was.AuraSpeed}))
+	add("Charge limit", fmt.Sprintf("%d%%", was.ChargeLimit), fmt.Sprintf("%d%%", now.ChargeLimit),
+		revert(Scene{ChargeLimit: was.ChargeLimit}))
+	sameProfile := was.Profile == now.Profile
+	if sameProfile && was.CPUCurve != "" && now.CPUCurve != "" {
+		add("CPU fan curve", was.CPUCurve, now.CPUCurve, revert(Scene{CPUCurve: was.CPUCurve}))
+		add("GPU fan curve", was.GPUCurve, now.GPUCurve, revert(Scene{GPUCurve: was.GPUCurve}))
+		if was.MidCurve != "" && now.MidCurve != "" {
+			add("Mid fan curve", was.MidCurve, now.MidCurve, revert(Scene{MidCurve: was.MidCurve}))
+		}
+	}
+
+	// A view of its own: the BIOS tab's may hold edits not yet written
+	var bios armouryView
+	if len(a.launch.bios) > 0 {
+		bios = readArmouryView(a.backend)
+	}
+	for _, at := range a.launch.bios {
+		if !sameProfile && indexFold(pptAttrs, at.Name) >= 0 {
+			continue
+		}
+		for _, cur := range bios.attrs {
+			if cur.Name == at.Name {
+				name, val := at.Name, at.Value
+				add(armouryLabel(at.Name), armouryValueLabel(at, at.Value), armouryValueLabel(cur, cur.Value),
+					func(done func(bool)) {
+						a.setArmoury(&bios, name, val, func(ok bool) {
+							if ok {
+								a.bios.setValue(name, val)
+							}
+							done(ok)
+						})
+					})
+			}
+		}
+	}
+	return items
+}
+
+func (a *App) openChanges() {
+	a.changes = &changesView{items: a.changesSinceLaunch()}
+}
+
+func (a *App) renderChanges() {
+	v := a.changes
+	t := a.term
+	W, H := t.Width(), t.Height()
+	w := min(76, W-4)
+	h := min(max(len(v.items), 1)+6, H-2)
+	x, y := (W-w)/2, (H-h)/2
+
+	t.ResetStyle()
+	t.FillRect(x, y, w, h, ColCard)
+	t.Bg(ColCard)
+	t.DrawBox(x, y, w, h, t.Accent())
+	t.ResetStyle()
+	t.Bg(ColCard)
+	t.Bold()
+	t.Fg(ColText)
+	t.MoveTo(x+2, y+1)
+	t.Write("Changed since launch (" + a.launch.at.Format("15:04") + ")")
+
+	if len(v.items) == 0 {
+		t.TextBg(x+2, y+3, ColTextMut, ColCard, "Nothing has changed since launch")
+	}
+	visible := max(h-6, 1)
+	first := clamp(v.sel-visible+1, 0, max(len(v.items)-visible, 0))
+	for i := first; i < min(len(v.items), first+visible); i++ {
+		it := v.items[i]
+		row := y + 3 + i - first
+		fg := ColTextDim
+		line := "  " + pad(it.label, 22)
+		if i == v.sel {
+			fg, line = ColText, "▸ "+pad(it.label, 22)
+		}
+		change := it.was + " → " + it.now
+		if it.reverted {
+			change = "reverted to " + it.was
+		}
+		t.ResetStyle()
+		t.Bg(ColCard)
+		t.Fg(fg)
+		t.MoveTo(x+2, row)
+		a.writeFocused(line)
+		col := ColText
+		if it.reverted {
+			col = ColSuccess
+		}
+		if r := []rune(change); len(r) > w-28 {
+			change = string(r[:w-29]) + "…"
+		}
+		t.TextBg(x+26, row, col, ColCard, change)
+	}
+	t.TextBg(x+2, y+h-2, ColTextMut, ColCard, "↑↓ select  r revert  R revert all  Esc close")
+	t.ResetStyle()
+}
+
+// handleChanges consumes every key while the overlay is open.
+func (a *App) handleChanges(key KeyEvent) {
+	v := a.changes
+	switch key.Type {
+	case KeyEscape, KeyCtrlC:
+		a.changes = nil
+	case KeyUp:
+		v.sel = max(v.sel-1, 0)
+	case KeyDown:
+		v.sel = min(v.sel+1, max(len(v.items)-1, 0))
+	case KeyChar:
+		switch key.Char {
+		case 'q', 'D':
+			a.changes = nil
+		case 'r':
+			if v.sel < len(v.items) {
+				a.revertChange(&v.items[v.sel])
+			}
+		case 'R':
+			var left []string
+			for i := range v.items {
+				if !v.items[i].reverted {
+					left = append(left, v.items[i].label)
+				}
+			}
+			if len(left) == 0 {
+				return
+			}
+			a.ask(&Confirm{
+				Title: "Revert everything to its launch value?",
+				Lines: []string{strings.Join(left, ", ")},
+				OnYes: func() {
+					for i := range v.items {
+						if !v.items[i].reverted {
+							a.revertChange(&v.items[i])
+						}
+					}
+				},
+			})
+		}
+	}
+}
+
+func (a *App) revertChange(it *stateChange) {
+	if it.reverted {
+		return
+	}
+	it.revert(func(ok bool) {
+		if ok {
+			it.reverted = true
+		}
+	})
+}
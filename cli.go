@@ -0,0 +1,23 @@
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// One-shot commands — `asusctl-gui <command> …` does one thing against the
+// backend and exits, without a terminal UI. For keybindings and scripts.
+// ═══════════════════════════════════════════════════════════════════════════════
+
+const commandUsage = "commands: aura apply <favourite>, aura list"
+
+// runCommand runs the command in args and returns the exit status.
+func runCommand(b Backend, cfg *Config, args []string) int {
+	switch args[0] {
+	case "aura":
+		return runAuraCommand(b, cfg, args[1:])
+	}
+	fmt.Fprintf(os.Stderr, "unknown command %q; %s\n", args[0], commandUsage)
+	return 2
+}
@@ -0,0 +1,205 @@
+package main
+
+import (
+	"fmt"
+	"math"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// HSV colour picker — h on a colour row of the Aura tab opens a modal with
+// hue, saturation and value sliders drawn as truecolor gradients, starting
+// from the row's selected colour. Enter makes the result the row's custom
+// colour (see aura_colour.go); nothing reaches the keyboard until a.
+// ═══════════════════════════════════════════════════════════════════════════════
+
+type colourPicker struct {
+	row     int // colour row it was opened from, 0 or 1
+	h, s, v int // hue 0–359, saturation and value 0–100
+	sel     int // focused slider: 0 hue, 1 saturation, 2 value
+}
+
+var pickerSliders = []struct {
+	label string
+	max   int
+	unit  string
+}{{"Hue", 359, "°"}, {"Saturation", 100, "%"}, {"Value", 100, "%"}}
+
+// hsvToRGB converts hue in degrees and saturation / value in percent.
+func hsvToRGB(h, s, v int) Color {
+	hf, sf, vf := float64(h%360)/60, float64(s)/100, float64(v)/100
+	c := vf * sf
+	x := c * (1 - math.Abs(math.Mod(hf, 2)-1))
+	var r, g, b float64
+	switch int(hf) {
+	case 0:
+		r, g = c, x
+	case 1:
+		r, g = x, c
+	case 2:
+		g, b = c, x
+	case 3:
+		g, b = x, c
+	case 4:
+		r, b = x, c
+	default:
+		r, b = c, x
+	}
+	m := vf - c
+	to := func(f float64) int { return int(math.Round((f + m) * 255)) }
+	return Color{to(r), to(g), to(b)}
+}
+
+func rgbToHSV(c Color) (h, s, v int) {
+	r, g, b := float64(c.R)/255, float64(c.G)/255, float64(c.B)/255
+	hi, lo := math.Max(r, math.Max(g, b)), math.Min(r, math.Min(g, b))
+	d := hi - lo
+	var hf float64
+	switch {
+	case d == 0:
+	case hi == r:
+		hf = math.Mod((g-b)/d+6, 6)
+	case hi == g:
+		hf = (b-r)/d + 2
+	default:
+		hf = (r-g)/d + 4
+	}
+	if hi > 0 {
+		s = int(math.Round(d / hi * 100))
+	}
+	return int(math.Round(hf*60)) % 360, s, int(math.Round(hi * 100))
+}
+
+func (p *colourPicker) value(i int) *int {
+	return [...]*int{&p.h, &p.s, &p.v}[i]
+}
+
+// at is the colour with slider i at pos and the others as they are.
+func (p *colourPicker) at(i, pos int) Color {
+	h, s, v := p.h, p.s, p.v
+	switch i {
+	case 0:
+		h = pos
+	case 1:
+		s = pos
+	default:
+		v = pos
+	}
+	return hsvToRGB(h, s, v)
+}
+
+func (p *colourPicker) colour() AuraColour {
+	c := hsvToRGB(p.h, p.s, p.v)
+	hex := fmt.Sprintf("%02x%02x%02x", c.R, c.G, c.B)
+	return AuraColour{Name: "#" + hex, Hex: hex, Rgb: c}
+}
+
+func (a *App) openColourPicker(row int) {
+	h, s, v := rgbToHSV(a.selectedAuraColour(row).Rgb)
+	a.picker = &colourPicker{row: row, h: h, s: s, v: v}
+}
+
+func (a *App) renderColourPicker() {
+	p := a.picker
+	t := a.term
+	W, H := t.Width(), t.Height()
+	w := min(64, W-4)
+	h := 13
+	x, y := (W-w)/2, (H-h)/2
+	barW := w - 22
+
+	t.ResetStyle()
+	t.FillRect(x, y, w, h, ColCard)
+	t.Bg(ColCard)
+	t.DrawBox(x, y, w, h, t.Accent())
+	t.ResetStyle()
+	t.Bg(ColCard)
+	t.Bold()
+	t.Fg(ColText)
+	t.MoveTo(x+2, y+1)
+	title := "Pick colour"
+	if p.row == 1 {
+		title = "Pick colour 2"
+	}
+	t.Write(title)
+
+	for i, sl := range pickerSliders {
+		row := y + 3 + i*2
+		label := "  " + sl.label
+		fg := ColTextDim
+		if i == p.sel {
+			label, fg = "▸ "+sl.label, ColText
+		}
+		t.ResetStyle()
+		t.Bg(ColCard)
+		t.Fg(fg)
+		t.MoveTo(x+2, row)
+		a.writeFocused(pad(label, 13))
+
+		cur := *p.value(i)
+		mark := cur * (barW - 1) / sl.max
+		for c := 0; c < barW; c++ {
+			col := p.at(i, c*sl.max/max(barW-1, 1))
+			t.ResetStyle()
+			t.Bg(col)
+			t.MoveTo(x+15+c, row)
+			if c == mark {
+				// black or white, whichever shows on the gradient
+				if col.R*3+col.G*6+col.B > 1280 {
+					t.Fg(Color{0, 0, 0})
+				} else {
+					t.Fg(Color{255, 255, 255})
+				}
+				t.Bold()
+				t.Write("◆")
+			} else {
+				t.Write(" ")
+			}
+		}
+		t.TextBg(x+16+barW, row, fg, ColCard, fmt.Sprintf("%3d%s", cur, sl.unit))
+	}
+
+	c := p.colour()
+	t.TextBg(x+2, y+9, ColTextDim, ColCard, "Result")
+	t.TextBg(x+15, y+9, ColText, c.Rgb, "        ")
+	t.TextBg(x+24, y+9, ColText, ColCard, c.Name)
+	t.TextBg(x+2, y+h-2, ColTextMut, ColCard, "↑↓ slider  ←→ adjust  PgUp/PgDn ×10  Enter use  Esc cancel")
+	t.ResetStyle()
+}
+
+// handleColourPicker consumes every key while the picker is open.
+func (a *App) handleColourPicker(key KeyEvent) {
+	p := a.picker
+	step := 0
+	switch key.Type {
+	case KeyEscape, KeyCtrlC:
+		a.picker = nil
+		a.SetStatus("Cancelled", true)
+	case KeyUp:
+		p.sel = (p.sel + 2) % 3
+	case KeyDown:
+		p.sel = (p.sel + 1) % 3
+	case KeyLeft:
+		step = -1
+	case KeyRight:
+		step = 1
+	case KeyPgDn:
+		step = -10
+	case KeyPgUp:
+		step = 10
+	case KeyEnter:
+		a.picker = nil
+		a.selectCustomColour(p.row, p.colour())
+	case KeyChar:
+		if key.Char == 'q' || key.Char == 'h' {
+			a.picker = nil
+		}
+	}
+	if step != 0 {
+		v, hi := p.value(p.sel), pickerSliders[p.sel].max
+		if p.sel == 0 {
+			*v = (*v + step + 360) % 360 // hue wraps around
+		} else {
+			*v = clamp(*v+step, 0, hi)
+		}
+	}
+}
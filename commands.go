@@ -0,0 +1,154 @@
+package main
+
+import "strings"
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// Command palette — Ctrl-P lists what can be switched to from any tab:
+// workspaces, scenes, the recommended defaults, the config file in $EDITOR
+// and tabs. Typing narrows the list (every word must appear in an entry),
+// ↑↓ pick and Enter runs the entry, which does what its own tab would. An
+// overlay like the changes view.
+// ═══════════════════════════════════════════════════════════════════════════════
+
+type command struct {
+	label string // shown, and what the filter matches
+	hint  string // dimmed after the label
+	run   func()
+}
+
+type commandPalette struct {
+	input LineEdit
+	sel   int
+}
+
+// commandList is every entry, workspaces first.
+func (a *App) commandList() []command {
+	var cmds []command
+	for _, w := range a.cfg.Workspaces {
+		w := w
+		hint := "no scene"
+		if w.Scene != "" {
+			hint = "scene " + w.Scene
+		}
+		if strings.EqualFold(w.Name, a.workspace) {
+			hint = "● active · " + hint
+		}
+		cmds = append(cmds, command{"Workspace: " + w.Name, hint, func() { a.switchWorkspace(w) }})
+	}
+	if !a.cfg.Lockdown.TabLocked(TabScenes) {
+		for _, sc := range a.cfg.Scenes {
+			sc := sc
+			cmds = append(cmds, command{"Scene: " + sc.Name, sc.Describe(), func() { a.applyScene(sc, nil) }})
+		}
+	}
+	cmds = append(cmds, command{"Reset to recommended defaults", "Balanced, 80% charge, static white Aura…", a.askResetDefaults})
+	cmds = append(cmds, command{"Edit config file", "in " + editorCommand() + ", reloaded on return", func() { a.editConfig("") }})
+	for _, s := range configSections {
+		prefix := s.prefix
+		cmds = append(cmds, command{"Edit config: " + s.label, s.prefix, func() { a.editConfig(prefix) }})
+	}
+	for i, name := range tabNames {
+		tab := Tab(i)
+		if a.cfg.Lockdown.TabLocked(tab) {
+			continue
+		}
+		hint := ""
+		if tabKeys[i] != "" {
+			hint = "key " + tabKeys[i]
+		}
+		cmds = append(cmds, command{"Go to: " + name, hint, func() { a.switchTab(tab) }})
+	}
+	return cmds
+}
+
+// filtered is the entries matching the typed words.
+func (p *commandPalette) filtered(cmds []command) []command {
+	words := strings.Fields(strings.ToLower(p.input.String()))
+	var out []command
+	for _, c := range cmds {
+		label := strings.ToLower(c.label)
+		match := true
+		for _, w := range words {
+			match = match && strings.Contains(label, w)
+		}
+		if match {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+func (a *App) openCommands() {
+	a.commands = &commandPalette{}
+}
+
+func (a *App) renderCommands() {
+	p := a.commands
+	t := a.term
+	W, H := t.Width(), t.Height()
+	cmds := p.filtered(a.commandList())
+	w := min(70, W-4)
+	h := min(max(len(cmds), 1)+7, H-2)
+	x, y := (W-w)/2, (H-h)/2
+
+	t.ResetStyle()
+	t.FillRect(x, y, w, h, ColCard)
+	t.Bg(ColCard)
+	t.DrawBox(x, y, w, h, t.Accent())
+	t.ResetStyle()
+	t.Bg(ColCard)
+	t.Bold()
+	t.Fg(ColText)
+	t.MoveTo(x+2, y+1)
+	t.Write("Go to, apply or switch workspace")
+	p.input.Render(t, x+2, y+2, w-4, true)
+
+	if len(cmds) == 0 {
+		t.TextBg(x+2, y+4, ColTextMut, ColCard, "Nothing matches")
+	}
+	visible := max(h-7, 1)
+	first := clamp(p.sel-visible+1, 0, max(len(cmds)-visible, 0))
+	for i := first; i < min(len(cmds), first+visible); i++ {
+		c := cmds[i]
+		row := y + 4 + i - first
+		fg, line := ColTextDim, "  "+c.label
+		if i == p.sel {
+			fg, line = ColText, "▸ "+c.label
+		}
+		t.ResetStyle()
+		t.Bg(ColCard)
+		t.Fg(fg)
+		t.MoveTo(x+2, row)
+		a.writeFocused(line)
+		hint := c.hint
+		if r := []rune(hint); len(r) > w-34 {
+			hint = string(r[:max(w-35, 0)]) + "…"
+		}
+		t.TextBg(x+32, row, ColTextMut, ColCard, hint)
+	}
+	t.TextBg(x+2, y+h-2, ColTextMut, ColCard, "type to filter  ↑↓ select  Enter run  Esc close")
+	t.ResetStyle()
+}
+
+// handleCommands consumes every key while the palette is open.
+func (a *App) handleCommands(key KeyEvent) {
+	p := a.commands
+	cmds := p.filtered(a.commandList())
+	switch key.Type {
+	case KeyEscape, KeyCtrlC, KeyCtrlP:
+		a.commands = nil
+	case KeyUp:
+		p.sel = max(p.sel-1, 0)
+	case KeyDown:
+		p.sel = min(p.sel+1, max(len(cmds)-1, 0))
+	case KeyEnter:
+		if p.sel < len(cmds) {
+			a.commands = nil
+			cmds[p.sel].run()
+		}
+	default:
+		if p.input.HandleKey(key) {
+			p.sel = 0
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// asusctl version detection and argument mapping
+// The CLI was reshaped between 4.x, 5.x and 6.x; every high-level backend call
+// goes through argsFor so the right syntax is emitted for the detected version.
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// latestCliMajor is assumed when the version probe fails.
+const latestCliMajor = 6
+
+// cliArgTable maps an operation to per-major-version argument templates.
+// "{0}", "{1}"… are replaced by the call's parameters. Lookup picks the
+// highest version key that is <= the detected major version.
+var cliArgTable = map[string]map[int][]string{
+	"profile.get":  {4: {"profile", "-p"}, 6: {"profile", "get"}},
+	"profile.set":  {4: {"profile", "-P", "{0}"}, 6: {"profile", "set", "{0}"}},
+	"profile.next": {4: {"profile", "-n"}, 6: {"profile", "next"}},
+	"profile.list": {4: {"profile", "-l"}, 6: {"profile", "list"}},
+
+	"leds.get":  {4: {"-k"}, 6: {"leds", "get"}},
+	"leds.set":  {4: {"--kbd-bright", "{0}"}, 6: {"leds", "set", "{0}"}},
+	"leds.next": {4: {"--next-kbd-bright"}, 6: {"leds", "next"}},
+	"leds.prev": {4: {"--prev-kbd-bright"}, 6: {"leds", "prev"}},
+
+	"battery.info":    {4: {"-c"}, 6: {"battery", "info"}},
+	"battery.limit":   {4: {"--chg-limit", "{0}"}, 6: {"battery", "limit", "{0}"}},
+	"battery.oneshot": {4: {"--one-shot-chg"}, 6: {"battery", "oneshot"}},
+
+	"screenpad.brightness": {6: {"backlight", "--screenpad-brightness", "{0}"}},
+
+	"aura.power.sleep": {4: {"led-pow-2", "keyboard", "--sleep", "{0}"}, 6: {"aura-power", "keyboard", "--sleep", "{0}"}},
+	"aura.power.awake": {4: {"led-pow-2", "{0}", "--awake", "{1}"}, 6: {"aura-power", "{0}", "--awake", "{1}"}},
+
+	"aura.effect": {4: {"led-mode", "{0}"}, 5: {"aura", "{0}"}, 6: {"aura", "effect", "{0}"}},
+	"aura.next":   {4: {"led-mode", "-n"}, 5: {"aura", "-n"}, 6: {"aura", "effect", "--next-mode"}},
+	"aura.prev":   {4: {"led-mode", "-p"}, 5: {"aura", "-p"}, 6: {"aura", "effect", "--prev-mode"}},
+}
+
+// parseCliVersion extracts the major version from `asusctl --version` output
+// such as "asusctl v6.1.4" or "asusctl version: 5.0.10". Returns 0 if unknown.
+func parseCliVersion(out string) int {
+	for _, field := range strings.Fields(out) {
+		field = strings.TrimPrefix(strings.ToLower(field), "v")
+		dot := strings.Index(field, ".")
+		if dot <= 0 {
+			continue
+		}
+		if major, err := strconv.Atoi(field[:dot]); err == nil {
+			return major
+		}
+	}
+	return 0
+}
+
+// argsFor builds the asusctl arguments for op on the given major version.
+func argsFor(major int, op string, params ...string) []string {
+	variants, ok := cliArgTable[op]
+	if !ok {
+		return nil
+	}
+	if major <= 0 {
+		major = latestCliMajor
+	}
+	best := -1
+	for v := range variants {
+		if v <= major && v > best {
+			best = v
+		}
+	}
+	if best < 0 {
+		// Older than anything we know: use the oldest syntax.
+		for v := range variants {
+			if best < 0 || v < best {
+				best = v
+			}
+		}
+	}
+	tmpl := variants[best]
+	args := make([]string, len(tmpl))
+	for i, a := range tmpl {
+		if len(a) == 3 && a[0] == '{' && a[2] == '}' {
+			if n := int(a[1] - '0'); n < len(params) {
+				a = params[n]
+			}
+		}
+		args[i] = a
+	}
+	return args
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseCliVersion(t *testing.T) {
+	tests := []struct {
+		out  string
+		want int
+	}{
+		{"asusctl v4.7.2\n", 4},
+		{"  asusctl v4.0.7\n  daemon v4.0.7\n", 4},
+		{"asusctl version: 5.0.10\n", 5},
+		{"asusctl v6.1.4\n", 6},
+		{"asusctl 6.0.12", 6},
+		{"asusctl V6.1.0-rc1", 6},
+		{"", 0},
+		{"bash: asusctl: command not found", 0},
+		{"error: unexpected argument '--version' found", 0},
+		{"asusctl version: .5", 0},
+		{"asusctl vX.1", 0},
+	}
+	for _, tt := range tests {
+		if got := parseCliVersion(tt.out); got != tt.want {
+			t.Errorf("parseCliVersion(%q) = %d, want %d", tt.out, got, tt.want)
+		}
+	}
+}
+
+func TestArgsFor(t *testing.T) {
+	tests := []struct {
+		name   string
+		major  int
+		op     string
+		params []string
+		want   []string
+	}{
+		{"6.x syntax", 6, "profile.set", []string{"Quiet"}, []string{"profile", "set", "Quiet"}},
+		{"4.x syntax", 4, "profile.set", []string{"Quiet"}, []string{"profile", "-P", "Quiet"}},
+		{"5.x falls back to 4.x", 5, "profile.set", []string{"Quiet"}, []string{"profile", "-P", "Quiet"}},
+		{"5.x has its own", 5, "aura.effect", []string{"static"}, []string{"aura", "static"}},
+		{"unknown version is the latest", 0, "leds.next", nil, []string{"leds", "next"}},
+		{"newer than known", 7, "battery.limit", []string{"80"}, []string{"battery", "limit", "80"}},
+		{"older than known", 3, "battery.limit", []string{"80"}, []string{"--chg-limit", "80"}},
+		{"op only in a later version", 4, "screenpad.brightness", []string{"50"},
+			[]string{"backlight", "--screenpad-brightness", "50"}},
+		{"two parameters", 6, "aura.power.awake", []string{"logo", "false"},
+			[]string{"aura-power", "logo", "--awake", "false"}},
+		{"missing parameter kept", 6, "profile.set", nil, []string{"profile", "set", "{0}"}},
+		{"unknown op", 6, "fan.turbo", nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := argsFor(tt.major, tt.op, tt.params...); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("argsFor(%d, %q, %q) = %q, want %q", tt.major, tt.op, tt.params, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package main
+
+import "time"
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// Completion alert — with completion_alert on, a queued write that took
+// longer than slowWriteAfter rings the terminal bell and flashes the header
+// when it finishes, if the user has moved to another tab meanwhile. The
+// result itself is reported by the job's own status message as usual.
+// ═══════════════════════════════════════════════════════════════════════════════
+
+const (
+	slowWriteAfter = 2 * time.Second
+	headerFlashFor = time.Second
+)
+
+// submit queues a write like CommandQueue.Submit, remembering the tab it
+// was started from and how long it ran, for the completion alert. Time
+// spent waiting behind other writes does not count. Writes queued for a
+// scene report to it too.
+func (a *App) submit(key string, run func(b Backend) (bool, string), done func(ok bool, out string, argv []string)) {
+	from, steps := a.activeTab, a.sceneSteps
+	var took time.Duration // set by the worker before done is sent
+	a.queue.Submit(key, func(b Backend) (bool, string) {
+		start := time.Now()
+		ok, out := run(b)
+		took = time.Since(start)
+		return ok, out
+	}, func(ok bool, out string, argv []string) {
+		done(ok, out, argv)
+		steps.add(key, ok)
+		a.alertIfMissed(from, took, ok)
+	})
+}
+
+// alertIfMissed rings and flashes for a slow write the user looked away
+// from.
+func (a *App) alertIfMissed(from Tab, took time.Duration, ok bool) {
+	if !a.cfg.CompletionAlert || a.quick || took < slowWriteAfter || a.activeTab == from {
+		return
+	}
+	a.term.Bell()
+	a.headerFlash = time.Now().Add(headerFlashFor)
+	a.headerFlashOk = ok
+	// Nothing else may redraw once the flash is over
+	time.AfterFunc(headerFlashFor, a.RequestRender)
+}
+
+// headerBg is the header's background: the panel colour, or green / red
+// while a completion flash lasts.
+func (a *App) headerBg() Color {
+	if time.Now().After(a.headerFlash) {
+		return ColPanel
+	}
+	c := ColSuccess
+	if !a.headerFlashOk {
+		c = ColError
+	}
+	return Color{c.R / 3, c.G / 3, c.B / 3}
+}
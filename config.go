@@ -0,0 +1,465 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// Config — user settings in $XDG_CONFIG_HOME/asusctl-tui/config.toml,
+// layered over the admin's /etc/asusctl-tui/config.toml
+// ═══════════════════════════════════════════════════════════════════════════════
+
+type Config struct {
+	// Show the exact asusctl command under the footer after each action
+	ShowCommands bool `toml:"show_commands"`
+	// Give Aura, Battery and Fans their own accent colour
+	TabAccents bool `toml:"tab_accents"`
+	// Ease toggles and bars to their new value instead of jumping, and play
+	// the Aura tab's effect preview
+	Animations bool `toml:"animations"`
+	// "celsius" or "fahrenheit"; sensors and fan curves stay in °C underneath
+	TemperatureUnit string `toml:"temperature_unit"`
+	// Profile tab layout: "cards", or "compact" for one line per profile
+	// with live readings below
+	ProfileLayout string `toml:"profile_layout"`
+	// Spacing in the tabs: "compact", "normal" or "comfortable"
+	Density string `toml:"density"`
+	// Colour theme: "dark", "high-contrast" or "light"
+	Theme string `toml:"theme"`
+	// Plain ASCII instead of box drawing, arrows and other symbols
+	ASCIIMode bool `toml:"ascii_mode"`
+	// How often live readings refresh, as a Go duration
+	RefreshInterval string `toml:"refresh_interval"`
+	// Ask "are you sure?" before overwriting or reverting things
+	ConfirmPrompts bool `toml:"confirm_prompts"`
+	// Moving the selection on the Profile, Keyboard and Aura tabs applies
+	// it; on Aura once it rests
+	ApplyOnSelect bool `toml:"apply_on_select"`
+	// Bell and header flash when a slow write finishes while another tab
+	// is open
+	CompletionAlert bool `toml:"completion_alert"`
+	// How the focused item is marked: "marker" (▸), "reverse", "blink" or
+	// "caret"
+	FocusStyle string `toml:"focus_style"`
+
+	// How long one asusctl call may take, as a Go duration ("5s", "1500ms")
+	CommandTimeout string `toml:"command_timeout"`
+	// Extra attempts when asusd is restarting or a call hangs
+	Retries int `toml:"retries"`
+	// Console lines kept across restarts in the state directory and loaded
+	// back at startup; 0 keeps none
+	ConsoleHistory int `toml:"console_history"`
+	// Aura effects shown first in the Aura tab's grid (* pins one), in the
+	// order pinned
+	PinnedEffects []string `toml:"pinned_effects"`
+
+	GameMode     GameModeConfig     `toml:"game_mode"`
+	QuietHours   QuietHoursConfig   `toml:"quiet_hours"`
+	DisplayRules []DisplayRule      `toml:"display_rules"`
+	PowerSource  PowerSourceConfig  `toml:"power_source"`
+	AuraProfiles AuraProfilesConfig `toml:"aura_profiles"`
+	Anime        AnimeConfig        `toml:"anime"`
+	Scenes       []Scene            `toml:"scenes"`
+	Rules        []Rule             `toml:"rules"`
+	AuraPresets  []AuraPreset       `toml:"aura_presets"`
+	Palette      []PaletteColour    `toml:"palette"`
+	Workspaces   []Workspace        `toml:"workspaces"`
+	// Per-key layouts saved from the Aura tab's per-key editor (k)
+	PerKeyLayouts []PerKeyLayout `toml:"per_key_layouts"`
+
+	// Read from the system-wide file only; see lockdown.go
+	Lockdown LockdownConfig `toml:"-"`
+
+	path   string  // file this was loaded from, for Persist
+	system *Config // defaults plus the system-wide file; nil without one
+}
+
+// systemConfigPath holds defaults an admin ships for every user. The user's
+// file is applied on top, so any key set there wins.
+var systemConfigPath = "/etc/asusctl-tui/config.toml"
+
+// GameModeConfig is what game mode changes while it is active.
+type GameModeConfig struct {
+	// Switch off the desktop's Super key action (GNOME/KDE)
+	DisableSuper bool `toml:"disable_super"`
+	// Shell command run on each ROG key press; empty leaves the key alone
+	RogKeyCommand string `toml:"rog_key_command"`
+	// Power profile while game mode is on; empty leaves it alone
+	Profile string `toml:"profile"`
+	// Turn game mode on while a game is registered with Feral GameMode
+	Feral bool `toml:"feral_gamemode"`
+}
+
+// QuietHoursConfig is the nightly window in which quiet.go forces the
+// Quiet profile and caps fan speeds. Empty start or end turns it off.
+type QuietHoursConfig struct {
+	// Window as "HH:MM", e.g. "23:00" to "07:00"; it may span midnight
+	Start string `toml:"start"`
+	End   string `toml:"end"`
+	// Highest speed any Quiet fan curve point may ask for, in percent
+	MaxFan int `toml:"max_fan"`
+}
+
+// DisplayRule is one [[display_rules]] entry: what to do when an external
+// monitor is plugged in or out. See hotplug.go.
+type DisplayRule struct {
+	// "attach" or "detach"
+	On string `toml:"on"`
+	// Connector glob such as "HDMI*" or "DP-2"; empty matches any
+	Connector string `toml:"connector"`
+	// Profile to switch to; empty leaves the profile alone
+	Profile string `toml:"profile"`
+	// Suggest the dGPU MUX mode when the iGPU drives the display
+	MuxAdvice bool `toml:"mux_advice"`
+}
+
+// PowerSourceConfig is the profile to switch to when the charger is
+// connected or removed. Empty leaves the profile alone. See power.go.
+type PowerSourceConfig struct {
+	ACProfile      string `toml:"ac_profile"`
+	BatteryProfile string `toml:"battery_profile"`
+}
+
+// AuraProfilesConfig is the Aura favourite ([[aura_presets]] name) applied
+// when the profile switches to each one. Empty leaves Aura alone. See
+// aura_follow.go.
+type AuraProfilesConfig struct {
+	Performance string `toml:"performance"`
+	Balanced    string `toml:"balanced"`
+	Quiet       string `toml:"quiet"`
+}
+
+// AnimeConfig is what --daemon shows on the AniMe lid display. See anime.go.
+type AnimeConfig struct {
+	// Battery percentage and charging state, kept up to date
+	Battery bool `toml:"battery"`
+}
+
+// Scene is one [[scenes]] entry: settings saved under a name and applied
+// together from the Scenes tab. Empty (or zero) fields are left alone.
+type Scene struct {
+	Name    string `toml:"name"`
+	Profile string `toml:"profile"`
+	// Keyboard backlight: "off", "low", "med" or "high"
+	Keyboard string `toml:"keyboard"`
+	// Aura effect by its Aura tab name, colours as "rrggbb"
+	AuraMode  string `toml:"aura_mode"`
+	Colour1   string `toml:"colour1"`
+	Colour2   string `toml:"colour2"`
+	AuraSpeed string `toml:"aura_speed"`
+	// Zone colours in zone order on multizone keyboards, as "rrggbb"
+	Zones []string `toml:"zones"`
+	// Charge limit in percent
+	ChargeLimit int `toml:"charge_limit"`
+	// Fan curves for the scene's profile, as "30c:0%,40c:5%,…"
+	CPUCurve string `toml:"cpu_curve"`
+	GPUCurve string `toml:"gpu_curve"`
+	MidCurve string `toml:"mid_curve"` // models with a mid fan only
+}
+
+// AuraPreset is one [[aura_presets]] entry: an Aura effect saved as a
+// favourite on the Aura tab and applied by name, also from the command line
+// (`aura apply <name>`).
+type AuraPreset struct {
+	Name string `toml:"name"`
+	// Effect by its Aura tab name, colours as "rrggbb"; unused ones are empty
+	Mode    string `toml:"mode"`
+	Colour1 string `toml:"colour1"`
+	Colour2 string `toml:"colour2"`
+	Speed   string `toml:"speed"`
+	// Rainbow Wave's left, right, up or down
+	Direction string `toml:"direction"`
+	// Zone colours in zone order on multizone keyboards, as "rrggbb"
+	Zones []string `toml:"zones"`
+}
+
+// PaletteColour is one [[palette]] entry: a colour saved from the Aura tab,
+// shown after the built-in swatches. See aura_colour.go.
+type PaletteColour struct {
+	Name string `toml:"name"`
+	Hex  string `toml:"hex"` // "rrggbb"
+}
+
+// PerKeyLayout is one [[per_key_layouts]] entry: a colour per key saved
+// from the per-key editor. See aura_perkey.go.
+type PerKeyLayout struct {
+	Name string `toml:"name"`
+	// Colours as "rrggbb" in the editor's key order, "" for an unlit key
+	Keys []string `toml:"keys"`
+}
+
+// Workspace is one [[workspaces]] entry: a scene and a set of rules for one
+// place, switched from the command palette (Ctrl-P). See workspace.go.
+type Workspace struct {
+	Name string `toml:"name"`
+	// Scene applied on switching to it, by name; empty applies none
+	Scene string `toml:"scene"`
+	// Wi-Fi networks that select it in --daemon mode
+	SSIDs []string `toml:"ssids"`
+}
+
+// Rule is one [[rules]] entry: actions run when a trigger fires. See
+// rules.go. Empty (or zero) actions leave that setting alone.
+type Rule struct {
+	// "battery", "ac", "profile" or "battery_below"
+	When string `toml:"when"`
+	// For "profile": the profile switched to; empty matches any
+	Profile string `toml:"profile"`
+	// For "battery_below": the charge in percent the battery drops under
+	Below int `toml:"below"`
+
+	SetProfile  string `toml:"set_profile"`
+	ChargeLimit int    `toml:"charge_limit"`
+	// Keyboard backlight: "off", "low", "med" or "high"
+	Keyboard string `toml:"keyboard"`
+	// Aura effect by its Aura tab name, and its colour as "rrggbb"
+	AuraMode string `toml:"aura_mode"`
+	Colour   string `toml:"colour"`
+
+	// Only while this workspace is active; empty runs in every one
+	Workspace string `toml:"workspace"`
+}
+
+// LockdownConfig is the [lockdown] table of /etc/asusctl-tui/config.toml.
+type LockdownConfig struct {
+	// Tabs that cannot be opened, by name ("BIOS", "Console")
+	Tabs []string `toml:"tabs"`
+	// Actions that are refused, see lockActions
+	Actions []string `toml:"actions"`
+}
+
+func DefaultConfig() *Config {
+	return &Config{
+		Animations:      true,
+		TemperatureUnit: "celsius",
+		ProfileLayout:   "cards",
+		Density:         "normal",
+		FocusStyle:      "marker",
+		Theme:           "dark",
+		RefreshInterval: sensorSampleEvery.String(),
+		ConfirmPrompts:  true,
+		CommandTimeout:  defaultCommandPolicy.Timeout.String(),
+		Retries:         defaultCommandPolicy.Retries,
+		QuietHours:      QuietHoursConfig{MaxFan: 40},
+	}
+}
+
+// CommandPolicy converts the timeout and retry settings for the backend.
+func (c *Config) CommandPolicy() (CommandPolicy, error) {
+	p := defaultCommandPolicy
+	if c.CommandTimeout != "" {
+		d, err := time.ParseDuration(c.CommandTimeout)
+		if err != nil || d <= 0 {
+			return p, fmt.Errorf("command_timeout: invalid duration %q", c.CommandTimeout)
+		}
+		p.Timeout = d
+	}
+	if c.Retries < 0 {
+		return p, fmt.Errorf("retries: must not be negative")
+	}
+	p.Retries = c.Retries
+	return p, nil
+}
+
+// RefreshEvery is refresh_interval, or the default when unset or invalid.
+func (c *Config) RefreshEvery() time.Duration {
+	d, err := time.ParseDuration(c.RefreshInterval)
+	if err != nil || d < minRefresh {
+		return sensorSampleEvery
+	}
+	return d
+}
+
+// minRefresh keeps the sensor poller from spinning.
+const minRefresh = 500 * time.Millisecond
+
+// check validates the settings that need more than their type.
+func (c *Config) check() error {
+	if _, err := c.CommandPolicy(); err != nil {
+		return err
+	}
+	if u := c.TemperatureUnit; u != "" && u != "celsius" && u != "fahrenheit" {
+		return fmt.Errorf("temperature_unit: %q must be \"celsius\" or \"fahrenheit\"", u)
+	}
+	if l := c.ProfileLayout; l != "" && l != "cards" && l != "compact" {
+		return fmt.Errorf("profile_layout: %q must be \"cards\" or \"compact\"", l)
+	}
+	if d := c.Density; d != "" && indexFold(densities, d) < 0 {
+		return fmt.Errorf("density: %q must be \"compact\", \"normal\" or \"comfortable\"", d)
+	}
+	c.Density = strings.ToLower(c.Density) // densityStep matches exactly
+	if th := c.Theme; th != "" && indexFold(themeNames(), th) < 0 {
+		return fmt.Errorf("theme: %q must be \"dark\", \"high-contrast\" or \"light\"", th)
+	}
+	if r := c.RefreshInterval; r != "" {
+		if d, err := time.ParseDuration(r); err != nil || d < minRefresh {
+			return fmt.Errorf("refresh_interval: %q must be a duration of at least %s", r, minRefresh)
+		}
+	}
+	if c.ConsoleHistory < 0 {
+		return fmt.Errorf("console_history: must not be negative")
+	}
+	if f := c.FocusStyle; f != "" && indexFold(focusStyles, f) < 0 {
+		return fmt.Errorf("focus_style: %q must be \"marker\", \"reverse\", \"blink\" or \"caret\"", f)
+	}
+	c.FocusStyle = strings.ToLower(c.FocusStyle) // writeFocused matches exactly
+	if p := c.GameMode.Profile; p != "" && matchProfile(p) == "" {
+		return fmt.Errorf("game_mode.profile: unknown profile %q", p)
+	}
+	if err := c.QuietHours.Validate(); err != nil {
+		return err
+	}
+	for i, r := range c.DisplayRules {
+		if err := r.Validate(); err != nil {
+			return fmt.Errorf("display_rules[%d]: %w", i, err)
+		}
+	}
+	for i, r := range c.Rules {
+		if err := r.Validate(); err != nil {
+			return fmt.Errorf("rules[%d]: %w", i, err)
+		}
+	}
+	for i, sc := range c.Scenes {
+		if err := sc.Validate(); err != nil {
+			return fmt.Errorf("scenes[%d]: %w", i, err)
+		}
+	}
+	for i, p := range c.AuraPresets {
+		if err := p.Validate(); err != nil {
+			return fmt.Errorf("aura_presets[%d]: %w", i, err)
+		}
+	}
+	for i, p := range c.Palette {
+		if err := p.Validate(); err != nil {
+			return fmt.Errorf("palette[%d]: %w", i, err)
+		}
+	}
+	for i, l := range c.PerKeyLayouts {
+		if err := l.Validate(); err != nil {
+			return fmt.Errorf("per_key_layouts[%d]: %w", i, err)
+		}
+	}
+	if err := c.checkWorkspaces(); err != nil {
+		return err
+	}
+	if err := c.checkPinnedEffects(); err != nil {
+		return err
+	}
+	if err := c.checkAuraProfiles(); err != nil {
+		return err
+	}
+	return c.PowerSource.Validate()
+}
+
+// configDir returns $XDG_CONFIG_HOME/asusctl-tui (or ~/.config/asusctl-tui).
+func configDir() string {
+	base := os.Getenv("XDG_CONFIG_HOME")
+	if base == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return ""
+		}
+		base = filepath.Join(home, ".config")
+	}
+	return filepath.Join(base, "asusctl-tui")
+}
+
+func defaultConfigPath() string {
+	dir := configDir()
+	if dir == "" {
+		return ""
+	}
+	return filepath.Join(dir, "config.toml")
+}
+
+// LoadConfig reads the system-wide file and then path on top of the
+// defaults. Missing files are not an error — they just add nothing.
+func LoadConfig(path string) (*Config, error) {
+	cfg := DefaultConfig()
+	cfg.path = path
+
+	sysData, err := readConfigFile(systemConfigPath)
+	if err != nil {
+		return cfg, err
+	}
+	if sysData != "" {
+		sys := DefaultConfig()
+		if err := decodeToml(sysData, sys); err != nil {
+			return cfg, fmt.Errorf("%s: %w", systemConfigPath, err)
+		}
+		if err := sys.check(); err != nil {
+			return cfg, fmt.Errorf("%s: %w", systemConfigPath, err)
+		}
+		var policy struct {
+			Lockdown LockdownConfig `toml:"lockdown"`
+		}
+		if err := decodeToml(sysData, &policy); err != nil {
+			return cfg, fmt.Errorf("%s: %w", systemConfigPath, err)
+		}
+		if err := policy.Lockdown.Validate(); err != nil {
+			return cfg, fmt.Errorf("%s: %w", systemConfigPath, err)
+		}
+		decodeToml(sysData, cfg) // same input, cannot fail now
+		cfg.Lockdown = policy.Lockdown
+		cfg.system = sys
+	}
+
+	userData, err := readConfigFile(path)
+	if err != nil {
+		return cfg, err
+	}
+	if err := decodeToml(userData, cfg); err != nil {
+		return cfg, fmt.Errorf("%s: %w", path, err)
+	}
+	if err := cfg.check(); err != nil {
+		return cfg, fmt.Errorf("%s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// readConfigFile returns the file's contents, or "" if it does not exist.
+func readConfigFile(path string) (string, error) {
+	if path == "" {
+		return "", nil
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", nil
+	}
+	return string(data), err
+}
+
+// Persist saves the config back to the file it was loaded from.
+func (c *Config) Persist() error {
+	if c.path == "" {
+		return errors.New("no config file path")
+	}
+	return c.Save(c.path)
+}
+
+// Save writes the config atomically (temp file + rename).
+func (c *Config) Save(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	// Over a system-wide file only the user's own differences are written,
+	// so later changes to the system defaults still reach this user
+	var body string
+	if c.system != nil {
+		body = encodeTomlOver(*c, *c.system)
+	} else {
+		body = encodeToml(*c)
+	}
+	data := "# asusctl-tui configuration\n\n" + body
+	if err := os.WriteFile(tmp, []byte(data), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
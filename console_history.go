@@ -0,0 +1,178 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// Console history — with console_history = N in the config, every console
+// line (typed commands and the actions the tabs ran) is appended to
+// console.log in the state directory, and the last N are loaded back into
+// the Console at startup. Lines from earlier days show their date. The file
+// is cut back as it grows, so --daemon can run for months. Demo mode
+// neither reads nor writes the file.
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// historyLine is one console line on disk, as a line of JSON.
+type historyLine struct {
+	At      time.Time `json:"at"`
+	Command string    `json:"command"`
+	Output  string    `json:"output,omitempty"`
+	Ok      bool      `json:"ok"`
+	Hint    string    `json:"hint,omitempty"`
+}
+
+// consoleHistory tracks the file between loadConsoleHistory and the
+// appends. Lines logged during the startup reads wait in pending until the
+// load, so they are not loaded back as history of their own run.
+type consoleHistory struct {
+	loaded  bool
+	pending []historyLine
+	lines   int // lines in the file, to know when to cut it back
+}
+
+func consoleHistoryPath() string {
+	dir := stateDir()
+	if dir == "" {
+		return ""
+	}
+	return filepath.Join(dir, "console.log")
+}
+
+// historyOn reports whether console lines are kept across runs.
+func (a *App) historyOn() bool {
+	_, demo := a.backend.(*MockBackend)
+	return a.cfg.ConsoleHistory > 0 && !demo && consoleHistoryPath() != ""
+}
+
+// readConsoleHistory returns the last keep lines of the file and how many
+// it holds. Damaged lines are skipped; lines have no length limit.
+func readConsoleHistory(path string, keep int) ([]historyLine, int, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer f.Close()
+	var lines []historyLine
+	total := 0
+	r := bufio.NewReader(f)
+	for {
+		data, err := r.ReadBytes('\n')
+		var h historyLine
+		if len(data) > 0 && json.Unmarshal(data, &h) == nil { // else torn or damaged
+			total++
+			lines = append(lines, h)
+			if len(lines) > keep {
+				lines = lines[1:]
+			}
+		}
+		if err == io.EOF {
+			return lines, total, nil
+		}
+		if err != nil {
+			return lines, total, err
+		}
+	}
+}
+
+// loadConsoleHistory puts the last console_history lines from earlier runs
+// in front of the console log, then writes out the lines logged so far.
+// A file grown past twice that is cut back to them.
+func (a *App) loadConsoleHistory() {
+	if !a.historyOn() || a.history.loaded {
+		return
+	}
+	path := consoleHistoryPath()
+	lines, total, err := readConsoleHistory(path, a.cfg.ConsoleHistory)
+	a.history.loaded = true
+	a.history.lines = total
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		// Cutting back a file that could not be read would lose lines
+		a.history.lines = 0
+		a.consoleLog = append(a.consoleLog, ConsoleLine{
+			Time: time.Now().Format("15:04:05"), Command: "console history", Output: err.Error(),
+		})
+	} else if total > 2*a.cfg.ConsoleHistory {
+		writeConsoleHistory(path, lines)
+		a.history.lines = len(lines)
+	}
+	pending := a.history.pending
+	a.history.pending = nil
+	for _, h := range pending {
+		a.writeHistoryLine(h)
+	}
+
+	today := time.Now().Format("2006-01-02")
+	restored := make([]ConsoleLine, 0, len(lines)+len(a.consoleLog))
+	for _, h := range lines {
+		l := ConsoleLine{Time: h.At.Format("15:04:05"), Command: h.Command, Output: h.Output, Ok: h.Ok, Hint: h.Hint}
+		if h.At.Format("2006-01-02") != today {
+			l.Time = h.At.Format("Jan 2 15:04")
+		}
+		restored = append(restored, l)
+	}
+	a.consoleLog = append(restored, a.consoleLog...)
+}
+
+// appendConsoleHistory adds one line to the file, or holds it while the
+// startup reads run and the history is not loaded yet.
+func (a *App) appendConsoleHistory(l ConsoleLine) {
+	if !a.historyOn() {
+		return
+	}
+	h := historyLine{At: time.Now(), Command: l.Command, Output: l.Output, Ok: l.Ok, Hint: l.Hint}
+	if a.loading && !a.history.loaded {
+		a.history.pending = append(a.history.pending, h)
+		return
+	}
+	a.writeHistoryLine(h)
+}
+
+// writeHistoryLine appends h to the file and cuts the file back once it
+// holds twice console_history lines, so a long --daemon run stays bounded.
+// Errors are dropped: the history must never get in the way of the action
+// it records.
+func (a *App) writeHistoryLine(h historyLine) {
+	path := consoleHistoryPath()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return
+	}
+	data, _ := json.Marshal(h)
+	_, err = f.Write(append(data, '\n'))
+	f.Close()
+	if err != nil {
+		return
+	}
+	a.history.lines++
+	if a.history.lines > 2*a.cfg.ConsoleHistory {
+		if lines, _, err := readConsoleHistory(path, a.cfg.ConsoleHistory); err == nil {
+			writeConsoleHistory(path, lines)
+			a.history.lines = len(lines)
+		}
+	}
+}
+
+// writeConsoleHistory replaces the file with lines (temp file + rename).
+func writeConsoleHistory(path string, lines []historyLine) {
+	var b strings.Builder
+	for _, h := range lines {
+		data, _ := json.Marshal(h)
+		b.Write(data)
+		b.WriteByte('\n')
+	}
+	if os.WriteFile(path+".tmp", []byte(b.String()), 0o600) == nil {
+		os.Rename(path+".tmp", path)
+	}
+}
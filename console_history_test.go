@@ -0,0 +1,69 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+)
+
+// newHistoryApp is an App that keeps n console lines in a temporary state
+// directory. The mock is wrapped, as demo mode keeps no history.
+func newHistoryApp(t *testing.T, n int) *App {
+	t.Helper()
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+	cfg := DefaultConfig()
+	cfg.ConsoleHistory = n
+	return NewApp(NewFakeTerminal(80, 24, io.Discard), NewCachedBackend(NewMockBackend()), cfg)
+}
+
+func TestConsoleHistoryStartupLinesOnce(t *testing.T) {
+	a := newHistoryApp(t, 10)
+	a.addLog("earlier", "", true)
+	a.loading = true
+	a.history = consoleHistory{}
+	a.consoleLog = nil
+	a.addLog("during startup", "", true)
+	a.loading = false
+	a.loadConsoleHistory()
+	var got []string
+	for _, l := range a.consoleLog {
+		got = append(got, l.Command)
+	}
+	if want := "earlier,during startup"; strings.Join(got, ",") != want {
+		t.Errorf("console = %q, want %q", got, want)
+	}
+	lines, _, err := readConsoleHistory(consoleHistoryPath(), 10)
+	if err != nil || len(lines) != 2 {
+		t.Errorf("file holds %d lines (%v), want 2", len(lines), err)
+	}
+}
+
+func TestConsoleHistoryStaysBounded(t *testing.T) {
+	a := newHistoryApp(t, 5)
+	a.loadConsoleHistory()
+	for i := 0; i < 100; i++ {
+		a.addLog(fmt.Sprintf("line %d", i), "", true)
+	}
+	lines, total, err := readConsoleHistory(consoleHistoryPath(), 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total > 10 {
+		t.Errorf("file holds %d lines, want at most 10", total)
+	}
+	if lines[len(lines)-1].Command != "line 99" {
+		t.Errorf("last line = %q", lines[len(lines)-1].Command)
+	}
+}
+
+func TestConsoleHistoryLongLine(t *testing.T) {
+	a := newHistoryApp(t, 5)
+	a.loadConsoleHistory()
+	a.addLog("big", strings.Repeat("x", 2<<20), true)
+	a.addLog("after", "", true)
+	lines, _, err := readConsoleHistory(consoleHistoryPath(), 5)
+	if err != nil || len(lines) != 2 || lines[1].Command != "after" {
+		t.Errorf("read %d lines (%v), want both", len(lines), err)
+	}
+}
@@ -0,0 +1,180 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// Console search — '/' on an empty Console prompt finds text in the log's
+// commands, outputs and hints. Matches are highlighted; n steps to older
+// matches and N back to newer ones, keeping the current one at the top of
+// the log until it is scrolled. Esc ends the search.
+// ═══════════════════════════════════════════════════════════════════════════════
+
+type consoleSearch struct {
+	query  string
+	match  int  // consoleLog index of the current match, -1 for none
+	follow bool // draw the log from the current match
+}
+
+func (l ConsoleLine) matches(q string) bool {
+	q = strings.ToLower(q)
+	for _, s := range []string{l.Command, l.Output, l.Hint} {
+		if strings.Contains(strings.ToLower(s), q) {
+			return true
+		}
+	}
+	return false
+}
+
+func (a *App) promptConsoleSearch() {
+	a.prompt = &Prompt{
+		Title: "Search console",
+		Label: "Text to find in commands and output",
+		OnOk: func(q string) {
+			a.consoleFind = consoleSearch{query: q, match: len(a.consoleLog)}
+			a.findConsole(-1)
+		},
+	}
+}
+
+// findConsole moves to the next match older (dir < 0) or newer (dir > 0)
+// than the current one.
+func (a *App) findConsole(dir int) {
+	s := &a.consoleFind
+	for i := s.match + dir; i >= 0 && i < len(a.consoleLog); i += dir {
+		if !a.consoleLog[i].matches(s.query) {
+			continue
+		}
+		s.match, s.follow = i, true
+		n, of := 0, 0
+		for j, l := range a.consoleLog {
+			if l.matches(s.query) {
+				of++
+				if j <= i {
+					n++
+				}
+			}
+		}
+		a.SetStatus(fmt.Sprintf("%q: match %d of %d (n older, N newer, Esc done)", s.query, n, of), true)
+		return
+	}
+	found := false
+	for _, l := range a.consoleLog {
+		found = found || l.matches(s.query)
+	}
+	switch {
+	case !found:
+		s.match = -1
+		a.SetStatus(fmt.Sprintf("%q not found in the console log", s.query), false)
+	case dir < 0:
+		a.SetStatus("No older matches", false)
+	default:
+		a.SetStatus("No newer matches", false)
+	}
+}
+
+// endConsoleSearch drops the query and its highlighting.
+func (a *App) endConsoleSearch() {
+	a.consoleFind = consoleSearch{match: -1}
+}
+
+// trimConsoleSearch keeps the current match on its line after n lines
+// were dropped from the front of the log. A match that was dropped starts
+// the search over from the newest line, so n finds the next one.
+func (a *App) trimConsoleSearch(n int) {
+	s := &a.consoleFind
+	if s.match < 0 || s.query == "" {
+		return
+	}
+	if s.match -= n; s.match < 0 {
+		s.match, s.follow = len(a.consoleLog), false
+	}
+}
+
+// consoleRows is how many lines the Console's log area shows.
+func (a *App) consoleRows() int {
+	_, h := a.contentArea()
+	return consoleRowsIn(h)
+}
+
+func consoleRowsIn(h int) int { return max(h-7, 3) }
+
+// consoleStart is the first log line shown: the current match while the
+// view follows it, else consoleScroll lines up from the newest.
+func (a *App) consoleStart() int {
+	if f := a.consoleFind; f.follow && f.query != "" {
+		return clamp(f.match, 0, max(len(a.consoleLog)-1, 0))
+	}
+	return max(len(a.consoleLog)-a.consoleRows()-a.consoleScroll, 0)
+}
+
+// unfollowConsole turns the view that follows a match into a scroll
+// position showing the same lines, for the scroll keys to move from.
+func (a *App) unfollowConsole() {
+	if f := &a.consoleFind; f.follow {
+		a.consoleScroll = max(len(a.consoleLog)-a.consoleRows()-a.consoleStart(), 0)
+		f.follow = false
+	}
+}
+
+// shownOutput is the line of an entry's output the Console shows, cut to
+// maxW columns: the first, or during a search the first holding the query,
+// marked "… " when it is a later one. A match past maxW is scrolled into
+// view.
+func (a *App) shownOutput(out string, maxW int) string {
+	lines := strings.Split(out, "\n")
+	line, lq := lines[0], strings.ToLower(a.consoleFind.query)
+	if lq != "" && !strings.Contains(strings.ToLower(line), lq) {
+		for _, l := range lines[1:] {
+			if strings.Contains(strings.ToLower(l), lq) {
+				line = "… " + l
+				break
+			}
+		}
+	}
+	r := []rune(line)
+	if len(r) <= maxW {
+		return line
+	}
+	lower := strings.ToLower(line)
+	if i := strings.Index(lower, lq); lq != "" && i >= 0 && len(lower) == len(line) {
+		at := utf8.RuneCountInString(line[:i])
+		if end := at + utf8.RuneCountInString(lq); end > maxW-1 {
+			r = append([]rune("…"), r[min(end-maxW+2, at):]...)
+		}
+	}
+	if len(r) > maxW {
+		r = append(r[:maxW-1], '…')
+	}
+	return string(r)
+}
+
+// writeHighlighted writes s at the cursor in fg, with each occurrence of
+// the search query in reverse video.
+func (a *App) writeHighlighted(s string, fg Color) {
+	t := a.term
+	t.Fg(fg)
+	q := a.consoleFind.query
+	if q == "" {
+		t.Write(s)
+		return
+	}
+	lower := strings.ToLower(s)
+	lq := strings.ToLower(q)
+	for {
+		i := strings.Index(lower, lq)
+		if i < 0 || len(lower) != len(s) || len(lq) != len(q) { // case folding changed byte offsets
+			t.Write(s)
+			return
+		}
+		t.Write(s[:i])
+		t.Reverse()
+		t.Write(s[i : i+len(q)])
+		t.ResetStyle()
+		t.Fg(fg)
+		s, lower = s[i+len(q):], lower[i+len(q):]
+	}
+}
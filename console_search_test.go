@@ -0,0 +1,77 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+)
+
+func newConsoleApp(t *testing.T) *App {
+	t.Helper()
+	a := NewApp(NewFakeTerminal(100, 30, io.Discard), NewMockBackend(), DefaultConfig())
+	a.consoleFind.match = -1
+	return a
+}
+
+// TestConsoleSearchAfterTrim checks n still finds matches once the current
+// one was dropped from the front of the log.
+func TestConsoleSearchAfterTrim(t *testing.T) {
+	a := newConsoleApp(t)
+	a.addLog("needle 0", "", true)
+	for i := 0; i < 99; i++ {
+		a.addLog(fmt.Sprintf("hay %d", i), "", true)
+	}
+	a.consoleFind = consoleSearch{query: "needle", match: len(a.consoleLog)}
+	a.findConsole(-1)
+	if a.consoleFind.match != 0 {
+		t.Fatalf("match = %d, want 0", a.consoleFind.match)
+	}
+	a.addLog("needle 1", "", true) // drops needle 0
+	a.findConsole(-1)
+	if got := a.consoleLog[max(a.consoleFind.match, 0)].Command; a.consoleFind.match < 0 || got != "needle 1" {
+		t.Errorf("match = %d (%q), want needle 1; status %q", a.consoleFind.match, got, a.statusMsg)
+	}
+}
+
+func TestConsoleRenderKeepsScroll(t *testing.T) {
+	a := newConsoleApp(t)
+	for i := 0; i < 50; i++ {
+		a.addLog(fmt.Sprintf("cmd %d", i), "", true)
+	}
+	a.consoleFind = consoleSearch{query: "cmd 10", match: 10, follow: true}
+	a.renderConsole(a.contentArea())
+	if a.consoleScroll != 0 {
+		t.Errorf("render moved consoleScroll to %d", a.consoleScroll)
+	}
+	a.unfollowConsole()
+	if got := len(a.consoleLog) - a.consoleRows() - a.consoleScroll; got != 10 {
+		t.Errorf("after unfollowing the view starts at %d, want 10", got)
+	}
+}
+
+func TestShownOutput(t *testing.T) {
+	a := newConsoleApp(t)
+	tests := []struct {
+		query, out string
+		maxW       int
+		want       string
+	}{
+		{"", "one\ntwo", 20, "one"},
+		{"two", "one\ntwo", 20, "… two"},
+		{"one", "one\ntwo", 20, "one"},
+		{"", "abcdefghij", 6, "abcde…"},
+		{"ij", "abcdefghij", 6, "…ghij"},
+		{"zz", "abcdefghij", 6, "abcde…"},
+	}
+	for _, tt := range tests {
+		a.consoleFind.query = tt.query
+		got := a.shownOutput(tt.out, tt.maxW)
+		if got != tt.want {
+			t.Errorf("shownOutput(%q, %d) with %q = %q, want %q", tt.out, tt.maxW, tt.query, got, tt.want)
+		}
+		if !strings.Contains(strings.ToLower(got), tt.query) && strings.Contains(tt.out, tt.query) {
+			t.Errorf("%q hides the match", got)
+		}
+	}
+}
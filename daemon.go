@@ -0,0 +1,57 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// Daemon mode — the automation policies without a terminal (--daemon)
+// The App runs as usual against a discarded screen: quiet hours, display
+// rules and the background samplers keep working, and every logged action is
+// printed to stderr, which systemd sends to the journal.
+// ═══════════════════════════════════════════════════════════════════════════════
+
+func runDaemon(backend Backend, cfg *Config) int {
+	app := NewApp(NewFakeTerminal(80, 24, io.Discard), backend, cfg)
+	app.logTo = os.Stderr
+	app.automation.wifiAuto = cfg.hasWifiWorkspaces()
+	app.Init()
+	if !app.installed {
+		fmt.Fprintln(os.Stderr, "asusctl not found")
+		return 1
+	}
+	fmt.Fprintf(os.Stderr, "asusctl-tui %s: daemon started, %d display rules, quiet hours %s\n",
+		fullVersion(), len(cfg.DisplayRules), quietSummary(cfg.QuietHours))
+	if cfg.Anime.Battery {
+		app.startAnimeBattery()
+	}
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		<-sigCh
+		app.Post(func() { app.running = false })
+	}()
+
+	for app.running {
+		select {
+		case fn := <-app.events:
+			fn()
+		case fn := <-app.queue.Done():
+			fn()
+		}
+	}
+	app.Shutdown()
+	return 0
+}
+
+func quietSummary(q QuietHoursConfig) string {
+	if !q.Enabled() {
+		return "off"
+	}
+	return q.Start + "–" + q.End
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// Page: Dashboard — the live readings and current settings on one screen
+// The values come from the sensor poller (every refresh_interval), which also re-reads the
+// battery while this tab is open.
+// ═══════════════════════════════════════════════════════════════════════════════
+
+const dashGaugeW = 16
+
+type dashGauge struct {
+	value, label string
+	col          Color
+}
+
+// tempColor colours a temperature reading by how hot it is.
+func tempColor(c float64) Color {
+	switch {
+	case c >= 90:
+		return ColError
+	case c >= 75:
+		return ColWarning
+	case c <= 0:
+		return ColTextMut
+	}
+	return ColSuccess
+}
+
+func (a *App) renderDashboard(y, h int) {
+	t := a.term
+	W := t.Width()
+	cx := a.padX()
+	s := a.sensors
+	bat := a.planner.battery
+
+	t.TextBold(cx, y+1, ColText, "Dashboard")
+	t.Text(cx, y+2, ColTextDim, "Live readings, refreshed every "+a.cfg.RefreshEvery().String())
+
+	// ─── Big gauges ───
+	gauges := []dashGauge{
+		{formatTemp(s.CPUTempC), "CPU", tempColor(s.CPUTempC)},
+		{formatTemp(s.GPUTempC), "GPU", tempColor(s.GPUTempC)},
+	}
+	if bat.Present {
+		col := ColSuccess
+		switch {
+		case bat.Percent <= 15:
+			col = ColError
+		case bat.Percent <= 35:
+			col = ColWarning
+		}
+		gauges = append(gauges, dashGauge{fmt.Sprintf("%d%%", bat.Percent), "Battery", col})
+		if bat.PowerW > 0 {
+			label := "W draw"
+			if bat.Status == "Charging" {
+				label = "W charging"
+			}
+			gauges = append(gauges, dashGauge{fmt.Sprintf("%.1f", bat.PowerW), label, ColText})
+		}
+	}
+	perRow := max((W-cx)/(dashGaugeW+2), 1)
+	for i, g := range gauges {
+		gx := cx + (i%perRow)*(dashGaugeW+2)
+		gy := y + 4 + (i/perRow)*5
+		t.DrawBigGauge(gx, gy, dashGaugeW, g.value, g.label, g.col)
+	}
+	row := y + 4 + ((len(gauges)-1)/perRow+1)*5
+
+	// ─── Temperature bars and fans ───
+	barW := min(W-30, 40)
+	for _, tb := range []struct {
+		label string
+		c     float64
+	}{{"CPU", s.CPUTempC}, {"GPU", s.GPUTempC}} {
+		t.Text(cx, row, ColTextDim, tb.label)
+		t.DrawBar(cx+6, row, barW, math.Min(tb.c, 100)/100, tempColor(tb.c), ColInput)
+		t.Text(cx+7+barW, row, ColText, formatTemp(tb.c))
+		row++
+	}
+	row++
+	var fans []string
+	for _, f := range s.Fans {
+		fans = append(fans, fmt.Sprintf("%s %d rpm", strings.TrimSuffix(f.Label, "_fan"), f.RPM))
+	}
+	t.Text(cx, row, ColTextDim, "Fans")
+	if len(fans) == 0 {
+		t.Text(cx+6, row, ColTextMut, "no fan tachometers in hwmon")
+	} else {
+		t.Text(cx+6, row, ColText, strings.Join(fans, "   "))
+	}
+	row += 2
+
+	// ─── Settings ───
+	t.HLine(cx, row, min(W-6, 60), ColBorder)
+	row++
+	mux := "Hybrid"
+	if a.gpuMuxDedicated {
+		mux = "Dedicated"
+	}
+	gpu := "MUX " + mux
+	if a.gfx.mode != "" {
+		gpu = a.gfx.mode + " · " + gpu
+	}
+	lit := auraModes[a.auraMode]
+	if a.kbdLevel == 0 {
+		lit += " (backlight off)"
+	}
+	for _, kv := range [][2]string{
+		{"Profile", a.profile},
+		{"Aura", lit},
+		{"GPU", gpu},
+	} {
+		if row >= y+h {
+			break
+		}
+		t.Text(cx, row, ColTextDim, kv[0])
+		t.TextBold(cx+10, row, ColText, kv[1])
+		if kv[0] == "Aura" && auraEffectNeedsColour1(auraModes[a.auraMode]) {
+			t.TextBg(cx+11+len([]rune(lit)), row, ColText, a.selectedAuraColour(0).Rgb, "  ")
+		}
+		row++
+	}
+}
+
+// refreshDashboard re-reads what the sensor poller does not cover.
+func (a *App) refreshDashboard() {
+	a.planner.battery = a.backend.GetBatteryStatus()
+}
+
+func (a *App) handleDashboard(key KeyEvent) {
+	if key.Type == KeyChar && key.Char == 'r' {
+		a.sensors = a.backend.ReadSensors()
+		a.refreshDashboard()
+		a.SetStatus("Dashboard refreshed", true)
+	}
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// Live sync — follow asusd D-Bus signals so external changes (Fn keys, other
+// tools) show up immediately. Uses dbus-monitor to stay dependency-free.
+// ═══════════════════════════════════════════════════════════════════════════════
+
+type ChangeArea int
+
+const (
+	ChangeProfile ChangeArea = iota
+	ChangeKeyboard
+	ChangeAura
+	ChangeFans
+	ChangeAreaCount
+)
+
+// ChangeWatcher is implemented by backends that can report state changed
+// outside the TUI. onChange is called from a background goroutine.
+type ChangeWatcher interface {
+	WatchChanges(onChange func(ChangeArea)) error
+}
+
+// asusdBusNames covers the current and the pre-6.0 service names.
+var asusdBusNames = []string{"xyz.ljones.Asusd", "org.asuslinux.Daemon"}
+
+// signalAreas maps property/member names seen in asusd signals to the area
+// that needs re-reading.
+var signalAreas = []struct {
+	needle string
+	area   ChangeArea
+}{
+	{"PlatformProfile", ChangeProfile},
+	{"ThrottlePolicy", ChangeProfile},
+	{"NotifyProfile", ChangeProfile},
+	{"LedBrightness", ChangeKeyboard},
+	{"Brightness", ChangeKeyboard},
+	{"NotifyLed", ChangeAura},
+	{"LedMode", ChangeAura},
+	{"LedModeData", ChangeAura},
+}
+
+// classifySignalLine returns the areas mentioned on one dbus-monitor line.
+func classifySignalLine(line string) []ChangeArea {
+	var areas []ChangeArea
+	seen := [ChangeAreaCount]bool{}
+	for _, s := range signalAreas {
+		if strings.Contains(line, s.needle) && !seen[s.area] {
+			seen[s.area] = true
+			areas = append(areas, s.area)
+		}
+	}
+	return areas
+}
+
+// WatchChanges follows asusd signals and rewrites of its config files.
+// It only fails if neither source could be started.
+func (b *ExecBackend) WatchChanges(onChange func(ChangeArea)) error {
+	errSignals := b.watchSignals(onChange)
+	errFiles := watchAsusdConfigs(asusdConfigDir, onChange)
+	if errSignals != nil && errFiles != nil {
+		return errors.Join(errSignals, errFiles)
+	}
+	return nil
+}
+
+func (b *ExecBackend) watchSignals(onChange func(ChangeArea)) error {
+	if _, err := hostLookPath("dbus-monitor"); err != nil {
+		return err
+	}
+	var rules []string
+	for _, name := range asusdBusNames {
+		rules = append(rules, "type='signal',sender='"+name+"'")
+	}
+	cmd := hostCommand("dbus-monitor", append([]string{"--system"}, rules...)...)
+	cmd.SysProcAttr = &syscall.SysProcAttr{Pdeathsig: syscall.SIGTERM}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	areas := make(chan ChangeArea, 64)
+	go func() {
+		sc := bufio.NewScanner(stdout)
+		for sc.Scan() {
+			for _, area := range classifySignalLine(sc.Text()) {
+				areas <- area
+			}
+		}
+		close(areas)
+		cmd.Wait()
+	}()
+	go coalesceChanges(areas, onChange)
+	return nil
+}
+
+// coalesceChanges forwards areas to onChange, collapsing bursts (one change
+// produces several signals or file events) so each area refreshes once.
+func coalesceChanges(in <-chan ChangeArea, onChange func(ChangeArea)) {
+	var pending [ChangeAreaCount]bool
+	var flush <-chan time.Time
+	for {
+		select {
+		case area, ok := <-in:
+			if !ok {
+				return
+			}
+			pending[area] = true
+			if flush == nil {
+				flush = time.After(150 * time.Millisecond)
+			}
+		case <-flush:
+			flush = nil
+			for area := ChangeArea(0); area < ChangeAreaCount; area++ {
+				if pending[area] {
+					pending[area] = false
+					onChange(area)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// Debug log — a trace of every host command (--debug)
+// execWithTimeout reports each run here: command line, duration, exit status
+// and output, appended to $XDG_STATE_HOME/asusctl-tui/debug.log for attaching
+// to bug reports. Past debugLogMax the file moves to debug.log.1.
+// ═══════════════════════════════════════════════════════════════════════════════
+
+const debugLogMax = 1 << 20 // bytes
+
+type debugLog struct {
+	mu   sync.Mutex
+	path string
+	f    *os.File
+	size int64
+}
+
+// tracer is nil unless --debug is set.
+var tracer *debugLog
+
+func debugLogPath() string {
+	dir := stateDir()
+	if dir == "" {
+		return ""
+	}
+	return filepath.Join(dir, "debug.log")
+}
+
+func openDebugLog(path string) (*debugLog, error) {
+	if path == "" {
+		return nil, errors.New("no state directory")
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, err
+	}
+	d := &debugLog{path: path}
+	if err := d.open(); err != nil {
+		return nil, err
+	}
+	d.write(fmt.Sprintf("──── asusctl-tui %s started %s ────\n", fullVersion(), time.Now().Format(time.RFC3339)))
+	return d, nil
+}
+
+func (d *debugLog) open() error {
+	f, err := os.OpenFile(d.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	st, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+	d.f, d.size = f, st.Size()
+	return nil
+}
+
+// write appends s, rotating first when the file is full. Errors are
+// dropped: tracing must never break the command it traces.
+func (d *debugLog) write(s string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.f == nil {
+		return
+	}
+	if d.size+int64(len(s)) > debugLogMax {
+		d.f.Close()
+		d.f = nil
+		os.Rename(d.path, d.path+".1")
+		if d.open() != nil {
+			return
+		}
+	}
+	n, _ := d.f.WriteString(s)
+	d.size += int64(n)
+}
+
+func (d *debugLog) Close() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.f != nil {
+		d.f.Close()
+		d.f = nil
+	}
+}
+
+// traceCommand logs one finished (or killed) run of cmd.
+func traceCommand(cmd *exec.Cmd, start time.Time, status, out string) {
+	if tracer == nil {
+		return
+	}
+	args := make([]string, len(cmd.Args))
+	for i, a := range cmd.Args {
+		args[i] = a
+		if a == "" || strings.ContainsAny(a, " \t\"'") {
+			args[i] = strconv.Quote(a)
+		}
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %s (%s) %s\n", start.Format("15:04:05.000"),
+		strings.Join(args, " "), time.Since(start).Round(time.Millisecond), status)
+	for _, line := range strings.Split(out, "\n") {
+		if line != "" {
+			b.WriteString("    " + line + "\n")
+		}
+	}
+	tracer.write(b.String())
+}
+
+// exitStatus describes how cmd ended for the trace.
+func exitStatus(cmd *exec.Cmd, err error) string {
+	if cmd.ProcessState != nil {
+		return fmt.Sprintf("exit %d", cmd.ProcessState.ExitCode())
+	}
+	if err != nil {
+		return "failed: " + err.Error()
+	}
+	return "exit 0"
+}
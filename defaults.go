@@ -0,0 +1,99 @@
+package main
+
+import "fmt"
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// Recommended defaults — a way back for a laptop tweaked into a bad state,
+// from the command palette. A dialog lists each setting that would change
+// (Balanced profile, 80% charge limit, static white Aura, custom fan curves
+// off, panel overdrive on); y applies them through applyScene and the
+// settings' own writers, so lockdown and quiet hours apply as usual.
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// recommendedDefaults is the scene part of the reset.
+var recommendedDefaults = Scene{
+	Name:        "recommended defaults",
+	Profile:     "Balanced",
+	AuraMode:    "Static",
+	Colour1:     "ffffff",
+	ChargeLimit: 80,
+}
+
+// askResetDefaults shows what the reset would change and applies it on y.
+// It always asks, whatever confirm_prompts says: the list is the point.
+func (a *App) askResetDefaults() {
+	a.panel = readPanelView(a.backend)
+	now := a.captureScene("now")
+	def := recommendedDefaults
+
+	var lines []string
+	add := func(label, was, want string) {
+		if was != want {
+			lines = append(lines, pad(label, 20)+was+" → "+want)
+		}
+	}
+	add("Profile", now.Profile, def.Profile)
+	add("Aura", auraSummary(now), auraSummary(def))
+	add("Charge limit", fmt.Sprintf("%d%%", now.ChargeLimit), fmt.Sprintf("%d%%", def.ChargeLimit))
+	switch {
+	case !a.fanEnabled:
+	case a.quiet.active:
+		lines = append(lines, pad("Custom fan curves", 20)+"on, kept while quiet hours cap them")
+	default:
+		lines = append(lines, pad("Custom fan curves", 20)+"on ("+a.profile+") → off for every profile")
+	}
+	if a.panel.odOk {
+		add("Panel overdrive", onOff(a.panel.od), "on")
+	}
+
+	a.confirm = &Confirm{
+		Title: "Reset to the recommended defaults?",
+		Lines: lines,
+		OnYes: a.resetDefaults,
+	}
+}
+
+// resetDefaults applies recommendedDefaults. The fan curve job is queued
+// behind the scene's, so the profile switch has gone through first. Quiet
+// hours keep the curves: they cap them until the window ends.
+func (a *App) resetDefaults() {
+	a.applyScene(recommendedDefaults, nil)
+	switch {
+	case a.cfg.Lockdown.ActionLocked("fan_curves"):
+	case a.quiet.active:
+		a.addLog("fan-curve --enable-fan-curves false", "held back by quiet hours", false)
+	default:
+		var argvs [][]string
+		var outs []string
+		var oks []bool
+		a.submit("defaults", func(b Backend) (bool, string) {
+			for _, p := range profileNames {
+				ok, out := b.EnableFanCurves(p, false)
+				argvs, outs, oks = append(argvs, b.LastCommand()), append(outs, out), append(oks, ok)
+				if !ok {
+					return false, out
+				}
+			}
+			return true, ""
+		}, func(ok bool, out string, argv []string) {
+			for i := range argvs {
+				a.logCommand(argvs[i], outs[i], oks[i])
+			}
+			if !ok {
+				a.SetError(out)
+				return
+			}
+			a.fanEnabled = false
+		})
+	}
+	if a.panel.odOk && !a.panel.od {
+		a.setPanelOverdrive(true)
+	}
+}
+
+func onOff(on bool) string {
+	if on {
+		return "on"
+	}
+	return "off"
+}
@@ -0,0 +1,38 @@
+package main
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// Density — how much space the tabs leave around and between things, from
+// density in the config. "compact" fits the tabs on short terminals,
+// "comfortable" spaces them out for large fonts. Layouts take their margins
+// from padX/padY and space their lists with spread.
+// ═══════════════════════════════════════════════════════════════════════════════
+
+var densities = []string{"compact", "normal", "comfortable"}
+
+// densityStep is -1 for compact, 0 for normal and 1 for comfortable.
+func (a *App) densityStep() int {
+	switch a.cfg.Density {
+	case "compact":
+		return -1
+	case "comfortable":
+		return 1
+	}
+	return 0
+}
+
+// padX is the content's left margin.
+func (a *App) padX() int {
+	return 3 + a.densityStep()
+}
+
+// padY shifts the content down from the tab bar: one row less when compact,
+// one more when comfortable.
+func (a *App) padY() int {
+	return a.densityStep()
+}
+
+// spread is the pitch of a list whose items are n rows apart at normal
+// density, never closer than one row.
+func (a *App) spread(n int) int {
+	return max(n+a.densityStep(), 1)
+}
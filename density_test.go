@@ -0,0 +1,23 @@
+package main
+
+import (
+	"io"
+	"testing"
+)
+
+func TestDensityIgnoresCase(t *testing.T) {
+	for _, tt := range []struct {
+		density string
+		step    int
+	}{{"Compact", -1}, {"NORMAL", 0}, {"comfortable", 1}} {
+		cfg := DefaultConfig()
+		cfg.Density = tt.density
+		if err := cfg.check(); err != nil {
+			t.Fatal(err)
+		}
+		a := NewApp(NewFakeTerminal(80, 24, io.Discard), NewMockBackend(), cfg)
+		if got := a.densityStep(); got != tt.step {
+			t.Errorf("density %q: step %d, want %d", tt.density, got, tt.step)
+		}
+	}
+}
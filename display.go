@@ -0,0 +1,99 @@
+package main
+
+import (
+	"bytes"
+	"path/filepath"
+	"sort"
+	"strings"
+	"syscall"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// Displays — external monitors from DRM connector status in sysfs
+// /sys/class/drm/card*-<connector>/status reads "connected" while a monitor
+// is plugged in. Hotplug is announced by a kernel uevent (SUBSYSTEM=drm,
+// HOTPLUG=1) on the NETLINK_KOBJECT_UEVENT socket; raw syscalls, no udev.
+// ═══════════════════════════════════════════════════════════════════════════════
+
+type DisplayControl interface {
+	// GetDisplays returns the connected external connectors, e.g. "HDMI-A-1".
+	GetDisplays() []string
+	// WatchDisplays calls onChange from a background goroutine after every
+	// DRM hotplug event until the process exits.
+	WatchDisplays(onChange func()) error
+	// GetPanelRates reads the built-in panel's refresh rates from the
+	// display server; see panel.go.
+	GetPanelRates() (PanelRates, error)
+	SetPanelRate(p PanelRates, i int) (bool, string)
+	// GetScreenPad reads the second screen of ScreenPad models; see
+	// screenpad.go.
+	GetScreenPad() ScreenPadState
+	SetScreenPadBrightness(level int) (bool, string)
+	SetScreenPadPower(on bool) (bool, string)
+}
+
+const drmSysfsDir = "/sys/class/drm"
+
+// internalConnectors are the built-in panel connector types.
+var internalConnectors = []string{"eDP", "LVDS", "DSI"}
+
+func readDisplays() []string {
+	var out []string
+	dirs, _ := filepath.Glob(filepath.Join(drmSysfsDir, "card[0-9]*-*"))
+	for _, d := range dirs {
+		// card1-HDMI-A-1 → HDMI-A-1
+		_, name, _ := strings.Cut(filepath.Base(d), "-")
+		if isInternalConnector(name) || readSysfsString(filepath.Join(d, "status")) != "connected" {
+			continue
+		}
+		out = append(out, name)
+	}
+	sort.Strings(out)
+	return out
+}
+
+func (b *ExecBackend) GetDisplays() []string { return readDisplays() }
+
+func (b *ExecBackend) WatchDisplays(onChange func()) error {
+	fd, err := syscall.Socket(syscall.AF_NETLINK, syscall.SOCK_DGRAM|syscall.SOCK_CLOEXEC, syscall.NETLINK_KOBJECT_UEVENT)
+	if err != nil {
+		return err
+	}
+	// group 1 carries the kernel's own uevents
+	if err := syscall.Bind(fd, &syscall.SockaddrNetlink{Family: syscall.AF_NETLINK, Groups: 1}); err != nil {
+		syscall.Close(fd)
+		return err
+	}
+	go func() {
+		defer syscall.Close(fd)
+		buf := make([]byte, 8192)
+		for {
+			n, _, err := syscall.Recvfrom(fd, buf, 0)
+			if err == syscall.EINTR || err == syscall.ENOBUFS {
+				continue
+			}
+			if err != nil {
+				return
+			}
+			if isDrmHotplug(buf[:n]) {
+				onChange()
+			}
+		}
+	}()
+	return nil
+}
+
+// isDrmHotplug reports whether a uevent message ("change@/devices/…" then
+// NUL-separated KEY=value pairs) is a DRM connector hotplug.
+func isDrmHotplug(msg []byte) bool {
+	var drm, hotplug bool
+	for _, f := range bytes.Split(msg, []byte{0}) {
+		switch string(f) {
+		case "SUBSYSTEM=drm":
+			drm = true
+		case "HOTPLUG=1":
+			hotplug = true
+		}
+	}
+	return drm && hotplug
+}
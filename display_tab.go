@@ -0,0 +1,326 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// Page: Display — the built-in panel: overdrive and mini-LED (firmware
+// attributes through asusctl armoury) and the refresh rate (display server);
+// the ScreenPad on models that have one
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// panelView is what the Display tab shows; read on tab entry.
+type panelView struct {
+	od        bool
+	odOk      bool
+	miniLed   string // current mini_led_mode value
+	miniLedOk bool
+	miniLeds  []string // values mini_led_mode accepts
+	rates     PanelRates
+	ratesErr  string
+	screenpad ScreenPadState
+}
+
+func readPanelView(b Backend) panelView {
+	var v panelView
+	if ok, out := b.GetPanelOverdrive(); ok {
+		v.odOk = true
+		v.od = parseArmouryValue(out) == "1"
+	}
+	if ok, out := b.GetArmoury("mini_led_mode"); ok {
+		v.miniLedOk = true
+		v.miniLed = parseArmouryValue(out)
+		v.miniLeds = parseArmouryOptions(out)
+		if len(v.miniLeds) == 0 {
+			v.miniLeds = []string{"0", "1"}
+		}
+	}
+	v.screenpad = b.GetScreenPad()
+	rates, err := b.GetPanelRates()
+	v.rates = rates
+	if err != nil {
+		v.ratesErr = err.Error()
+	}
+	return v
+}
+
+// miniLedLabel names a mini_led_mode value. Two-value panels are off/on;
+// newer ones add a second, stronger multi-zone level.
+func miniLedLabel(val string, values []string) string {
+	if len(values) <= 2 {
+		return map[string]string{"0": "Off", "1": "On"}[val]
+	}
+	switch val {
+	case "0":
+		return "Off"
+	case "1":
+		return "Multi-zone"
+	case "2":
+		return "Multi-zone strong"
+	}
+	return "Mode " + val
+}
+
+// Rows of the Display tab.
+const (
+	displayRowRate = iota
+	displayRowOverdrive
+	displayRowMiniLed
+	displayRowScreenPad
+	displayRowScreenPadBrightness
+	displayRowCount
+)
+
+func (a *App) renderDisplay(y, h int) {
+	t := a.term
+	cx := a.padX()
+	p := &a.panel
+
+	t.TextBold(cx, y+1, ColText, "Display")
+	t.Text(cx, y+2, ColTextDim, "The built-in panel and the ScreenPad")
+
+	label := func(row, idx int, text string) {
+		if a.focusIdx == idx {
+			a.focusText(cx, row, "▸ "+text)
+		} else {
+			t.Text(cx, row, ColTextDim, "  "+text)
+		}
+	}
+
+	// Refresh rate
+	row := y + 4
+	label(row, displayRowRate, "Refresh Rate")
+	if p.ratesErr != "" {
+		t.Text(cx+24, row, ColTextMut, "n/a")
+		t.Text(cx+2, row+1, ColTextMut, p.ratesErr)
+	} else {
+		x := cx + 24
+		for i, r := range p.rates.Rates {
+			text := fmt.Sprintf("%.0f Hz", r)
+			col := ColTextMut
+			if i == p.rates.Current {
+				col = a.accent()
+				text = "● " + text
+			}
+			t.Text(x, row, col, text)
+			x += len([]rune(text)) + 3
+		}
+		t.Text(cx+2, row+1, ColTextMut, fmt.Sprintf("%s at %s, set with %s", p.rates.Connector, p.rates.Mode, p.rates.Tool))
+	}
+
+	// Overdrive
+	row += 3
+	label(row, displayRowOverdrive, "Panel Overdrive")
+	t.Text(cx+2, row+1, ColTextMut, "Reduce ghosting (may introduce artifacts)")
+	if p.odOk {
+		t.DrawToggle(cx+24, row, p.od)
+	} else {
+		t.Text(cx+24, row, ColTextMut, "n/a")
+	}
+
+	// Mini-LED
+	row += 3
+	label(row, displayRowMiniLed, "Mini-LED Backlight")
+	t.Text(cx+2, row+1, ColTextMut, "Local dimming zones: deeper blacks, higher power draw")
+	if !p.miniLedOk {
+		t.Text(cx+24, row, ColTextMut, "n/a — no mini-LED panel")
+	} else {
+		x := cx + 24
+		for _, v := range p.miniLeds {
+			text := miniLedLabel(v, p.miniLeds)
+			col := ColTextMut
+			if v == p.miniLed {
+				col = a.accent()
+				text = "● " + text
+			}
+			t.Text(x, row, col, text)
+			x += len([]rune(text)) + 3
+		}
+	}
+
+	// ScreenPad
+	row += 3
+	sp := p.screenpad
+	label(row, displayRowScreenPad, "ScreenPad")
+	t.Text(cx+2, row+1, ColTextMut, "Second screen of Zenbook Duo / ScreenPad models")
+	if !sp.Present {
+		t.Text(cx+24, row, ColTextMut, "n/a — no ScreenPad")
+	} else {
+		t.DrawToggle(cx+24, row, sp.On)
+	}
+	row += 2
+	label(row, displayRowScreenPadBrightness, "ScreenPad Brightness")
+	if sp.Present {
+		pct := sp.Brightness * 100 / max(sp.Max, 1)
+		t.DrawBar(cx+24, row, 30, float64(pct)/100, a.accent(), ColInput)
+		t.Text(cx+56, row, ColText, fmt.Sprintf("%d%%", pct))
+	} else {
+		t.Text(cx+24, row, ColTextMut, "n/a")
+	}
+
+	t.Text(cx, row+2, ColTextMut, "↑↓ select  ←→ change  Enter toggle / next  r refresh")
+}
+
+func (a *App) handleDisplay(key KeyEvent) {
+	p := &a.panel
+	dir := 1
+	switch key.Type {
+	case KeyUp:
+		if a.focusIdx > 0 {
+			a.focusIdx--
+		}
+		return
+	case KeyDown:
+		if a.focusIdx < displayRowCount-1 {
+			a.focusIdx++
+		}
+		return
+	case KeyChar:
+		if key.Char == 'r' {
+			a.panel = readPanelView(a.backend)
+			a.SetStatus("Display state refreshed", true)
+		}
+		return
+	case KeyLeft:
+		dir = -1
+	case KeyRight, KeyEnter:
+	default:
+		return
+	}
+
+	switch a.focusIdx {
+	case displayRowRate:
+		if n := len(p.rates.Rates); n > 0 {
+			a.setPanelRate((p.rates.Current + dir + n) % n)
+		}
+	case displayRowOverdrive:
+		if key.Type == KeyEnter {
+			a.setPanelOverdrive(!p.od)
+		}
+	case displayRowScreenPad:
+		if key.Type == KeyEnter {
+			a.setScreenPadPower(!p.screenpad.On)
+		}
+	case displayRowScreenPadBrightness:
+		if sp := p.screenpad; sp.Present && key.Type != KeyEnter {
+			a.setScreenPadBrightness(sp.Brightness + dir*max(sp.Max/10, 1))
+		}
+	case displayRowMiniLed:
+		if n := len(p.miniLeds); n > 0 && p.miniLedOk {
+			cur := 0
+			for i, v := range p.miniLeds {
+				if v == p.miniLed {
+					cur = i
+				}
+			}
+			a.setMiniLed(p.miniLeds[(cur+dir+n)%n])
+		}
+	}
+}
+
+func (a *App) setPanelRate(i int) {
+	rates := a.panel.rates
+	a.submit("panel_rate", func(b Backend) (bool, string) {
+		return b.SetPanelRate(rates, i)
+	}, func(ok bool, out string, argv []string) {
+		a.logCommand(argv, out, ok)
+		if !ok {
+			a.SetError(out)
+			return
+		}
+		a.panel.rates.Current = i
+		a.SetStatus(fmt.Sprintf("Refresh rate → %.0f Hz", rates.Rates[i]), true)
+	})
+}
+
+func (a *App) setPanelOverdrive(on bool) {
+	if a.locked("bios") || !a.panel.odOk {
+		return
+	}
+	applied := func() {
+		a.panel.od = on
+		st := "OFF"
+		if on {
+			st = "ON"
+		}
+		a.SetStatus("Panel overdrive → "+st, true)
+	}
+	a.submit("armoury:panel_od", func(b Backend) (bool, string) {
+		return b.SetPanelOverdrive(on)
+	}, func(ok bool, out string, argv []string) {
+		a.logCommand(argv, out, ok)
+		if ok {
+			applied()
+			a.countUefiWrite(out)
+		} else {
+			a.SetError(out)
+			a.offerElevationFor(argv, out, func() { applied(); a.countUefiWrite(out) })
+		}
+	})
+}
+
+func (a *App) setMiniLed(val string) {
+	if a.locked("bios") {
+		return
+	}
+	applied := func() {
+		a.panel.miniLed = val
+		a.SetStatus("Mini-LED → "+strings.ToLower(miniLedLabel(val, a.panel.miniLeds)), true)
+	}
+	a.submit("armoury:mini_led_mode", func(b Backend) (bool, string) {
+		return b.SetArmoury("mini_led_mode", val)
+	}, func(ok bool, out string, argv []string) {
+		a.logCommand(argv, out, ok)
+		if ok {
+			applied()
+			a.countUefiWrite(out)
+		} else {
+			a.SetError(out)
+			a.offerElevationFor(argv, out, func() { applied(); a.countUefiWrite(out) })
+		}
+	})
+}
+
+func (a *App) setScreenPadPower(on bool) {
+	if !a.panel.screenpad.Present {
+		return
+	}
+	st := "OFF"
+	if on {
+		st = "ON"
+	}
+	a.submit("screenpad_power", func(b Backend) (bool, string) {
+		return b.SetScreenPadPower(on)
+	}, func(ok bool, out string, _ []string) {
+		a.addLog("sysfs screenpad → "+st, out, ok)
+		if !ok {
+			a.SetError(out)
+			return
+		}
+		a.panel.screenpad.On = on
+		a.SetStatus("ScreenPad → "+st, true)
+	})
+}
+
+func (a *App) setScreenPadBrightness(level int) {
+	level = clamp(level, 0, a.panel.screenpad.Max)
+	a.submit("screenpad_brightness", func(b Backend) (bool, string) {
+		return b.SetScreenPadBrightness(level)
+	}, func(ok bool, out string, argv []string) {
+		// sysfs when writable, else asusctl; only the latter leaves an argv
+		if len(argv) > 0 {
+			a.logCommand(argv, out, ok)
+		} else {
+			a.addLog(fmt.Sprintf("sysfs screenpad brightness → %d", level), out, ok)
+		}
+		if !ok {
+			a.SetError(out)
+			return
+		}
+		sp := &a.panel.screenpad
+		sp.Brightness = level
+		a.SetStatus(fmt.Sprintf("ScreenPad brightness → %d%%", level*100/max(sp.Max, 1)), true)
+	})
+}
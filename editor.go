@@ -0,0 +1,131 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// Edit in $EDITOR — command palette entries that suspend the UI, open
+// config.toml in $VISUAL or $EDITOR (vi without either), at the section
+// the entry names when the editor takes +LINE, and reload it on return.
+// A file that no longer loads is left alone, with an offer to edit it
+// again. command_timeout, retries and feral_gamemode still need a restart.
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// configSections are the palette's "Edit config: …" entries and the line
+// each opens at: the first one starting with prefix.
+var configSections = []struct{ label, prefix string }{
+	{"theme", "theme ="},
+	{"rules", "[[rules]]"},
+	{"workspaces", "[[workspaces]]"},
+	{"scenes", "[[scenes]]"},
+	{"palette", "[[palette]]"},
+	{"Aura favourites", "[[aura_presets]]"},
+}
+
+// lineEditors take +LINE before the file to open at that line.
+var lineEditors = []string{"vi", "vim", "nvim", "nano", "emacs", "micro", "kak", "joe", "mg", "ne"}
+
+// editorCommand is the editor to run: $VISUAL, $EDITOR or vi.
+func editorCommand() string {
+	for _, env := range []string{"VISUAL", "EDITOR"} {
+		if e := strings.TrimSpace(os.Getenv(env)); e != "" {
+			return e
+		}
+	}
+	return "vi"
+}
+
+// configLine is the 1-based line of the config file that starts with
+// prefix, or 0.
+func configLine(path, prefix string) int {
+	data, err := os.ReadFile(path)
+	if err != nil || prefix == "" {
+		return 0
+	}
+	for i, line := range strings.Split(string(data), "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), prefix) {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// editConfig opens the config file at the line starting with prefix ("" for
+// the top) and reloads it once the editor exits.
+func (a *App) editConfig(prefix string) {
+	path := a.cfg.path
+	if path == "" {
+		a.SetStatus("No config file path (start with --config)", false)
+		return
+	}
+	// Write the defaults out first, so a new file shows every key
+	if _, err := os.Stat(path); err != nil {
+		if err := a.cfg.Persist(); err != nil {
+			a.SetError(err.Error())
+			return
+		}
+	}
+
+	editor := editorCommand()
+	args := []string{path}
+	if n := configLine(path, prefix); n > 0 && indexFold(lineEditors, filepath.Base(strings.Fields(editor)[0])) >= 0 {
+		args = []string{"+" + strconv.Itoa(n), path}
+	}
+	// through sh, so an $EDITOR like "code --wait" keeps its arguments
+	cmd := exec.Command("sh", append([]string{"-c", editor + ` "$@"`, "sh"}, args...)...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	var runErr error
+	rearm := a.muteQuitSignals()
+	err := a.term.Suspend(func() { runErr = cmd.Run() })
+	rearm()
+	if err != nil {
+		a.SetError("Terminal not restored: " + err.Error())
+		return
+	}
+	if runErr != nil {
+		a.SetError(editor + ": " + runErr.Error())
+		return
+	}
+	a.reloadConfig(prefix)
+}
+
+// muteQuitSignals keeps SIGINT and SIGTERM from ending the app while the
+// editor runs: Ctrl-C there reaches the whole process group. The returned
+// func re-arms main's handler.
+func (a *App) muteQuitSignals() func() {
+	if a.quitSig == nil {
+		return func() {}
+	}
+	signal.Ignore(syscall.SIGINT, syscall.SIGTERM)
+	return func() { signal.Notify(a.quitSig, syscall.SIGINT, syscall.SIGTERM) }
+}
+
+// reloadConfig loads the edited file over the running config and applies
+// what the Settings tab would. prefix is where to reopen it on an error.
+// It runs on the main loop, like everything that reads the config;
+// background pollers take what they need from it when they start.
+func (a *App) reloadConfig(prefix string) {
+	fresh, err := LoadConfig(a.cfg.path)
+	if err != nil {
+		a.confirm = &Confirm{
+			Title: "Config not reloaded",
+			Lines: []string{err.Error(), "", "The app keeps the settings it had. Edit the file again?"},
+			OnYes: func() { a.editConfig(prefix) },
+		}
+		return
+	}
+	*a.cfg = *fresh
+	for _, s := range settingRows {
+		if s.apply != nil {
+			s.apply(a)
+		}
+	}
+	a.SetStatus("Config reloaded from "+a.cfg.path, true)
+}
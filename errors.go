@@ -0,0 +1,108 @@
+package main
+
+import (
+	"strings"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// Backend errors — classify raw asusctl failures into actionable messages
+// ═══════════════════════════════════════════════════════════════════════════════
+
+type ErrKind int
+
+const (
+	ErrUnknown ErrKind = iota
+	ErrNotInstalled
+	ErrPermissionDenied
+	ErrUnsupported
+	ErrTimeout
+	ErrDaemonDown
+	ErrBadArgument
+)
+
+// BackendError is a failed backend call with its raw output and category.
+type BackendError struct {
+	Kind   ErrKind
+	Output string
+}
+
+func (e *BackendError) Error() string { return e.Message() }
+
+// errPatterns are matched case-insensitively against command output, in order.
+var errPatterns = []struct {
+	kind    ErrKind
+	needles []string
+}{
+	// Only the failed exec lookup: a "no such file" from a running command is
+	// a missing file of its own, not a missing asusctl.
+	{ErrNotInstalled, []string{"executable file not found", "asusctl: not found"}},
+	{ErrTimeout, []string{"timed out", "noreply", "did not receive a reply"}},
+	{ErrDaemonDown, []string{"serviceunknown", "was not provided by any .service", "connection refused",
+		"could not connect", "asusd is not running", "failed to connect"}},
+	{ErrPermissionDenied, []string{"permission denied", "accessdenied", "access denied", "not authorized",
+		"interactiveauthorizationrequired", "authentication required", "operation not permitted"}},
+	{ErrUnsupported, []string{"not supported", "unsupported", "notsupported", "unknownmethod",
+		"unknownproperty", "unknown interface", "no such interface", "not available on this"}},
+	// Last: clap's usage errors are generic enough to shadow the kinds above.
+	{ErrBadArgument, []string{"invalid value", "invalid argument", "invalid profile", "invalid brightness",
+		"unexpected argument", "unrecognized", "unrecognised", "isn't a valid value", "possible values",
+		"error: invalid", "usage:"}},
+}
+
+// classifyFailure maps the output of a failed call to a BackendError.
+func classifyFailure(out string) *BackendError {
+	lo := strings.ToLower(out)
+	for _, p := range errPatterns {
+		for _, n := range p.needles {
+			if strings.Contains(lo, n) {
+				return &BackendError{Kind: p.kind, Output: out}
+			}
+		}
+	}
+	return &BackendError{Kind: ErrUnknown, Output: out}
+}
+
+// Message is the short text shown in the status bar.
+func (e *BackendError) Message() string {
+	switch e.Kind {
+	case ErrNotInstalled:
+		return "asusctl not found"
+	case ErrPermissionDenied:
+		return "Permission denied"
+	case ErrUnsupported:
+		return "Not supported on this laptop"
+	case ErrTimeout:
+		if i := strings.Index(e.Output, "timed out after "); i >= 0 {
+			return "asusctl " + strings.TrimSpace(e.Output[i:])
+		}
+		return "asusctl timed out"
+	case ErrDaemonDown:
+		return "asusd is not running"
+	case ErrBadArgument:
+		return "asusctl rejected the arguments"
+	}
+	line := strings.TrimSpace(strings.SplitN(e.Output, "\n", 2)[0])
+	if line == "" {
+		line = "unknown error"
+	}
+	return "Failed: " + line
+}
+
+// Hint suggests a fix, or returns "" when there is nothing useful to add.
+func (e *BackendError) Hint() string {
+	switch e.Kind {
+	case ErrNotInstalled:
+		return "install asusctl (https://asus-linux.org) and make sure it is on PATH, or point --asusctl-bin / ASUSCTL_BIN at it"
+	case ErrPermissionDenied:
+		return "asusd policy refused the change; check polkit rules or run as a user in the 'wheel'/'users' group"
+	case ErrUnsupported:
+		return "this feature is not exposed by your model's firmware or asusctl version"
+	case ErrTimeout:
+		return "asusd may be busy or hung; try `systemctl restart asusd` or raise command_timeout in config.toml"
+	case ErrDaemonDown:
+		return "start the daemon with `systemctl start asusd`"
+	case ErrBadArgument:
+		return "this asusctl version may use a different syntax; see `asusctl --help`"
+	}
+	return ""
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestClassifyFailure(t *testing.T) {
+	tests := []struct {
+		out  string
+		want ErrKind
+	}{
+		{`exec: "asusctl": executable file not found in $PATH`, ErrNotInstalled},
+		{"sh: 1: asusctl: not found", ErrNotInstalled},
+		{"Error: open /etc/asusd/fan_curves.ron: no such file or directory", ErrUnknown},
+		{"cp: cannot stat '/tmp/aura.ron': No such file or directory", ErrUnknown},
+		{"Error: org.freedesktop.DBus.Error.ServiceUnknown: The name xyz.ljones.Asusd was not provided by any .service files", ErrDaemonDown},
+		{"Error: org.freedesktop.DBus.Error.AccessDenied", ErrPermissionDenied},
+		{"command timed out after 5s", ErrTimeout},
+		{"error: invalid value 'turbo' for '--profile-set <PROFILE_SET>'", ErrBadArgument},
+	}
+	for _, tt := range tests {
+		if got := classifyFailure(tt.out).Kind; got != tt.want {
+			t.Errorf("%q: kind %d, want %d", tt.out, got, tt.want)
+		}
+	}
+}
+
+// TestMissingBinaryNotInstalled checks a binary that is not there is
+// ErrNotInstalled whether it was looked up on PATH or given by path.
+func TestMissingBinaryNotInstalled(t *testing.T) {
+	t.Setenv("PATH", t.TempDir())
+	for _, bin := range []string{"asusctl", filepath.Join(t.TempDir(), "asusctl")} {
+		ok, out := execWithTimeout(exec.Command(bin, "--version"), time.Second)
+		if ok || classifyFailure(out).Kind != ErrNotInstalled {
+			t.Errorf("%s: %v, %q", bin, ok, out)
+		}
+	}
+}
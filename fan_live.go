@@ -0,0 +1,87 @@
+package main
+
+import (
+	"fmt"
+	"math"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// Fan graph overlay — the selected fan's temperature drawn into the curve
+// graph as a vertical marker, with its RPM and the speed the curve asks
+// for at that temperature under the axis, so an edit can be judged against
+// what the fan does. The values come from App.sensors and move with every
+// sensor poll.
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// fanTempC is the temperature fan follows: the GPU's for the GPU fan, the
+// CPU's for the others. 0 when unknown.
+func (a *App) fanTempC(fan string) float64 {
+	if fan == "gpu" {
+		return a.sensors.GPUTempC
+	}
+	return a.sensors.CPUTempC
+}
+
+// curvePosition is where tempC falls between the curve's points, in points
+// from the first (0-7), clamped to the curve.
+func curvePosition(temps [8]int, tempC float64) float64 {
+	if tempC <= float64(temps[0]) {
+		return 0
+	}
+	for p := 0; p < 7; p++ {
+		lo, hi := float64(temps[p]), float64(temps[p+1])
+		if tempC < hi {
+			if hi <= lo {
+				return float64(p + 1)
+			}
+			return float64(p) + (tempC-lo)/(hi-lo)
+		}
+	}
+	return 7
+}
+
+// curveSpeedAt is the speed the curve asks for at point position pos,
+// held at the end points outside 0-7.
+func curveSpeedAt(speeds [8]int, pos float64) int {
+	pos = math.Max(0, math.Min(pos, 7))
+	p := min(int(pos), 6)
+	rem := pos - float64(p)
+	return int(float64(speeds[p])*(1-rem) + float64(speeds[p+1])*rem + 0.5)
+}
+
+// fanMarkerCol is the graph column of the live temperature marker for a
+// graph graphW wide, or -1 without a reading.
+func (a *App) fanMarkerCol(graphW int) int {
+	tempC := a.fanTempC(fanNames[a.selectedFan])
+	if tempC <= 0 {
+		return -1
+	}
+	pos := curvePosition(a.fanTemps[a.selectedFan], tempC)
+	return int(pos*float64(graphW-1)/7 + 0.5)
+}
+
+// renderFanLive labels the marker on row y under a graph at graphX:
+// temperature, RPM and the curve's speed there. The label starts at the
+// marker, or ends there near the right edge.
+func (a *App) renderFanLive(graphX, graphW, y int) {
+	col := a.fanMarkerCol(graphW)
+	if col < 0 {
+		return
+	}
+	fan := fanNames[a.selectedFan]
+	tempC := a.fanTempC(fan)
+	label := formatTemp(tempC)
+	if rpm, ok := a.sensors.FanRPM(fan); ok {
+		label += fmt.Sprintf(" · %d rpm", rpm)
+	}
+	pos := curvePosition(a.fanTemps[a.selectedFan], tempC)
+	label += fmt.Sprintf(" · curve %d%%", curveSpeedAt(a.fanSpeeds[a.selectedFan], pos))
+	x := graphX + col
+	if n := len([]rune(label)) + 2; col+n > graphW {
+		label += " ▲"
+		x = max(graphX+col-n+1, graphX)
+	} else {
+		label = "▲ " + label
+	}
+	a.term.Text(x, y, ColWarning, label)
+}
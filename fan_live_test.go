@@ -0,0 +1,58 @@
+package main
+
+import "testing"
+
+func TestCurvePosition(t *testing.T) {
+	even := [8]int{30, 40, 50, 60, 70, 80, 90, 100}
+	flat := [8]int{30, 40, 40, 40, 70, 80, 90, 100}
+	same := [8]int{60, 60, 60, 60, 60, 60, 60, 60}
+	tests := []struct {
+		name  string
+		temps [8]int
+		tempC float64
+		want  float64
+	}{
+		{"on the first point", even, 30, 0},
+		{"below the first point", even, 12, 0},
+		{"between points", even, 45, 1.5},
+		{"on a point", even, 70, 4},
+		{"on the last point", even, 100, 7},
+		{"beyond the last point", even, 115, 7},
+		{"on equal points", flat, 40, 3},
+		{"just under equal points", flat, 39, 0.9},
+		{"past equal points", flat, 55, 3.5},
+		{"all equal, at them", same, 60, 0},
+		{"all equal, above them", same, 61, 7},
+	}
+	for _, tt := range tests {
+		if got := curvePosition(tt.temps, tt.tempC); got < tt.want-1e-9 || got > tt.want+1e-9 {
+			t.Errorf("%s: curvePosition(%v) = %v, want %v", tt.name, tt.tempC, got, tt.want)
+		}
+	}
+}
+
+func TestCurveSpeedAt(t *testing.T) {
+	speeds := [8]int{0, 10, 20, 40, 60, 80, 90, 100}
+	tests := []struct {
+		pos  float64
+		want int
+	}{
+		{0, 0},
+		{0.5, 5},
+		{2.25, 25},
+		{3, 40},
+		{6.5, 95},
+		{7, 100},
+		{9, 100}, // beyond the last point: held, not extrapolated
+		{-1, 0},
+	}
+	for _, tt := range tests {
+		if got := curveSpeedAt(speeds, tt.pos); got != tt.want {
+			t.Errorf("curveSpeedAt(%v) = %d, want %d", tt.pos, got, tt.want)
+		}
+	}
+	flat := [8]int{50, 50, 50, 50, 50, 50, 50, 50}
+	if got := curveSpeedAt(flat, curvePosition(flat, 1000)); got != 50 {
+		t.Errorf("flat curve = %d, want 50", got)
+	}
+}
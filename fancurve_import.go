@@ -0,0 +1,180 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// Fan curve import — curves shared on forums and in dotfiles come as
+// "30c:1%,40c:5%…", "30:1 40:5 …", one "30 = 1" per line or in °F. A curve
+// is in one unit; bare numbers are °F only when one is too hot for °C. They
+// are read into asusd's °C form wherever a curve enters the app: i on the Fans
+// tab (pasted text or a file), scenes in the config, and fan-curve --data
+// typed in the Console.
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// maxCurveTempC bounds curve temperatures; a bare number above it is °F.
+const maxCurveTempC = 120
+
+// splitCurvePoints cuts a curve into its points. Points are separated by
+// commas, semicolons or newlines; when there are none, by whitespace
+// between temp:speed pairs. Brackets and a leading --data are dropped.
+func splitCurvePoints(s string) []string {
+	s = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(s), "--data"))
+	s = strings.Trim(s, "[](){}\"'")
+	s = strings.NewReplacer(";", ",", "\n", ",").Replace(s)
+	var points []string
+	for _, pt := range strings.Split(s, ",") {
+		if pt = strings.TrimSpace(pt); pt != "" {
+			points = append(points, pt)
+		}
+	}
+	if len(points) == 1 && strings.ContainsAny(points[0], ":=") {
+		points = strings.Fields(points[0])
+	}
+	return points
+}
+
+// parseCurvePoint reads one point: a temperature with its unit as written,
+// "c" ("30c", "30°C"), "f" ("86F", "86°F") or "" when bare, then ':', '='
+// or a space, then a speed in percent with or without '%'.
+func parseCurvePoint(pt string) (deg float64, unit string, speed int, err error) {
+	t, s, ok := strings.Cut(pt, ":")
+	if !ok {
+		t, s, ok = strings.Cut(pt, "=")
+	}
+	if !ok {
+		if f := strings.Fields(pt); len(f) == 2 {
+			t, s, ok = f[0], f[1], true
+		}
+	}
+	if !ok {
+		return 0, "", 0, fmt.Errorf("want temperature:speed, e.g. 60c:40%%")
+	}
+
+	t = strings.ToLower(strings.TrimSpace(t))
+	for _, u := range []string{"c", "f"} {
+		if strings.HasSuffix(t, u) {
+			unit, t = u, strings.TrimSuffix(t, u)
+		}
+	}
+	t = strings.TrimSuffix(t, "°")
+	deg, err = strconv.ParseFloat(strings.TrimSpace(t), 64)
+	if err != nil {
+		return 0, "", 0, fmt.Errorf("temperature %q is not a number", t)
+	}
+
+	s = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(s), "%"))
+	pct, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, "", 0, fmt.Errorf("speed %q is not a number", s)
+	}
+	if pct < 0 || pct > 100 {
+		return 0, "", 0, fmt.Errorf("speed must be 0-100%%")
+	}
+	return deg, unit, int(math.Round(pct)), nil
+}
+
+// curveUnit settles the unit of a curve's temperatures, as parseCurvePoint
+// read them, for the whole curve: bare ones take the unit the others name,
+// and a curve with no unit at all is °C unless a temperature is above
+// maxCurveTempC, which only a °F curve has. Deciding point by point would
+// read the low half of a bare °F curve as °C.
+func curveUnit(degs []float64, units []string) (string, error) {
+	unit := ""
+	for _, u := range units {
+		switch {
+		case u == "" || u == unit:
+		case unit == "":
+			unit = u
+		default:
+			return "", fmt.Errorf("the curve mixes °C and °F; write it in one unit")
+		}
+	}
+	if unit != "" {
+		return unit, nil
+	}
+	for _, d := range degs {
+		if d > maxCurveTempC {
+			return "f", nil
+		}
+	}
+	return "c", nil
+}
+
+// curveTempC converts a curve temperature in unit to whole °C within the
+// range asusd takes.
+func curveTempC(deg float64, unit string) (int, error) {
+	if unit == "f" {
+		deg = (deg - 32) * 5 / 9
+	}
+	tempC := int(math.Round(deg))
+	switch {
+	case tempC < 0:
+		return 0, fmt.Errorf("temperature must not be below %s", formatTempLimit(0))
+	case tempC > maxCurveTempC:
+		return 0, fmt.Errorf("temperature above %s", formatTempLimit(maxCurveTempC))
+	}
+	return tempC, nil
+}
+
+// promptFanImport reads a curve for the selected fan from pasted text or a
+// file. Like a preset, it is only applied with Enter.
+func (a *App) promptFanImport() {
+	fan := fanNames[a.selectedFan]
+	a.prompt = &Prompt{
+		Title: "Import " + strings.ToUpper(fan) + " fan curve",
+		Label: "8 points (30c:1%,40c:5%… or °F), or a file path",
+		OnOk: func(text string) {
+			src := "pasted curve"
+			if strings.HasPrefix(text, "/") || strings.HasPrefix(text, "~/") || strings.HasPrefix(text, "./") {
+				path := expandHome(text)
+				data, err := os.ReadFile(path)
+				if err != nil {
+					a.SetStatus("Import: "+err.Error(), false)
+					return
+				}
+				text, src = string(data), filepath.Base(path)
+			}
+			temps, speeds, err := ParseFanCurveData(text)
+			if err != nil {
+				a.SetStatus("Import: "+err.Error(), false)
+				return
+			}
+			a.fanTemps[a.selectedFan], a.fanSpeeds[a.selectedFan] = temps, speeds
+			a.fanDirty = true
+			a.SetStatus(fmt.Sprintf("Imported %s curve from %s; Enter applies it", strings.ToUpper(fan), src), true)
+		},
+	}
+}
+
+// normalizeCurveCommand rewrites the --data of a console fan-curve command
+// into asusd's form, so a curve can be pasted as it was shared. Other
+// commands are returned as they are.
+func normalizeCurveCommand(cmd string) (string, error) {
+	fields := strings.Fields(cmd)
+	if len(fields) == 0 || fields[0] != "fan-curve" {
+		return cmd, nil
+	}
+	for i, f := range fields {
+		if f != "--data" {
+			continue
+		}
+		end := i + 1
+		for end < len(fields) && !strings.HasPrefix(fields[end], "--") {
+			end++
+		}
+		temps, speeds, err := ParseFanCurveData(strings.Join(fields[i+1:end], " "))
+		if err != nil {
+			return cmd, fmt.Errorf("--data: %w", err)
+		}
+		out := append(append(fields[:i+1:i+1], FormatFanCurve(temps[:], speeds[:])), fields[end:]...)
+		return strings.Join(out, " "), nil
+	}
+	return cmd, nil
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseFanCurveData(t *testing.T) {
+	celsius := [8]int{30, 40, 50, 60, 70, 80, 90, 100}
+	speeds := [8]int{1, 5, 10, 20, 35, 55, 65, 65}
+	tests := []struct {
+		name  string
+		in    string
+		temps [8]int
+	}{
+		{"asusd form", "30c:1%,40c:5%,50c:10%,60c:20%,70c:35%,80c:55%,90c:65%,100c:65%", celsius},
+		{"bare pairs", "30:1 40:5 50:10 60:20 70:35 80:55 90:65 100:65", celsius},
+		{"one per line", "30 = 1\n40 = 5\n50 = 10\n60 = 20\n70 = 35\n80 = 55\n90 = 65\n100 = 65\n", celsius},
+		{"degree signs", "[30°C:1%; 40°C:5%; 50°:10%; 60°:20%; 70°:35%; 80°:55%; 90°:65%; 100°:65%]", celsius},
+		{"console --data", "--data 30c:1%,40c:5%,50c:10%,60c:20%,70c:35%,80c:55%,90c:65%,100c:65%", celsius},
+		{"fahrenheit", "86F:1%,104F:5%,122F:10%,140F:20%,158F:35%,176F:55%,194F:65%,212F:65%", celsius},
+		// the low points are °F as well, though they would pass as °C
+		{"bare fahrenheit", "86:1 104:5 122:10 140:20 158:35 176:55 194:65 212:65", celsius},
+		{"unit on one point", "86F:1 104:5 113:10 118:20 120:35 120:55 120:65 120:65", [8]int{30, 40, 45, 48, 49, 49, 49, 49}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			temps, got, err := ParseFanCurveData(tt.in)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if temps != tt.temps || got != speeds {
+				t.Errorf("got %v %v, want %v %v", temps, got, tt.temps, speeds)
+			}
+		})
+	}
+}
+
+func TestParseFanCurveDataErrors(t *testing.T) {
+	tests := []struct {
+		name, in, want string
+	}{
+		{"seven points", "30:1 40:5 50:10 60:20 70:35 80:55 90:65", "needs 8 points"},
+		{"mixed units", "30c:1,104F:5,50:10,60:20,70:35,80:55,90:65,100:65", "mixes °C and °F"},
+		{"too hot", "86F:1,104F:5,122F:10,140F:20,158F:35,176F:55,194F:65,260F:65", `point 8 "260F:65": temperature above`},
+		{"falling", "30:1 40:5 50:10 45:20 70:35 80:55 90:65 100:65", `point 4 "45:20": temperature is below point 3's`},
+		{"speed", "30:1 40:5 50:10 60:120 70:35 80:55 90:65 100:65", "speed must be 0-100%"},
+		{"not a number", "30:1 forty:5 50:10 60:20 70:35 80:55 90:65 100:65", `temperature "forty" is not a number`},
+		{"no separator", "30,40,50,60,70,80,90,100", "want temperature:speed"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := ParseFanCurveData(tt.in)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("err = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeCurveCommand(t *testing.T) {
+	got, err := normalizeCurveCommand("fan-curve --mod-profile quiet --fan cpu --data 86F:1 104F:5 122F:10 140F:20 158F:35 176F:55 194F:65 212F:65")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "fan-curve --mod-profile quiet --fan cpu --data 30c:1%,40c:5%,50c:10%,60c:20%,70c:35%,80c:55%,90c:65%,100c:65%"
+	if got != want {
+		t.Errorf("got %q\nwant %q", got, want)
+	}
+	if got, _ := normalizeCurveCommand("profile -n"); got != "profile -n" {
+		t.Errorf("other command rewritten to %q", got)
+	}
+}
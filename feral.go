@@ -0,0 +1,81 @@
+package main
+
+import (
+	"bufio"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// Feral GameMode — game start and exit from gamemoded on the session bus
+// Games launched through `gamemoderun` (or Steam's GameMode option) register
+// with gamemoded. Followed with dbus-monitor like the asusd signals; the
+// count itself is re-read with busctl so a missed signal cannot drift it.
+// ═══════════════════════════════════════════════════════════════════════════════
+
+type GameWatcher interface {
+	// WatchGames calls onChange with the number of registered games now and
+	// after every registration change, from a background goroutine.
+	WatchGames(onChange func(games int)) error
+}
+
+const (
+	feralBusName = "com.feralinteractive.GameMode"
+	feralPath    = "/com/feralinteractive/GameMode"
+)
+
+// feralClientCount reads gamemoded's ClientCount property ("i 1"), or -1.
+func (b *ExecBackend) feralClientCount() int {
+	ok, out := execWithTimeout(hostCommand("busctl", "--user", "get-property",
+		feralBusName, feralPath, feralBusName, "ClientCount"), b.policy.Timeout)
+	f := strings.Fields(out)
+	if !ok || len(f) != 2 || f[0] != "i" {
+		return -1
+	}
+	n, err := strconv.Atoi(f[1])
+	if err != nil {
+		return -1
+	}
+	return n
+}
+
+func (b *ExecBackend) WatchGames(onChange func(games int)) error {
+	if _, err := hostLookPath("dbus-monitor"); err != nil {
+		return err
+	}
+	cmd := hostCommand("dbus-monitor", "--session", "type='signal',interface='"+feralBusName+"'")
+	cmd.SysProcAttr = &syscall.SysProcAttr{Pdeathsig: syscall.SIGTERM}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	go func() {
+		games := max(b.feralClientCount(), 0)
+		onChange(games)
+		sc := bufio.NewScanner(stdout)
+		for sc.Scan() {
+			line := sc.Text()
+			var delta int
+			switch {
+			case strings.Contains(line, "member=GameRegistered"):
+				delta = 1
+			case strings.Contains(line, "member=GameUnregistered"):
+				delta = -1
+			default:
+				continue
+			}
+			if n := b.feralClientCount(); n >= 0 {
+				games = n
+			} else {
+				games = max(games+delta, 0)
+			}
+			onChange(games)
+		}
+		cmd.Wait()
+	}()
+	return nil
+}
@@ -0,0 +1,55 @@
+package main
+
+import "strings"
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// Focus styles — how the focused item is marked, from focus_style in the
+// config. The default "▸" is easy to lose on large colour fields such as the
+// Aura palette, so it can be made stronger: "reverse" inverts the focused
+// row, "blink" blinks the marker and "caret" swaps it for a heavier ❯.
+// Renderers write the marker as a leading "▸" and leave the rest to
+// writeFocused / focusText.
+// ═══════════════════════════════════════════════════════════════════════════════
+
+var focusStyles = []string{"marker", "reverse", "blink", "caret"}
+
+// writeFocused writes s, the focused item's text starting with its "▸"
+// marker, at the cursor in the current colours, marked in the focus style.
+func (a *App) writeFocused(s string) {
+	t := a.term
+	rest, marked := strings.CutPrefix(s, "▸")
+	if !marked {
+		t.Write(s)
+		return
+	}
+	switch a.cfg.FocusStyle {
+	case "reverse":
+		t.Reverse()
+		t.Write(s)
+		t.ReverseOff()
+	case "blink":
+		t.Blink()
+		t.Write("▸")
+		t.BlinkOff()
+		t.Write(rest)
+	case "caret":
+		t.Write("❯" + rest)
+	default:
+		t.Write(s)
+	}
+}
+
+// focusText draws the focused row of a list: s in bold, starting with its
+// "▸" marker. In the reverse style the highlight runs to the content's
+// right margin; values drawn after it sit on top.
+func (a *App) focusText(x, y int, s string) {
+	t := a.term
+	if a.cfg.FocusStyle == "reverse" {
+		s = pad(s, max(t.Width()-x-a.padX(), len([]rune(s))))
+	}
+	t.ResetStyle()
+	t.Bold()
+	t.Fg(ColText)
+	t.MoveTo(x, y)
+	a.writeFocused(s)
+}
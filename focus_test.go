@@ -0,0 +1,52 @@
+package main
+
+import (
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestWriteFocused(t *testing.T) {
+	tests := []struct {
+		style, want string
+	}{
+		{"marker", "▸ Static"},
+		{"Reverse", "\033[7m▸ Static\033[27m"},
+		{"blink", "\033[5m▸\033[25m Static"},
+		{"CARET", "❯ Static"},
+	}
+	for _, tt := range tests {
+		cfg := DefaultConfig()
+		cfg.FocusStyle = tt.style
+		if err := cfg.check(); err != nil {
+			t.Fatal(err)
+		}
+		a := NewApp(NewFakeTerminal(80, 24, io.Discard), NewMockBackend(), cfg)
+		a.term.buf.Reset()
+		a.writeFocused("▸ Static")
+		if got := a.term.buf.String(); got != tt.want {
+			t.Errorf("%s: wrote %q, want %q", tt.style, got, tt.want)
+		}
+	}
+}
+
+// TestAuraUnappliedOnSelect checks apply_on_select hides the unapplied
+// hint only for the moves it applies itself.
+func TestAuraUnappliedOnSelect(t *testing.T) {
+	var out strings.Builder
+	cfg := DefaultConfig()
+	cfg.ApplyOnSelect = true
+	cfg.AuraPresets = []AuraPreset{{Name: "Desk", Mode: "Static", Colour1: "1e90ff"}}
+	a := NewApp(NewFakeTerminal(200, 60, &out), NewMockBackend(), cfg)
+	a.activeTab = TabAura
+	a.HandleKey(KeyEvent{Type: KeyRight})
+	a.Render()
+	if !a.auraDirty || strings.Contains(out.String(), "Unapplied changes") {
+		t.Fatalf("a move: dirty = %v, hint shown = %v", a.auraDirty, strings.Contains(out.String(), "Unapplied changes"))
+	}
+	a.HandleKey(KeyEvent{Type: KeyChar, Char: 'f'})
+	a.Render()
+	if !strings.Contains(out.String(), "Unapplied changes") {
+		t.Error("no hint for a favourite the delayed apply does not send")
+	}
+}
@@ -0,0 +1,189 @@
+package main
+
+import "time"
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// Game mode — suppress the Super key, repurpose the ROG key and switch the
+// power profile while gaming. Toggled by hand or, with feral_gamemode, by
+// games registering with Feral GameMode (feral.go).
+// Everything changed on entry is undone when game mode ends or the app quits.
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// The writes go through the queue, so game mode may end before they are
+// done; superDisabled and prevProfile are set when they are queued and
+// cleared if they fail, and the restoring writes queue behind them.
+type gameModeState struct {
+	superDisabled bool   // we switched the Super key off and must restore it
+	stopRogKey    func() // nil when the ROG key is not being watched
+	prevProfile   string // profile to restore, "" if we did not change it
+	profileSet    bool   // the game profile's write has completed
+	auto          bool   // started by a game, so it ends with the last game
+}
+
+// setGameMode enters or leaves game mode according to cfg.GameMode.
+func (a *App) setGameMode(on bool) {
+	if on == (a.gameMode != nil) {
+		return
+	}
+	if !on {
+		a.endGameMode()
+		a.SetStatus("Game mode off — Super and ROG keys restored", true)
+		return
+	}
+
+	gm := &gameModeState{}
+	var failed string
+	if a.cfg.GameMode.DisableSuper {
+		gm.superDisabled = true
+		a.submit("super_key", func(b Backend) (bool, string) {
+			return b.SetSuperKey(false)
+		}, func(ok bool, out string, _ []string) {
+			a.addLog("game mode: disable Super key", out, ok)
+			if !ok {
+				gm.superDisabled = false
+				a.SetError(out)
+			}
+		})
+	}
+	if cmd := a.cfg.GameMode.RogKeyCommand; cmd != "" {
+		stop, err := a.backend.WatchRogKey(func() {
+			a.Post(func() { a.runRogKeyCommand(cmd) })
+		})
+		if err != nil {
+			a.addLog("game mode: watch ROG key", err.Error(), false)
+			failed = err.Error()
+		} else {
+			gm.stopRogKey = stop
+		}
+	}
+	if p := matchProfile(a.cfg.GameMode.Profile); p != "" && p != a.profile {
+		switch {
+		case a.quiet.active:
+			a.addLog("game mode: profile "+p, "held back by quiet hours", false)
+		case a.cfg.Lockdown.ActionLocked("profile"):
+		default:
+			gm.prevProfile = a.profile
+			a.submit("profile", func(b Backend) (bool, string) {
+				return b.SetProfile(p)
+			}, func(ok bool, out string, _ []string) {
+				a.addLog("game mode: profile "+p, out, ok)
+				if !ok {
+					gm.prevProfile = ""
+					a.SetError(out)
+					return
+				}
+				gm.profileSet = true
+				a.profile = p
+				a.loadFanCurves()
+				a.followProfile()
+			})
+		}
+	}
+	a.gameMode = gm
+	if failed != "" {
+		a.SetError(failed)
+		return
+	}
+	a.SetStatus("Game mode on", true)
+}
+
+// endGameMode restores whatever game mode changed.
+func (a *App) endGameMode() {
+	gm := a.gameMode
+	if gm == nil {
+		return
+	}
+	a.gameMode = nil
+	if gm.stopRogKey != nil {
+		gm.stopRogKey()
+	}
+	if gm.superDisabled {
+		a.submit("super_key", func(b Backend) (bool, string) {
+			return b.SetSuperKey(true)
+		}, func(ok bool, out string, _ []string) {
+			a.addLog("game mode: restore Super key", out, ok)
+			if !ok {
+				a.SetError(out)
+			}
+		})
+	}
+	// only when still on the game profile, or still switching to it; a
+	// later change by hand wins
+	onGame := !gm.profileSet || a.profile == matchProfile(a.cfg.GameMode.Profile)
+	if prev := gm.prevProfile; prev != "" && onGame && !a.quiet.active {
+		a.submit("profile", func(b Backend) (bool, string) {
+			return b.SetProfile(prev)
+		}, func(ok bool, out string, _ []string) {
+			a.addLog("game mode: restore profile "+prev, out, ok)
+			if ok {
+				a.profile = prev
+				a.loadFanCurves()
+				a.followProfile()
+			}
+		})
+	}
+}
+
+// onGamesChanged follows Feral GameMode: game mode starts with the first
+// registered game and, if it started that way, ends with the last.
+func (a *App) onGamesChanged(games int) {
+	prev := a.automation.games
+	a.automation.games = games
+	switch {
+	case games > 0 && prev == 0 && a.gameMode == nil:
+		a.setGameMode(true)
+		if a.gameMode != nil {
+			a.gameMode.auto = true
+		}
+		a.automationLog("Feral GameMode: game started", "game mode on", a.gameMode != nil)
+	case games == 0 && a.gameMode != nil && a.gameMode.auto:
+		a.setGameMode(false)
+		a.automationLog("Feral GameMode: last game exited", "game mode off", true)
+	}
+}
+
+// runRogKeyCommand starts the user's command without waiting for it.
+func (a *App) runRogKeyCommand(command string) {
+	cmd := hostCommand("sh", "-c", command)
+	if err := cmd.Start(); err != nil {
+		a.addLog("ROG key: "+command, err.Error(), false)
+		a.SetError(err.Error())
+		return
+	}
+	go cmd.Wait()
+	a.addLog("ROG key: "+command, "", true)
+	a.SetStatus("ROG key → "+command, true)
+}
+
+// toggleDisableSuper flips the setting and saves it to config.toml.
+func (a *App) toggleDisableSuper() {
+	a.cfg.GameMode.DisableSuper = !a.cfg.GameMode.DisableSuper
+	if err := a.cfg.Persist(); err != nil {
+		a.SetStatus("Could not save config: "+err.Error(), false)
+		return
+	}
+	st := "off"
+	if a.cfg.GameMode.DisableSuper {
+		st = "on"
+	}
+	msg := "Disable Super key in game mode → " + st
+	if a.gameMode != nil {
+		msg += " (applies next time game mode starts)"
+	}
+	a.SetStatus(msg, true)
+}
+
+// Shutdown undoes temporary system changes before the app exits.
+func (a *App) Shutdown() {
+	a.endGameMode() // queues the restoring writes, so before the wait
+	a.endQuietHours()
+	a.queue.Wait(5 * time.Second)
+	a.stopAutomation()
+	a.stopAnimeBattery()
+	a.stopLogs()
+	a.stopPlanner()
+	a.stopMonitor()
+	a.endMonitorSession()
+	a.stopSensors()
+	a.stopAuraPreview()
+}
@@ -0,0 +1,108 @@
+package main
+
+import "strings"
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// GPU switching — wraps the supergfxctl CLI (supergfxd daemon)
+// ═══════════════════════════════════════════════════════════════════════════════
+
+type GpuControl interface {
+	GfxInstalled() bool
+	GetGfxMode() (bool, string)
+	// GetGfxSupported lists the modes supergfxd offers on this machine.
+	GetGfxSupported() (bool, string)
+	// GetGfxPower reports the dGPU power state (active, suspended, off…).
+	GetGfxPower() (bool, string)
+	// GetGfxPending reports a user action still needed for the last switch.
+	GetGfxPending() (bool, string)
+	SetGfxMode(mode string) (bool, string)
+}
+
+// gfxSwitchNeeds is what supergfxd will ask of the user to finish a switch
+// between two modes, in parseGfxAction's terms: the MUX (and with it the
+// eGPU) only changes at boot, the others when the session restarts, and
+// Vfio can only be entered from Integrated.
+func gfxSwitchNeeds(from, to string) string {
+	switch {
+	case from == to:
+		return ""
+	case from == "AsusMuxDgpu" || to == "AsusMuxDgpu":
+		return "Reboot"
+	case to == "Vfio" && from != "Integrated":
+		return "SwitchToIntegrated"
+	case from == "Vfio" || to == "Vfio":
+		return ""
+	}
+	return "Logout"
+}
+
+// gfxModes are the supergfxctl modes in display order.
+var gfxModes = []struct {
+	name string
+	desc string
+}{
+	{"Integrated", "iGPU only, dGPU powered off — longest battery life"},
+	{"Hybrid", "iGPU drives the display, dGPU on demand (PRIME offload)"},
+	{"AsusMuxDgpu", "dGPU drives the display through the MUX switch"},
+	{"Vfio", "dGPU detached for passthrough to a virtual machine"},
+	{"AsusEgpu", "external XG Mobile GPU"},
+}
+
+// parseGfxModes extracts known mode names from `supergfxctl -s` output,
+// which is printed as a list like "[Integrated, Hybrid, AsusMuxDgpu]".
+func parseGfxModes(out string) []string {
+	var modes []string
+	for _, field := range strings.FieldsFunc(out, func(r rune) bool {
+		return r == '[' || r == ']' || r == ',' || r == ' ' || r == '\n'
+	}) {
+		for _, m := range gfxModes {
+			if strings.EqualFold(field, m.name) {
+				modes = append(modes, m.name)
+			}
+		}
+	}
+	return modes
+}
+
+// parseGfxAction finds the user action a mode change requires in output such
+// as "Graphics mode changed to Integrated. Required user action is: Logout".
+// Returns "" when nothing is required.
+func parseGfxAction(out string) string {
+	lo := strings.ToLower(out)
+	switch {
+	case strings.Contains(lo, "reboot"):
+		return "Reboot"
+	case strings.Contains(lo, "logout"):
+		return "Logout"
+	case strings.Contains(lo, "switch") && strings.Contains(lo, "integrated"):
+		return "SwitchToIntegrated"
+	}
+	return ""
+}
+
+func (b *ExecBackend) GfxInstalled() bool {
+	_, err := hostLookPath("supergfxctl")
+	return err == nil
+}
+
+func (b *ExecBackend) runGfx(args ...string) (bool, string) {
+	return b.runBin("supergfxctl", args...)
+}
+
+func (b *ExecBackend) GetGfxMode() (bool, string)      { return b.runGfx("--get") }
+func (b *ExecBackend) GetGfxSupported() (bool, string) { return b.runGfx("--supported") }
+func (b *ExecBackend) GetGfxPending() (bool, string)   { return b.runGfx("--pend-action") }
+
+// GetGfxPower asks supergfxd; without it, an NVIDIA dGPU's runtime PM state
+// is read from sysfs instead.
+func (b *ExecBackend) GetGfxPower() (bool, string) {
+	if !b.GfxInstalled() {
+		st := nvidiaPowerState()
+		return st != "", st
+	}
+	return b.runGfx("--status")
+}
+
+func (b *ExecBackend) SetGfxMode(mode string) (bool, string) {
+	return b.runGfx("--mode", mode)
+}
@@ -0,0 +1,309 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// Page: GPU
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// gfxState is what the GPU tab shows; read at startup and on tab entry.
+type gfxState struct {
+	installed bool
+	mode      string
+	power     string
+	supported []string
+	pending   string // user action still required, "" if none
+	muxOk     bool   // asusctl can read the MUX mode
+	muxDgpu   bool   // the MUX has the dGPU drive the panel
+}
+
+func readGfxState(b Backend) gfxState {
+	st := gfxState{installed: b.GfxInstalled()}
+	var mux string
+	st.muxOk, mux = b.GetGpuMux()
+	st.muxDgpu = st.muxOk && parseArmouryValue(mux) == "1"
+	// While the dGPU drives the panel it is always on: there is no power
+	// state to read.
+	if !st.muxDgpu {
+		if ok, out := b.GetGfxPower(); ok {
+			st.power = strings.ToLower(strings.TrimSpace(out))
+		}
+	}
+	if !st.installed {
+		// asusctl still covers the MUX, and sysfs the dGPU power state
+		return st
+	}
+	if ok, out := b.GetGfxMode(); ok {
+		st.mode = strings.TrimSpace(out)
+	}
+	if ok, out := b.GetGfxSupported(); ok {
+		st.supported = parseGfxModes(out)
+	}
+	if ok, out := b.GetGfxPending(); ok {
+		st.pending = parseGfxAction(out)
+	}
+	return st
+}
+
+func (a *App) renderGpu(y, h int) {
+	t := a.term
+	cx := a.padX()
+
+	t.TextBold(cx, y+1, ColText, "GPU Mode")
+	g := a.gfx
+	if !g.installed {
+		a.renderGpuMux(y, h)
+		return
+	}
+	t.Text(cx, y+2, ColTextDim, "Switch graphics mode with supergfxctl")
+
+	t.Text(cx, y+4, ColTextDim, "Current:")
+	t.TextBold(cx+10, y+4, a.accent(), orDash(g.mode))
+	a.renderDgpuPower(cx+28, y+4)
+
+	for i, name := range g.supported {
+		row := y + 6 + i*2
+		desc := ""
+		for _, m := range gfxModes {
+			if m.name == name {
+				desc = m.desc
+			}
+		}
+		marker := "○"
+		if name == g.mode {
+			marker = "●"
+		}
+		line := fmt.Sprintf("%s %-12s", marker, name)
+		if a.focusIdx == i {
+			a.focusText(cx, row, "▸ "+line)
+		} else {
+			t.Text(cx, row, ColTextDim, "  "+line)
+		}
+		t.Text(cx+19, row, ColTextMut, desc)
+		if needs := gfxSwitchNeeds(g.mode, name); needs != "" && g.mode != "" {
+			text, col := "→ "+strings.ToLower(gfxActionText(needs))+" after switching", ColTextDim
+			switch needs {
+			case "Reboot":
+				col = ColWarning
+			case "SwitchToIntegrated":
+				text = "→ " + gfxActionText(needs)
+			}
+			t.Text(cx+19, row+1, col, text)
+		}
+	}
+
+	row := y + 7 + len(g.supported)*2
+	if g.pending != "" {
+		t.TextBold(cx, row, ColWarning, "⚠ "+gfxActionText(g.pending)+" to finish the last switch")
+		row += 2
+	}
+	t.Text(cx, row, ColTextMut, "Enter switch mode  │  r refresh")
+}
+
+// readGfx re-reads the GPU tab's state and with it the MUX mode.
+func (a *App) readGfx() {
+	a.gfx = readGfxState(a.backend)
+	if a.gfx.muxOk {
+		a.gpuMuxDedicated = a.gfx.muxDgpu
+	}
+}
+
+// renderDgpuPower draws the dGPU power state at x, y.
+func (a *App) renderDgpuPower(x, y int) {
+	t := a.term
+	power := a.gfx.power
+	t.Text(x, y, ColTextDim, "dGPU:")
+	if a.gfx.muxDgpu {
+		t.Text(x+6, y, ColWarning, "● drives the display (MUX)")
+		return
+	}
+	pc := ColTextMut
+	switch power {
+	case "active":
+		pc = ColWarning
+	case "suspended", "off":
+		pc = ColSuccess
+	}
+	t.Text(x+6, y, pc, "● "+orDash(power))
+}
+
+// gpuMuxModes are the rows of the GPU tab without supergfxctl.
+var gpuMuxModes = []struct {
+	name      string
+	dedicated bool
+	desc      string
+}{
+	{"Hybrid", false, "iGPU drives the display, dGPU on demand"},
+	{"Dedicated", true, "dGPU drives the display through the MUX switch"},
+}
+
+// renderGpuMux is the GPU tab without supergfxctl: only the MUX switch,
+// which asusctl sets in firmware.
+func (a *App) renderGpuMux(y, h int) {
+	t := a.term
+	cx := a.padX()
+	g := a.gfx
+	t.Text(cx, y+2, ColTextDim, "supergfxctl not found — the MUX switch is set through asusctl")
+
+	if !g.muxOk {
+		t.Text(cx, y+4, ColError, "This laptop has no GPU MUX that asusctl can set")
+		t.Text(cx, y+5, ColTextMut, "Install supergfxctl and enable supergfxd for GPU switching")
+		a.renderDgpuPower(cx, y+7)
+		return
+	}
+	a.renderDgpuPower(cx, y+4)
+	pitch := a.spread(2)
+	for i, m := range gpuMuxModes {
+		row := y + 6 + i*pitch
+		marker := "○"
+		if m.dedicated == a.gpuMuxDedicated {
+			marker = "●"
+		}
+		line := fmt.Sprintf("%s %-12s", marker, m.name)
+		if a.focusIdx == i {
+			a.focusText(cx, row, "▸ "+line)
+		} else {
+			t.Text(cx, row, ColTextDim, "  "+line)
+		}
+		t.Text(cx+19, row, ColTextMut, m.desc)
+	}
+	row := y + 6 + len(gpuMuxModes)*pitch
+	t.Text(cx, row, ColWarning, "The MUX is read at boot: reboot after switching")
+	t.Text(cx, row+2, ColTextMut, "Enter switch mode  │  r refresh")
+}
+
+// gfxActionText describes a supergfxctl required action for the user.
+func gfxActionText(action string) string {
+	switch action {
+	case "Logout":
+		return "Log out"
+	case "Reboot":
+		return "Reboot"
+	case "SwitchToIntegrated":
+		return "Switch to Integrated first"
+	}
+	return action
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "—"
+	}
+	return s
+}
+
+func (a *App) handleGpu(key KeyEvent) {
+	n := len(a.gfx.supported)
+	if !a.gfx.installed {
+		n = 0
+		if a.gfx.muxOk {
+			n = len(gpuMuxModes)
+		}
+	}
+	switch key.Type {
+	case KeyUp:
+		if a.focusIdx > 0 {
+			a.focusIdx--
+		}
+	case KeyDown:
+		if a.focusIdx < n-1 {
+			a.focusIdx++
+		}
+	case KeyChar:
+		if key.Char == 'r' {
+			a.readGfx()
+			a.SetStatus("GPU state refreshed", true)
+		}
+	case KeyEnter:
+		switch {
+		case a.focusIdx >= n:
+		case !a.gfx.installed:
+			if !a.locked("gpu_mode") && gpuMuxModes[a.focusIdx].dedicated != a.gpuMuxDedicated {
+				a.askGpuMux(gpuMuxModes[a.focusIdx].dedicated, nil)
+			}
+		default:
+			a.setGfxMode(a.gfx.supported[a.focusIdx])
+		}
+	}
+}
+
+// askGpuMux confirms a MUX switch, which only takes effect at the next
+// boot, before setGpuMux writes it. then, if set, learns whether it was
+// written; it is not called when the switch is declined.
+func (a *App) askGpuMux(dedicated bool, then func(ok bool)) {
+	m := gpuMuxModes[boolInt(dedicated)]
+	a.ask(&Confirm{
+		Title: "Switch the GPU MUX to " + m.name + "?",
+		Lines: []string{m.desc + ".", "It takes effect after a reboot."},
+		OnYes: func() { a.setGpuMux(dedicated, then) },
+	})
+}
+
+// setGpuMux queues the firmware MUX mode write through asusctl; it takes
+// effect at the next boot. then, if set, learns whether it was written.
+func (a *App) setGpuMux(dedicated bool, then func(ok bool)) {
+	a.submit("armoury:gpu_mux_mode", func(b Backend) (bool, string) {
+		return b.SetGpuMux(dedicated)
+	}, func(ok bool, out string, argv []string) {
+		a.logCommand(argv, out, ok)
+		if ok {
+			a.gpuMuxDedicated = dedicated
+			a.SetStatus("GPU MUX → "+gpuMuxModes[boolInt(dedicated)].name+" (reboot required)", true)
+			a.countUefiWrite(out)
+		} else {
+			a.SetError(out)
+			a.offerElevationFor(argv, out, func() {
+				a.gpuMuxDedicated = dedicated
+				a.SetStatus("GPU MUX changed (reboot required)", true)
+				a.countUefiWrite(out)
+			})
+		}
+		if then != nil {
+			then(ok)
+		}
+	})
+}
+
+func (a *App) setGfxMode(mode string) {
+	if a.locked("gpu_mode") {
+		return
+	}
+	if mode == a.gfx.mode {
+		a.SetStatus("Already in "+mode+" mode", true)
+		return
+	}
+	if gfxSwitchNeeds(a.gfx.mode, mode) == "Reboot" {
+		a.ask(&Confirm{
+			Title: "Switch the GPU to " + mode + "?",
+			Lines: []string{"The MUX switch takes effect after a reboot."},
+			OnYes: func() { a.writeGfxMode(mode) },
+		})
+		return
+	}
+	a.writeGfxMode(mode)
+}
+
+// writeGfxMode queues the switch of supergfxd to mode and notes what it
+// asks of the user to finish.
+func (a *App) writeGfxMode(mode string) {
+	a.submit("gfx_mode", func(b Backend) (bool, string) {
+		return b.SetGfxMode(mode)
+	}, func(ok bool, out string, argv []string) {
+		a.logCommand(argv, out, ok)
+		if !ok {
+			a.SetError(out)
+			return
+		}
+		action := parseGfxAction(out)
+		a.readGfx()
+		if action != "" {
+			a.gfx.pending = action
+			a.SetStatus("GPU → "+mode+": "+strings.ToLower(gfxActionText(action))+" required", true)
+			return
+		}
+		a.SetStatus("GPU → "+mode, true)
+	})
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"io"
+	"testing"
+)
+
+// TestReadGfxFollowsMux checks the GPU tab re-reads the MUX and skips the
+// dGPU power state while the dGPU drives the panel.
+func TestReadGfxFollowsMux(t *testing.T) {
+	m := NewMockBackend()
+	a := NewApp(NewFakeTerminal(80, 24, io.Discard), m, DefaultConfig())
+	a.readGfx()
+	if a.gfx.muxDgpu || a.gfx.power == "" {
+		t.Fatalf("hybrid: muxDgpu = %v, power = %q", a.gfx.muxDgpu, a.gfx.power)
+	}
+	m.SetGpuMux(true)
+	a.readGfx()
+	if !a.gfx.muxDgpu || !a.gpuMuxDedicated {
+		t.Errorf("dedicated: muxDgpu = %v, gpuMuxDedicated = %v", a.gfx.muxDgpu, a.gpuMuxDedicated)
+	}
+	if a.gfx.power != "" {
+		t.Errorf("power = %q, want it left unread", a.gfx.power)
+	}
+}
+
+// TestGpuMuxSwitchAsks checks each way of switching the MUX waits for a
+// yes, as the switch needs a reboot, and the other supergfxd modes do not.
+func TestGpuMuxSwitchAsks(t *testing.T) {
+	tests := []struct {
+		name string
+		do   func(a *App)
+		mux  func(m *MockBackend) bool
+		ask  bool
+	}{
+		{"GPU tab without supergfxctl", func(a *App) { a.askGpuMux(true, nil) },
+			func(m *MockBackend) bool { ok, out := m.GetGpuMux(); return ok && parseArmouryValue(out) == "1" }, true},
+		{"BIOS tab", func(a *App) {
+			a.bios = readArmouryView(a.backend)
+			a.setArmoury(&a.bios, "gpu_mux_mode", "1", nil)
+		}, func(m *MockBackend) bool { ok, out := m.GetGpuMux(); return ok && parseArmouryValue(out) == "1" }, true},
+		{"supergfxctl to the MUX", func(a *App) { a.setGfxMode("AsusMuxDgpu") },
+			func(m *MockBackend) bool { _, mode := m.GetGfxMode(); return mode == "AsusMuxDgpu" }, true},
+		{"supergfxctl to Integrated", func(a *App) { a.setGfxMode("Integrated") },
+			func(m *MockBackend) bool { _, mode := m.GetGfxMode(); return mode == "Integrated" }, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewMockBackend()
+			a := NewApp(NewFakeTerminal(80, 24, io.Discard), m, DefaultConfig())
+			a.readGfx()
+			tt.do(a)
+			if asked := a.confirm != nil; asked != tt.ask {
+				t.Fatalf("asked = %v, want %v", asked, tt.ask)
+			}
+			if tt.ask {
+				drainQueue(t, a)
+				if tt.mux(m) {
+					t.Fatal("switched before the answer")
+				}
+				a.HandleKey(KeyEvent{Type: KeyChar, Char: 'y'})
+			}
+			drainQueue(t, a)
+			if !tt.mux(m) {
+				t.Error("not switched")
+			}
+		})
+	}
+}
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"encoding/binary"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// Hotkeys — Super key suppression and the ROG key, for game mode
+// The Super key is handled by the desktop, so it is switched off through the
+// desktop's own settings and restored afterwards. The ROG key arrives as
+// KEY_PROG1 on the asus-wmi hotkey input device and is read with evdev.
+// ═══════════════════════════════════════════════════════════════════════════════
+
+type HotkeyControl interface {
+	// SetSuperKey turns the desktop's Super key action off or back on.
+	SetSuperKey(enabled bool) (bool, string)
+	// WatchRogKey calls onPress from a background goroutine for every ROG
+	// key press until stop is called.
+	WatchRogKey(onPress func()) (stop func(), err error)
+}
+
+// ─── Super key ───────────────────────────────────────────────────────────────
+
+const (
+	gnomeOverlaySchema = "org.gnome.mutter"
+	kdeMetaGroup       = "ModifierOnlyShortcuts"
+)
+
+// superKeySaved remembers the desktop's setting so it can be put back.
+var superKeySaved struct {
+	sync.Mutex
+	value string
+	set   bool
+}
+
+func (b *ExecBackend) SetSuperKey(enabled bool) (bool, string) {
+	desk := strings.ToLower(os.Getenv("XDG_CURRENT_DESKTOP"))
+	switch {
+	case strings.Contains(desk, "gnome"):
+		return b.setGnomeOverlayKey(enabled)
+	case strings.Contains(desk, "kde"):
+		return b.setKdeMetaKey(enabled)
+	}
+	return false, "disabling the Super key is not supported on this desktop (GNOME and KDE only)"
+}
+
+func (b *ExecBackend) setGnomeOverlayKey(enabled bool) (bool, string) {
+	superKeySaved.Lock()
+	defer superKeySaved.Unlock()
+	if !enabled {
+		ok, out := execWithTimeout(hostCommand("gsettings", "get", gnomeOverlaySchema, "overlay-key"), b.policy.Timeout)
+		if !ok {
+			return false, out
+		}
+		superKeySaved.value, superKeySaved.set = strings.Trim(out, "'"), true
+		return execWithTimeout(hostCommand("gsettings", "set", gnomeOverlaySchema, "overlay-key", ""), b.policy.Timeout)
+	}
+	prev := "Super_L"
+	if superKeySaved.set {
+		prev = superKeySaved.value
+	}
+	superKeySaved.set = false
+	return execWithTimeout(hostCommand("gsettings", "set", gnomeOverlaySchema, "overlay-key", prev), b.policy.Timeout)
+}
+
+func (b *ExecBackend) setKdeMetaKey(enabled bool) (bool, string) {
+	read, write := "kreadconfig6", "kwriteconfig6"
+	if _, err := hostLookPath(write); err != nil {
+		read, write = "kreadconfig5", "kwriteconfig5"
+	}
+	superKeySaved.Lock()
+	defer superKeySaved.Unlock()
+
+	args := []string{"--file", "kwinrc", "--group", kdeMetaGroup, "--key", "Meta"}
+	var ok bool
+	var out string
+	if !enabled {
+		if ok, out = execWithTimeout(hostCommand(read, args...), b.policy.Timeout); !ok {
+			return false, out
+		}
+		superKeySaved.value, superKeySaved.set = out, true
+		ok, out = execWithTimeout(hostCommand(write, append(args, "")...), b.policy.Timeout)
+	} else if superKeySaved.set && superKeySaved.value != "" {
+		ok, out = execWithTimeout(hostCommand(write, append(args, superKeySaved.value)...), b.policy.Timeout)
+		superKeySaved.set = false
+	} else {
+		ok, out = execWithTimeout(hostCommand(write, append(args, "--delete")...), b.policy.Timeout)
+		superKeySaved.set = false
+	}
+	if !ok {
+		return false, out
+	}
+	return execWithTimeout(hostCommand("dbus-send", "--session", "--type=method_call",
+		"--dest=org.kde.KWin", "/KWin", "org.kde.KWin.reconfigure"), b.policy.Timeout)
+}
+
+// ─── ROG key ─────────────────────────────────────────────────────────────────
+
+const (
+	evKey     = 0x01
+	keyProg1  = 148 // the ROG / Armoury Crate key on asus-wmi
+	eventSize = 24  // struct input_event on 64-bit: timeval, type, code, value
+)
+
+// findHotkeyDevice returns the /dev/input node of the asus-wmi hotkey device.
+func findHotkeyDevice() string {
+	names, _ := filepath.Glob("/sys/class/input/event*/device/name")
+	for _, n := range names {
+		data, err := os.ReadFile(n)
+		if err != nil {
+			continue
+		}
+		if strings.TrimSpace(string(data)) == "Asus WMI hotkeys" {
+			return "/dev/input/" + filepath.Base(filepath.Dir(filepath.Dir(n)))
+		}
+	}
+	return ""
+}
+
+func (b *ExecBackend) WatchRogKey(onPress func()) (func(), error) {
+	dev := findHotkeyDevice()
+	if dev == "" {
+		return nil, errors.New("asus-wmi hotkey device not found")
+	}
+	f, err := os.Open(dev)
+	if err != nil {
+		return nil, err // usually: user is not in the 'input' group
+	}
+	go func() {
+		buf := make([]byte, eventSize)
+		for {
+			if _, err := f.Read(buf); err != nil {
+				return
+			}
+			typ := binary.LittleEndian.Uint16(buf[16:])
+			code := binary.LittleEndian.Uint16(buf[18:])
+			value := int32(binary.LittleEndian.Uint32(buf[20:]))
+			if typ == evKey && code == keyProg1 && value == 1 {
+				onPress()
+			}
+		}
+	}()
+	return func() { f.Close() }, nil
+}
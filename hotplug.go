@@ -0,0 +1,109 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// Display rules — actions when an external monitor is plugged in or out
+// Typical use: Performance and a MUX hint when docking at a desk, back to
+// Balanced when the monitor goes. Quiet hours win over a rule's profile.
+// ═══════════════════════════════════════════════════════════════════════════════
+
+func (r DisplayRule) Validate() error {
+	if r.On != "attach" && r.On != "detach" {
+		return fmt.Errorf("on: %q must be \"attach\" or \"detach\"", r.On)
+	}
+	if _, err := filepath.Match(r.Connector, ""); err != nil {
+		return fmt.Errorf("connector: bad pattern %q", r.Connector)
+	}
+	if r.Profile != "" && matchProfile(r.Profile) == "" {
+		return fmt.Errorf("profile: unknown profile %q", r.Profile)
+	}
+	if r.Profile == "" && !r.MuxAdvice {
+		return errors.New("rule does nothing; set profile or mux_advice")
+	}
+	return nil
+}
+
+func (r DisplayRule) Matches(event, connector string) bool {
+	if r.On != event {
+		return false
+	}
+	ok, _ := filepath.Match(r.Connector, connector)
+	return r.Connector == "" || ok
+}
+
+// Describe is the one-line summary shown on the Automation tab.
+func (r DisplayRule) Describe() string {
+	conn := r.Connector
+	if conn == "" {
+		conn = "any display"
+	}
+	var acts []string
+	if r.Profile != "" {
+		acts = append(acts, matchProfile(r.Profile)+" profile")
+	}
+	if r.MuxAdvice {
+		acts = append(acts, "MUX advice")
+	}
+	return fmt.Sprintf("on %s of %s → %s", r.On, conn, strings.Join(acts, ", "))
+}
+
+// checkDisplays diffs the connected external displays against the last
+// reading and runs the rules for each one that came or went.
+func (a *App) checkDisplays() {
+	au := &a.automation
+	now := a.backend.GetDisplays()
+	prev, primed := au.displays, au.displaysRead
+	au.displays, au.displaysRead = now, true
+	if !primed {
+		return // monitors already plugged in at startup are not events
+	}
+	for _, c := range now {
+		if !containsString(prev, c) {
+			a.runDisplayRules("attach", c)
+		}
+	}
+	for _, c := range prev {
+		if !containsString(now, c) {
+			a.runDisplayRules("detach", c)
+		}
+	}
+}
+
+func (a *App) runDisplayRules(event, connector string) {
+	for i, r := range a.cfg.DisplayRules {
+		if !r.Matches(event, connector) {
+			continue
+		}
+		if a.automation.ruleFired == nil {
+			a.automation.ruleFired = map[int]string{}
+		}
+		a.automation.ruleFired[i] = time.Now().Format("15:04:05")
+		what := fmt.Sprintf("display %s %sed", connector, event)
+
+		if p := matchProfile(r.Profile); p != "" && p != a.profile {
+			msg := "Display " + connector + " " + event + "ed → " + p
+			a.automationProfile(what, p, func() { a.SetStatus(msg, true) })
+		}
+		if r.MuxAdvice && event == "attach" && a.gfx.installed && a.gfx.mode != "AsusMuxDgpu" {
+			advice := "the iGPU drives " + connector + "; AsusMuxDgpu on the GPU tab gives it the dGPU directly"
+			a.automationLog(what+": MUX advice", advice, true)
+			a.SetStatus("Display "+connector+": switch the GPU MUX to dGPU for full performance", true)
+		}
+	}
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
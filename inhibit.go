@@ -0,0 +1,76 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// Sleep inhibitor — logind's Inhibit API, so a running measurement is not cut
+// short by suspend. The lock is held by a systemd-inhibit child (logind
+// hands out a file descriptor, released when its holder exits); killing the
+// child releases it. Pdeathsig covers a crash of the TUI itself.
+// ═══════════════════════════════════════════════════════════════════════════════
+
+type InhibitControl interface {
+	// InhibitSleep blocks suspend and idle until release is called.
+	InhibitSleep(why string) (release func(), err error)
+}
+
+// inhibitSettle is how long systemd-inhibit gets to fail: when logind or
+// polkit refuses the lock it exits at once instead of running its child.
+const inhibitSettle = 300 * time.Millisecond
+
+func (b *ExecBackend) InhibitSleep(why string) (func(), error) {
+	if _, err := hostLookPath("systemd-inhibit"); err != nil {
+		return nil, err
+	}
+	cmd := hostCommand("systemd-inhibit", "--what=sleep:idle", "--who=asusctl-tui",
+		"--why="+why, "--mode=block", "sleep", "infinity")
+	cmd.SysProcAttr = &syscall.SysProcAttr{Pdeathsig: syscall.SIGTERM}
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+	exited := make(chan error, 1)
+	go func() { exited <- cmd.Wait() }()
+	select {
+	case err := <-exited:
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, errors.New(msg)
+		}
+		return nil, fmt.Errorf("systemd-inhibit exited at once (%v)", err)
+	case <-time.After(inhibitSettle):
+	}
+	return func() { cmd.Process.Signal(syscall.SIGTERM) }, nil
+}
+
+// holdSleepInhibit takes the inhibitor unless already held. Failures are
+// logged and returned; the session itself still works without it.
+func (a *App) holdSleepInhibit(why string) error {
+	if a.inhibitRelease != nil {
+		return nil
+	}
+	release, err := a.backend.InhibitSleep(why)
+	if err != nil {
+		a.addLog("systemd-inhibit --what=sleep:idle", err.Error(), false)
+		return err
+	}
+	a.addLog("systemd-inhibit --what=sleep:idle", "sleep inhibited: "+why, true)
+	a.inhibitRelease = release
+	return nil
+}
+
+func (a *App) releaseSleepInhibit() {
+	if a.inhibitRelease == nil {
+		return
+	}
+	a.inhibitRelease()
+	a.inhibitRelease = nil
+	a.addLog("systemd-inhibit", "sleep inhibitor released", true)
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// fakeInhibit puts a systemd-inhibit running script first on PATH.
+func fakeInhibit(t *testing.T, script string) {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "systemd-inhibit"), []byte("#!/bin/sh\n"+script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+}
+
+func TestInhibitSleepRefused(t *testing.T) {
+	fakeInhibit(t, "echo 'Failed to inhibit: Access denied' >&2\nexit 1\n")
+	release, err := (&ExecBackend{}).InhibitSleep("test")
+	if err == nil || !strings.Contains(err.Error(), "Access denied") {
+		t.Fatalf("err = %v, want the refusal", err)
+	}
+	if release != nil {
+		t.Error("a refused inhibitor came with a release func")
+	}
+}
+
+func TestInhibitSleepHeld(t *testing.T) {
+	fakeInhibit(t, "exec sleep 5\n")
+	release, err := (&ExecBackend{}).InhibitSleep("test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	release()
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"path/filepath"
+	"strings"
+	"syscall"
+	"unsafe"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// Config watcher — inotify on /etc/asusd so aura and fan curve state refresh
+// when asusd rewrites its RON configs. Raw syscalls, no fsnotify.
+// ═══════════════════════════════════════════════════════════════════════════════
+
+const asusdConfigDir = "/etc/asusd"
+
+// configFileArea maps an asusd config file name to the state it holds.
+func configFileArea(name string) (ChangeArea, bool) {
+	if !strings.HasSuffix(name, ".ron") {
+		return 0, false
+	}
+	switch {
+	case strings.HasPrefix(name, "aura"):
+		return ChangeAura, true
+	case strings.HasPrefix(name, "fan_curves"):
+		return ChangeFans, true
+	case name == "asusd.ron":
+		return ChangeProfile, true
+	}
+	return 0, false
+}
+
+// watchAsusdConfigs watches dir and reports changed areas until the process
+// exits. Editors and asusd replace files by rename, so moves count too.
+func watchAsusdConfigs(dir string, onChange func(ChangeArea)) error {
+	fd, err := syscall.InotifyInit1(syscall.IN_CLOEXEC)
+	if err != nil {
+		return err
+	}
+	mask := uint32(syscall.IN_CLOSE_WRITE | syscall.IN_MOVED_TO | syscall.IN_CREATE)
+	if _, err := syscall.InotifyAddWatch(fd, dir, mask); err != nil {
+		syscall.Close(fd)
+		return err
+	}
+
+	areas := make(chan ChangeArea, 64)
+	go func() {
+		defer syscall.Close(fd)
+		defer close(areas)
+		buf := make([]byte, 4096)
+		for {
+			n, err := syscall.Read(fd, buf)
+			if err == syscall.EINTR {
+				continue
+			}
+			if err != nil || n <= 0 {
+				return
+			}
+			for off := 0; off+syscall.SizeofInotifyEvent <= n; {
+				ev := (*syscall.InotifyEvent)(unsafe.Pointer(&buf[off]))
+				nameStart := off + syscall.SizeofInotifyEvent
+				nameEnd := nameStart + int(ev.Len)
+				if nameEnd > n {
+					break
+				}
+				name := strings.TrimRight(string(buf[nameStart:nameEnd]), "\x00")
+				if area, ok := configFileArea(filepath.Base(name)); ok {
+					areas <- area
+				}
+				off = nameEnd
+			}
+		}
+	}()
+	go coalesceChanges(areas, onChange)
+	return nil
+}
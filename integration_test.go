@@ -0,0 +1,333 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+	"unicode/utf8"
+	"unsafe"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// Integration tests — run the built binary in a PTY with --demo, send keys and
+// assert on the emulated screen. Skipped with -short.
+// ═══════════════════════════════════════════════════════════════════════════════
+
+const (
+	ioctlSetWinSz  = 0x5414     // TIOCSWINSZ
+	ioctlGetPtyNum = 0x80045430 // TIOCGPTN
+	ioctlSetPtyLck = 0x40045431 // TIOCSPTLCK
+)
+
+func ioctl(fd uintptr, req uintptr, arg unsafe.Pointer) error {
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, fd, req, uintptr(arg))
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
+
+// openPty returns the master and slave ends of a new pseudo-terminal.
+func openPty(cols, rows int) (*os.File, *os.File, error) {
+	master, err := os.OpenFile("/dev/ptmx", os.O_RDWR|syscall.O_NOCTTY, 0)
+	if err != nil {
+		return nil, nil, err
+	}
+	var unlock int32
+	if err := ioctl(master.Fd(), ioctlSetPtyLck, unsafe.Pointer(&unlock)); err != nil {
+		master.Close()
+		return nil, nil, err
+	}
+	var n uint32
+	if err := ioctl(master.Fd(), ioctlGetPtyNum, unsafe.Pointer(&n)); err != nil {
+		master.Close()
+		return nil, nil, err
+	}
+	slave, err := os.OpenFile("/dev/pts/"+strconv.Itoa(int(n)), os.O_RDWR|syscall.O_NOCTTY, 0)
+	if err != nil {
+		master.Close()
+		return nil, nil, err
+	}
+	ws := winsize{Row: uint16(rows), Col: uint16(cols)}
+	if err := ioctl(slave.Fd(), ioctlSetWinSz, unsafe.Pointer(&ws)); err != nil {
+		master.Close()
+		slave.Close()
+		return nil, nil, err
+	}
+	return master, slave, nil
+}
+
+// ─── Screen emulator ─────────────────────────────────────────────────────────
+
+// screen understands just enough ANSI to track what ends up where:
+// cursor positioning and printable text. Styles are ignored.
+type screen struct {
+	mu     sync.Mutex
+	cells  [][]rune
+	x, y   int
+	escBuf []byte
+	inEsc  bool
+	utf8   []byte
+}
+
+func newScreen(cols, rows int) *screen {
+	s := &screen{cells: make([][]rune, rows)}
+	for i := range s.cells {
+		s.cells[i] = []rune(strings.Repeat(" ", cols))
+	}
+	return s
+}
+
+func (s *screen) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, b := range p {
+		if s.inEsc {
+			s.escBuf = append(s.escBuf, b)
+			if len(s.escBuf) == 1 && b != '[' {
+				s.inEsc = false
+				continue
+			}
+			if len(s.escBuf) > 1 && b >= 0x40 && b <= 0x7e {
+				s.csi(string(s.escBuf[1:len(s.escBuf)-1]), b)
+				s.inEsc = false
+			}
+			continue
+		}
+		switch {
+		case b == 27:
+			s.inEsc = true
+			s.escBuf = s.escBuf[:0]
+		case b < 32:
+		default:
+			s.utf8 = append(s.utf8, b)
+			if !utf8.FullRune(s.utf8) {
+				continue
+			}
+			r, _ := utf8.DecodeRune(s.utf8)
+			s.utf8 = s.utf8[:0]
+			s.put(r)
+		}
+	}
+	return len(p), nil
+}
+
+func (s *screen) csi(params string, final byte) {
+	if final != 'H' {
+		return
+	}
+	s.x, s.y = 0, 0
+	if params == "" {
+		return
+	}
+	parts := strings.SplitN(params, ";", 2)
+	row, _ := strconv.Atoi(parts[0])
+	col := 1
+	if len(parts) == 2 {
+		col, _ = strconv.Atoi(parts[1])
+	}
+	s.x, s.y = col-1, row-1
+}
+
+func (s *screen) put(r rune) {
+	if s.y >= 0 && s.y < len(s.cells) && s.x >= 0 && s.x < len(s.cells[s.y]) {
+		s.cells[s.y][s.x] = r
+	}
+	s.x++
+}
+
+func (s *screen) String() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	lines := make([]string, len(s.cells))
+	for i, row := range s.cells {
+		lines[i] = strings.TrimRight(string(row), " ")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// ─── Harness ─────────────────────────────────────────────────────────────────
+
+type ptySession struct {
+	t      *testing.T
+	cmd    *exec.Cmd
+	master *os.File
+	scr    *screen
+	done   chan struct{}
+}
+
+var (
+	buildOnce sync.Once
+	buildPath string
+	buildErr  error
+)
+
+func TestMain(m *testing.M) {
+	code := m.Run()
+	if buildPath != "" {
+		os.RemoveAll(filepath.Dir(buildPath))
+	}
+	os.Exit(code)
+}
+
+func buildBinary(t *testing.T) string {
+	buildOnce.Do(func() {
+		dir, err := os.MkdirTemp("", "asusctl-gui-it")
+		if err != nil {
+			buildErr = err
+			return
+		}
+		buildPath = filepath.Join(dir, "asusctl-gui")
+		out, err := exec.Command("go", "build", "-o", buildPath, ".").CombinedOutput()
+		if err != nil {
+			buildErr = fmt.Errorf("%v\n%s", err, out)
+		}
+	})
+	if buildErr != nil {
+		t.Fatalf("build failed: %v", buildErr)
+	}
+	return buildPath
+}
+
+func startSession(t *testing.T, args ...string) *ptySession {
+	t.Helper()
+	if testing.Short() {
+		t.Skip("integration test")
+	}
+	bin := buildBinary(t)
+	const cols, rows = 120, 40
+	master, slave, err := openPty(cols, rows)
+	if err != nil {
+		t.Skipf("no pty available: %v", err)
+	}
+	cmd := exec.Command(bin, args...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = slave, slave, slave
+	// the developer's own config and state must not leak into the run
+	cmd.Env = append(os.Environ(), "TERM=xterm-256color",
+		"HOME="+t.TempDir(), "XDG_CONFIG_HOME="+t.TempDir(), "XDG_STATE_HOME="+t.TempDir())
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setsid: true, Setctty: true}
+	if err := cmd.Start(); err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	slave.Close()
+
+	s := &ptySession{t: t, cmd: cmd, master: master, scr: newScreen(cols, rows), done: make(chan struct{})}
+	go func() {
+		buf := make([]byte, 4096)
+		for {
+			n, err := master.Read(buf)
+			if n > 0 {
+				s.scr.Write(buf[:n])
+			}
+			if err != nil {
+				close(s.done)
+				return
+			}
+		}
+	}()
+	t.Cleanup(func() {
+		cmd.Process.Kill()
+		cmd.Wait()
+		master.Close()
+	})
+	return s
+}
+
+// send writes each key separately — ReadKey consumes one key per read.
+func (s *ptySession) send(keys ...string) {
+	for _, k := range keys {
+		if _, err := s.master.Write([]byte(k)); err != nil {
+			s.t.Fatalf("write %q: %v", k, err)
+		}
+		time.Sleep(60 * time.Millisecond)
+	}
+}
+
+func (s *ptySession) waitFor(want string) {
+	s.t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if strings.Contains(s.scr.String(), want) {
+			return
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	s.t.Fatalf("screen never showed %q; last screen:\n%s", want, s.scr.String())
+}
+
+func typeString(s string) []string {
+	keys := make([]string, 0, len(s))
+	for _, r := range s {
+		keys = append(keys, string(r))
+	}
+	return keys
+}
+
+const (
+	keyRight = "\033[C"
+	keyDown  = "\033[B"
+	keyEnter = "\r"
+)
+
+// ─── Tests ───────────────────────────────────────────────────────────────────
+
+func TestIntegrationStartupAndQuit(t *testing.T) {
+	s := startSession(t, "--demo")
+	s.waitFor("AsusCtl Control Center")
+	s.waitFor("demo mode")
+	s.waitFor("Live readings")
+
+	s.send("q")
+	select {
+	case <-s.done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("app did not exit on q")
+	}
+	if err := s.cmd.Wait(); err != nil {
+		t.Fatalf("exit: %v", err)
+	}
+}
+
+func TestIntegrationProfileSwitch(t *testing.T) {
+	s := startSession(t, "--demo")
+	s.waitFor("Live readings")
+	s.send("1")
+	s.waitFor("Power Profile")
+	s.send(keyDown, keyDown, keyEnter)
+	s.waitFor("Profile → Quiet")
+}
+
+func TestIntegrationBatteryLimit(t *testing.T) {
+	s := startSession(t, "--demo")
+	s.waitFor("Live readings")
+	s.send("4")
+	s.waitFor("Battery & Charging")
+	s.send(keyRight, keyRight, keyEnter)
+	s.waitFor("Charge limit → 90%")
+}
+
+func TestIntegrationConsole(t *testing.T) {
+	s := startSession(t, "--demo")
+	s.waitFor("Live readings")
+	s.send("9")
+	s.waitFor("Raw Console")
+	s.send(typeString("profile get")...)
+	s.send(keyEnter)
+	s.waitFor("Active profile is Balanced")
+}
+
+func TestIntegrationScenarioDrain(t *testing.T) {
+	s := startSession(t, "--scenario", "drain")
+	s.waitFor("Live readings")
+	s.send("4")
+	s.waitFor("Battery & Charging")
+	s.waitFor("42%")
+	s.waitFor("Discharging")
+}
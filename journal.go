@@ -0,0 +1,139 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// Journal — follow asusd's log through journalctl
+// asusd explains rejected fan curves and aura commands only in its log, so the
+// Logs tab tails it. journalctl's JSON output carries the syslog priority.
+// ═══════════════════════════════════════════════════════════════════════════════
+
+type LogControl interface {
+	// FollowLogs calls onLine from a background goroutine for the recent
+	// backlog and every new asusd log line until stop is called.
+	FollowLogs(onLine func(LogLine)) (stop func(), err error)
+}
+
+// LogLine is one journal entry.
+type LogLine struct {
+	Time     string // 15:04:05
+	Priority int    // syslog: 3 err, 4 warning, 6 info, 7 debug
+	Message  string
+}
+
+const (
+	logPrioErr     = 3
+	logPrioWarning = 4
+	logPrioInfo    = 6
+	logPrioDebug   = 7
+)
+
+// logLevelWords are the level tags asusd writes into the message itself.
+// journald files all of asusd's stderr under one priority, so the tag is
+// the better signal.
+var logLevelWords = []struct {
+	word string
+	prio int
+}{
+	{"ERROR", logPrioErr},
+	{"WARN", logPrioWarning},
+	{"INFO", logPrioInfo},
+	{"DEBUG", logPrioDebug},
+	{"TRACE", logPrioDebug},
+}
+
+// logSeverity picks the priority to colour msg by: the message's own level
+// tag when it has one, otherwise the journal's priority.
+func logSeverity(prio int, msg string) int {
+	head := msg
+	if len(head) > 48 {
+		head = head[:48]
+	}
+	for _, lw := range logLevelWords {
+		if strings.Contains(head, " "+lw.word+" ") || strings.HasPrefix(head, lw.word+" ") ||
+			strings.Contains(head, "["+lw.word) {
+			return lw.prio
+		}
+	}
+	return prio
+}
+
+// parseJournalLine reads one line of `journalctl -o json`.
+func parseJournalLine(line string) (LogLine, bool) {
+	var e map[string]any
+	if err := json.Unmarshal([]byte(line), &e); err != nil {
+		return LogLine{}, false
+	}
+	l := LogLine{Priority: logPrioInfo}
+	switch m := e["MESSAGE"].(type) {
+	case string:
+		l.Message = m
+	case []any: // non-UTF-8 messages come as a byte array
+		b := make([]byte, 0, len(m))
+		for _, v := range m {
+			if f, ok := v.(float64); ok {
+				b = append(b, byte(f))
+			}
+		}
+		l.Message = strings.ToValidUTF8(string(b), "?")
+	}
+	if p, ok := e["PRIORITY"].(string); ok {
+		if n, err := strconv.Atoi(p); err == nil {
+			l.Priority = n
+		}
+	}
+	if ts, ok := e["__REALTIME_TIMESTAMP"].(string); ok {
+		if us, err := strconv.ParseInt(ts, 10, 64); err == nil {
+			l.Time = time.UnixMicro(us).Format("15:04:05")
+		}
+	}
+	l.Priority = logSeverity(l.Priority, l.Message)
+	return l, true
+}
+
+func (b *ExecBackend) FollowLogs(onLine func(LogLine)) (func(), error) {
+	cmd := hostCommand("journalctl", "-u", asusdUnit, "-f", "-n", "200", "-o", "json", "--no-pager")
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+	go func() {
+		sc := bufio.NewScanner(stdout)
+		sc.Buffer(make([]byte, 64*1024), 1024*1024)
+		for sc.Scan() {
+			if l, ok := parseJournalLine(sc.Text()); ok {
+				onLine(l)
+			}
+		}
+		cmd.Wait()
+	}()
+	// journalctl reports missing permissions (not in systemd-journal) here
+	go func() {
+		sc := bufio.NewScanner(stderr)
+		for sc.Scan() {
+			onLine(LogLine{
+				Time:     time.Now().Format("15:04:05"),
+				Priority: logPrioWarning,
+				Message:  "journalctl: " + sc.Text(),
+			})
+		}
+	}()
+	return func() {
+		if cmd.Process != nil {
+			cmd.Process.Kill()
+		}
+	}, nil
+}
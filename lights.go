@@ -0,0 +1,81 @@
+package main
+
+import "strings"
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// Lights out — l on any tab but the Console turns the keyboard backlight
+// and the lit LEDs of the Keyboard tab (lightbar, logo, lid) off, and the
+// next l turns them back on as they were. The effect itself stays set in
+// asusd, so bringing the brightness back brings it back too. Meant for
+// dark rooms and meetings.
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// lightsOut is what l switched off.
+type lightsOut struct {
+	level int      // kbdLevel before
+	leds  []string // auraPower zones that were lit
+}
+
+// toggleLights switches the lighting off, or back on after an earlier l.
+// Turning the backlight on by hand in between forgets what l switched off.
+func (a *App) toggleLights() {
+	if a.locked("aura") {
+		return
+	}
+	if out := a.lightsOut; out != nil {
+		a.lightsOut = nil
+		a.setLights(out.level, out.leds, true)
+		msg := "Lighting back on"
+		if out.level > 0 {
+			msg += " (" + kbdLabels[out.level] + ")"
+		}
+		a.SetStatus(msg, true)
+		return
+	}
+	out := &lightsOut{level: a.kbdLevel}
+	for _, led := range a.auraPower {
+		if led.On {
+			out.leds = append(out.leds, led.Zone)
+		}
+	}
+	if out.level == 0 && len(out.leds) == 0 {
+		a.SetStatus("The lighting is already off", false)
+		return
+	}
+	a.lightsOut = out
+	a.setLights(0, out.leds, false)
+	a.SetStatus("Lighting off · l turns it back on", true)
+}
+
+// setLights queues the keyboard brightness level and switches the zones'
+// LEDs on or off. The writes share their keys with the Keyboard tab's, so
+// a second l replaces the first one's writes while they still wait.
+func (a *App) setLights(level int, zones []string, on bool) {
+	a.submit("kbd", func(b Backend) (bool, string) {
+		return b.SetKbdBrightness(kbdValues[level])
+	}, func(ok bool, out string, argv []string) {
+		a.logCommand(argv, out, ok)
+		if !ok {
+			a.SetError(out)
+			return
+		}
+		a.kbdLevel = level
+	})
+	for _, zone := range zones {
+		zone := zone
+		a.submit("aura_power:"+zone, func(b Backend) (bool, string) {
+			return b.SetAuraPower(zone, on)
+		}, func(ok bool, out string, argv []string) {
+			a.logCommand(argv, out, ok)
+			if !ok {
+				a.SetError(out)
+				return
+			}
+			for i := range a.auraPower {
+				if strings.EqualFold(a.auraPower[i].Zone, zone) {
+					a.auraPower[i].On = on
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,156 @@
+package main
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// LineEdit — single-line text input with a cursor
+// Readline-style keys: ←→ and Home/End move, Alt+B/Alt+F jump by word,
+// Backspace/Delete remove a character, Ctrl+W the word before the cursor and
+// Ctrl+U everything before it. Bracketed pastes are inserted as one line.
+// Used by the console; reuse it for any new text field.
+// ═══════════════════════════════════════════════════════════════════════════════
+
+type LineEdit struct {
+	text []rune
+	pos  int // cursor, 0..len(text)
+}
+
+func (e *LineEdit) String() string { return string(e.text) }
+func (e *LineEdit) Empty() bool    { return len(e.text) == 0 }
+
+// Set replaces the text and puts the cursor at the end.
+func (e *LineEdit) Set(s string) {
+	e.text = []rune(s)
+	e.pos = len(e.text)
+}
+
+func (e *LineEdit) Clear() { e.Set("") }
+
+// Insert types s at the cursor.
+func (e *LineEdit) Insert(s string) {
+	r := []rune(s)
+	e.text = append(e.text[:e.pos], append(r, e.text[e.pos:]...)...)
+	e.pos += len(r)
+}
+
+// HandleKey applies an editing key and reports whether it was one.
+func (e *LineEdit) HandleKey(key KeyEvent) bool {
+	switch key.Type {
+	case KeyLeft:
+		e.pos = max(e.pos-1, 0)
+	case KeyRight:
+		e.pos = min(e.pos+1, len(e.text))
+	case KeyHome:
+		e.pos = 0
+	case KeyEnd:
+		e.pos = len(e.text)
+	case KeyBackspace:
+		if e.pos > 0 {
+			e.text = append(e.text[:e.pos-1], e.text[e.pos:]...)
+			e.pos--
+		}
+	case KeyDelete:
+		if e.pos < len(e.text) {
+			e.text = append(e.text[:e.pos], e.text[e.pos+1:]...)
+		}
+	case KeyCtrlW:
+		from := e.wordLeft()
+		e.text = append(e.text[:from], e.text[e.pos:]...)
+		e.pos = from
+	case KeyCtrlU:
+		e.text = e.text[e.pos:]
+		e.pos = 0
+	case KeyPaste:
+		e.Insert(sanitizePaste(key.Text))
+	case KeyChar:
+		switch {
+		case key.Alt && key.Char == 'b':
+			e.pos = e.wordLeft()
+		case key.Alt && key.Char == 'f':
+			e.pos = e.wordRight()
+		case !key.Alt && key.Char >= 32 && key.Char < 127: // input is read bytewise
+			e.Insert(string(key.Char))
+		default:
+			return false
+		}
+	default:
+		return false
+	}
+	return true
+}
+
+// wordLeft is the start of the word before the cursor.
+func (e *LineEdit) wordLeft() int {
+	i := e.pos
+	for i > 0 && e.text[i-1] == ' ' {
+		i--
+	}
+	for i > 0 && e.text[i-1] != ' ' {
+		i--
+	}
+	return i
+}
+
+// wordRight is the end of the word after the cursor.
+func (e *LineEdit) wordRight() int {
+	i := e.pos
+	for i < len(e.text) && e.text[i] == ' ' {
+		i++
+	}
+	for i < len(e.text) && e.text[i] != ' ' {
+		i++
+	}
+	return i
+}
+
+// Render draws the field w cells wide at x,y, scrolled so the cursor is in
+// view. The cursor is only drawn when focused.
+func (e *LineEdit) Render(t *Terminal, x, y, w int, focused bool) {
+	off := max(e.pos-(w-1), 0)
+	vis := e.text[off:]
+	if len(vis) > w {
+		vis = vis[:w]
+	}
+	t.ResetStyle()
+	t.Fg(ColText)
+	t.Bg(ColInput)
+	t.MoveTo(x, y)
+	t.Write(string(vis) + rep(" ", w-len(vis)))
+	if !focused {
+		return
+	}
+	under := " "
+	if e.pos < len(e.text) {
+		under = string(e.text[e.pos])
+	}
+	t.Bg(t.Accent())
+	t.Fg(Color{255, 255, 255})
+	t.MoveTo(x+e.pos-off, y)
+	t.Write(under)
+	t.ResetStyle()
+}
+
+// ansiSeq matches CSI escape sequences such as colours copied from a terminal.
+var ansiSeq = regexp.MustCompile(`\x1b\[[0-9;?]*[ -/]*[@-~]`)
+
+// sanitizePaste makes pasted text safe for a single-line field: line breaks
+// and tabs become spaces, other control characters (escape sequences
+// included) and invalid UTF-8 are dropped.
+func sanitizePaste(s string) string {
+	var b strings.Builder
+	for _, r := range ansiSeq.ReplaceAllString(s, "") {
+		switch {
+		case r == '\n' || r == '\r' || r == '\t':
+			b.WriteByte(' ')
+		case r == utf8.RuneError, unicode.IsControl(r):
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return strings.TrimSpace(b.String())
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// Lockdown — admin policy that disables tabs and actions on managed laptops
+// Only the system-wide config can set it; a user's own file cannot lift it.
+// This is a UI policy: asusd's polkit rules remain the real enforcement.
+// ═══════════════════════════════════════════════════════════════════════════════
+
+const lockGlyph = "🔒" // two cells wide
+
+// lockActions are the action names [lockdown] actions accepts.
+var lockActions = map[string]string{
+	"profile":        "Changing the power profile",
+	"charge_limit":   "Changing charging settings",
+	"fan_curves":     "Changing fan curves",
+	"aura":           "Changing Aura lighting",
+	"bios":           "Changing BIOS settings",
+	"gpu_mode":       "Switching the GPU mode",
+	"console":        "Running raw asusctl commands",
+	"daemon_restart": "Restarting asusd",
+	"elevate":        "Retrying commands through pkexec",
+	"quiet_override": "Overriding quiet hours",
+}
+
+// tabActions is the action that covers a tab's writes, for the padlock on
+// the tab itself.
+var tabActions = map[Tab]string{
+	TabProfile: "profile",
+	TabAura:    "aura",
+	TabSlash:   "aura",
+	TabBattery: "charge_limit",
+	TabFans:    "fan_curves",
+	TabGpu:     "gpu_mode",
+	TabBios:    "bios",
+	TabDisplay: "bios",
+	TabConsole: "console",
+}
+
+// Validate rejects unknown tab and action names so a typo in the policy
+// does not silently leave something unlocked.
+func (l LockdownConfig) Validate() error {
+	for _, name := range l.Tabs {
+		if _, ok := tabByName(name); !ok {
+			return fmt.Errorf("lockdown.tabs: unknown tab %q", name)
+		}
+	}
+	for _, name := range l.Actions {
+		if _, ok := lockActions[name]; !ok {
+			return fmt.Errorf("lockdown.actions: unknown action %q", name)
+		}
+	}
+	return nil
+}
+
+func tabByName(name string) (Tab, bool) {
+	for i, n := range tabNames {
+		if strings.EqualFold(n, name) {
+			return Tab(i), true
+		}
+	}
+	return 0, false
+}
+
+func (l LockdownConfig) TabLocked(tab Tab) bool {
+	for _, name := range l.Tabs {
+		if t, ok := tabByName(name); ok && t == tab {
+			return true
+		}
+	}
+	return false
+}
+
+func (l LockdownConfig) ActionLocked(action string) bool {
+	for _, name := range l.Actions {
+		if name == action {
+			return true
+		}
+	}
+	return false
+}
+
+// locked reports whether action is disabled by policy, and says so in the
+// status bar when it is. Call it before the backend write.
+func (a *App) locked(action string) bool {
+	if !a.cfg.Lockdown.ActionLocked(action) {
+		return false
+	}
+	a.SetStatus(lockGlyph+" "+lockActions[action]+" is disabled by your administrator", false)
+	return true
+}
+
+// renderLockNotice marks the active tab read-only when its writes are locked.
+func (a *App) renderLockNotice(y int) {
+	action, ok := tabActions[a.activeTab]
+	if !ok || !a.cfg.Lockdown.ActionLocked(action) {
+		return
+	}
+	msg := lockGlyph + " locked by policy"
+	a.term.Text(a.term.Width()-len([]rune(msg))-4, y, ColWarning, msg)
+}
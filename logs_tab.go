@@ -0,0 +1,160 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// Page: Logs
+// ═══════════════════════════════════════════════════════════════════════════════
+
+const logScrollback = 2000
+
+// logState is the Logs tab. journalctl starts the first time the tab opens.
+type logState struct {
+	lines   []LogLine
+	scroll  int  // lines above the bottom; 0 follows new output
+	paused  bool // hold the view still while lines keep arriving
+	stop    func()
+	started bool
+
+	// Lines arrive on journalctl's goroutine and are handed over in batches,
+	// so the backlog does not flood the event queue.
+	mu      sync.Mutex
+	pending []LogLine
+}
+
+func (a *App) startLogs() {
+	lg := &a.logs
+	if lg.started {
+		return
+	}
+	lg.started = true
+	stop, err := a.backend.FollowLogs(func(l LogLine) {
+		lg.mu.Lock()
+		first := len(lg.pending) == 0
+		lg.pending = append(lg.pending, l)
+		lg.mu.Unlock()
+		if first {
+			a.Post(a.drainLogs)
+		}
+	})
+	if err != nil {
+		a.addLog("journalctl -u asusd -f", err.Error(), false)
+		a.SetError(err.Error())
+		return
+	}
+	lg.stop = stop
+}
+
+func (a *App) stopLogs() {
+	if a.logs.stop != nil {
+		a.logs.stop()
+		a.logs.stop = nil
+	}
+}
+
+// drainLogs moves pending lines into the scrollback on the main loop.
+func (a *App) drainLogs() {
+	lg := &a.logs
+	lg.mu.Lock()
+	batch := lg.pending
+	lg.pending = nil
+	lg.mu.Unlock()
+	if len(batch) == 0 {
+		return
+	}
+	lg.lines = append(lg.lines, batch...)
+	if lg.paused || lg.scroll > 0 {
+		lg.scroll += len(batch) // keep the same lines on screen
+	}
+	if n := len(lg.lines) - logScrollback; n > 0 {
+		lg.lines = append([]LogLine(nil), lg.lines[n:]...)
+	}
+	lg.scroll = clamp(lg.scroll, 0, max(len(lg.lines)-1, 0))
+}
+
+func logColor(prio int) Color {
+	switch {
+	case prio <= logPrioErr:
+		return ColError
+	case prio == logPrioWarning:
+		return ColWarning
+	case prio >= logPrioDebug:
+		return ColTextMut
+	}
+	return ColText
+}
+
+func (a *App) renderLogs(y, h int) {
+	t := a.term
+	W := t.Width()
+	cx := a.padX()
+	lg := &a.logs
+	a.drainLogs() // in case the Post was dropped while the queue was full
+
+	t.TextBold(cx, y+1, ColText, "asusd Log")
+	t.Text(cx, y+2, ColTextDim, "journalctl -u asusd -f — why the daemon rejected a command")
+
+	state, col := "● following", ColSuccess
+	if lg.paused {
+		state, col = "‖ paused", ColWarning
+	}
+	if lg.scroll > 0 {
+		state += fmt.Sprintf(" (+%d below)", lg.scroll)
+	}
+	t.Text(W-len([]rune(state))-3, y+1, col, state)
+
+	logY := y + 4
+	logH := max(h-6, 3)
+	t.HLine(cx, logY-1, W-6, ColBorder)
+
+	if len(lg.lines) == 0 {
+		t.Text(cx, logY, ColTextMut, "Waiting for journal output…")
+	}
+	end := len(lg.lines) - lg.scroll
+	start := max(end-logH, 0)
+	for i := start; i < end; i++ {
+		l := lg.lines[i]
+		row := logY + i - start
+		t.Text(cx, row, ColTextMut, fmt.Sprintf("%-8s ", l.Time))
+		t.Text(cx+9, row, logColor(l.Priority), pad(l.Message, W-cx-12))
+	}
+
+	t.Text(cx, logY+logH+1, ColTextMut, "↑↓ PgUp PgDn scroll  Home/End  p pause  c clear")
+}
+
+func (a *App) handleLogs(key KeyEvent) {
+	lg := &a.logs
+	page := max(a.term.Height()-12, 1)
+	top := max(len(lg.lines)-1, 0)
+	switch key.Type {
+	case KeyUp:
+		lg.scroll = min(lg.scroll+1, top)
+	case KeyDown:
+		lg.scroll = max(lg.scroll-1, 0)
+	case KeyPgUp:
+		lg.scroll = min(lg.scroll+page, top)
+	case KeyPgDn:
+		lg.scroll = max(lg.scroll-page, 0)
+	case KeyHome:
+		lg.scroll = top
+	case KeyEnd:
+		lg.scroll = 0
+	case KeyChar:
+		switch key.Char {
+		case 'p', ' ':
+			lg.paused = !lg.paused
+			if lg.paused {
+				a.SetStatus("Log paused", true)
+			} else {
+				lg.scroll = 0
+				a.SetStatus("Log following", true)
+			}
+		case 'c':
+			lg.lines, lg.scroll = nil, 0
+			a.SetStatus("Log cleared", true)
+		}
+	}
+}
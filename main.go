@@ -1,6 +1,7 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"os"
 	"os/signal"
@@ -20,8 +21,60 @@ func fullVersion() string {
 }
 
 func main() {
+	demo := flag.Bool("demo", false, "run against a simulated laptop (same as --backend mock)")
+	backendName := flag.String("backend", "asusctl", "backend provider: asusctl or mock")
+	configPath := flag.String("config", defaultConfigPath(), "path to config.toml")
+	asusctlBin := flag.String("asusctl-bin", "", "asusctl executable to run (default: $"+asusctlBinEnv+" or asusctl on PATH)")
+	timeout := flag.Duration("timeout", 0, "asusctl command timeout, e.g. 10s (overrides command_timeout)")
+	debug := flag.Bool("debug", false, "trace every command with its duration, exit status and output to $XDG_STATE_HOME/asusctl-tui/debug.log")
+	daemon := flag.Bool("daemon", false, "run quiet hours and display rules without the UI")
+	force := flag.Bool("force", false, "write settings even when unchanged since the last write (by default repeats are skipped to spare firmware NVRAM)")
+	quick := flag.Bool("quick", false, "show only the quick panel (profile, keyboard, aura, charge limit); Esc closes")
+	scenario := flag.String("scenario", "", "play a scripted scenario on the simulated laptop (implies --demo): "+mockScenarioNames())
+	flag.Parse()
+
+	cfg, err := LoadConfig(*configPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "config: %v\n", err)
+		os.Exit(2)
+	}
+
+	if *debug {
+		tracer, err = openDebugLog(debugLogPath())
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "debug log: %v\n", err)
+			os.Exit(2)
+		}
+		defer tracer.Close()
+	}
+
+	if *demo {
+		*backendName = "mock"
+	}
+	policy, _ := cfg.CommandPolicy() // validated by LoadConfig
+	if *timeout > 0 {
+		policy.Timeout = *timeout
+	}
+	var backend Backend
+	if *scenario != "" {
+		backend, err = NewMockScenarioBackend(*scenario, nil)
+	} else {
+		backend, err = NewBackendByName(*backendName, BackendOptions{Policy: policy, AsusctlBin: *asusctlBin, Force: *force})
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	if args := flag.Args(); len(args) > 0 {
+		os.Exit(runCommand(backend, cfg, args))
+	}
+
+	if *daemon {
+		os.Exit(runDaemon(backend, cfg))
+	}
+
 	term := NewTerminal()
-	backend := NewBackend()
 
 	if err := term.EnterRaw(); err != nil {
 		fmt.Fprintf(os.Stderr, "Failed to enter raw mode: %v\n", err)
@@ -32,20 +85,22 @@ func main() {
 	// Ensure cleanup on any exit
 	defer term.ExitRaw()
 
-	// Handle SIGINT/SIGTERM gracefully
+	app := NewApp(term, backend, cfg)
+	app.quick = *quick
+
+	// Handle SIGINT/SIGTERM gracefully: stop the loop so Shutdown runs
 	sigCh := make(chan os.Signal, 1)
 	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
+	app.quitSig = sigCh
 	go func() {
 		<-sigCh
-		term.ExitRaw()
-		os.Exit(0)
+		app.Post(func() { app.running = false })
 	}()
 
 	// Handle SIGWINCH (terminal resize)
 	winchCh := make(chan os.Signal, 1)
 	signal.Notify(winchCh, syscall.SIGWINCH)
 
-	app := NewApp(term, backend)
 	app.Init()
 
 	// Initial render
@@ -59,9 +114,26 @@ func main() {
 			term.updateSize()
 			app.Render()
 			continue
+		case fn := <-app.events:
+			fn()
+			app.Render()
+			continue
+		case fn := <-app.queue.Done():
+			fn()
+			app.Render()
+			continue
+		case <-app.redraw:
+			app.Render()
+			continue
 		default:
 		}
 
+		// Mid-animation: draw the next frame unless a key is waiting
+		if term.Animating() && !WaitInput(frameInterval) {
+			app.Render()
+			continue
+		}
+
 		// Read key (with timeout from raw mode settings)
 		key := ReadKey()
 		if key.Type == KeyChar && key.Char == 0 {
@@ -77,4 +149,5 @@ func main() {
 			app.Render()
 		}
 	}
+	app.Shutdown()
 }
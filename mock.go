@@ -0,0 +1,1046 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// MockBackend — simulated laptop for --demo (no asusctl or ASUS hardware needed)
+// ═══════════════════════════════════════════════════════════════════════════════
+
+type MockBackend struct {
+	*mockState
+	rec *commandRecord // per session, see Session
+}
+
+// mockState is the simulated laptop, shared by a MockBackend's sessions.
+type mockState struct {
+	profile       string
+	kbdLevel      string
+	chargeLimit   int
+	oneShot       bool
+	aura          AuraState
+	fanCurves     map[string]*[3][8]int // profile → CPU/GPU/mid pwm percent
+	fanEnabled    bool
+	armoury       map[string]string            // firmware attribute → value
+	ppt           map[string]map[string]string // profile → power limit → value, kept per profile like asusd
+	kbdSleepLight bool
+	auraPower     []AuraPowerLed
+	anime         bool
+	slash         SlashState
+	platformLeds  []PlatformLed
+	touchpad      bool
+	superKey      bool
+	gfxMode       string
+	gfxPending    string
+	daemon        string // systemctl is-active asusd
+	throttles     uint64 // simulated core throttle count
+	panelRate     int    // index into mockPanelRates
+	screenpad     ScreenPadState
+	sim           *mockSim // --scenario script, nil for the plain demo
+
+	mu sync.Mutex // guards all of the above; the pollers and the queue call in concurrently
+}
+
+func NewMockBackend() *MockBackend {
+	m := &MockBackend{rec: &commandRecord{}, mockState: &mockState{
+		profile:     "Balanced",
+		kbdLevel:    "med",
+		chargeLimit: 80,
+		aura: AuraState{
+			Mode: "Breathe",
+			R1:   255, G1: 0, B1: 0,
+			R2: 0, G2: 255, B2: 255,
+			Speed: "Med", Direction: "Right",
+		},
+		fanCurves: map[string]*[3][8]int{},
+		armoury: map[string]string{
+			"panel_od":      "0",
+			"gpu_mux_mode":  "0",
+			"mcu_powersave": "1",
+			"mini_led_mode": "1",
+			"boot_sound":    "0",
+		},
+		ppt: map[string]map[string]string{
+			"Performance": {"ppt_pl1_spl": "80", "ppt_pl2_sppt": "115", "ppt_fppt": "115", "nv_dynamic_boost": "25", "nv_temp_target": "87"},
+			"Balanced":    {"ppt_pl1_spl": "55", "ppt_pl2_sppt": "80", "ppt_fppt": "90", "nv_dynamic_boost": "15", "nv_temp_target": "87"},
+			"Quiet":       {"ppt_pl1_spl": "25", "ppt_pl2_sppt": "35", "ppt_fppt": "45", "nv_dynamic_boost": "5", "nv_temp_target": "80"},
+		},
+		kbdSleepLight: true,
+		auraPower: []AuraPowerLed{
+			{Zone: "lightbar", Label: "Lightbar", On: true},
+			{Zone: "logo", Label: "ROG logo", On: true},
+			{Zone: "lid", Label: "Lid LEDs", On: true},
+		},
+		slash:     SlashState{Present: true, Enabled: true, Brightness: 180, Interval: 2, Mode: "Bounce"},
+		touchpad:  true,
+		superKey:  true,
+		gfxMode:   "Hybrid",
+		screenpad: ScreenPadState{Present: true, On: true, Brightness: 150, Max: 255, Writable: true},
+		daemon:    "active",
+		platformLeds: []PlatformLed{
+			{ID: "camera", Label: "Camera enabled", On: true, Writable: true},
+			{ID: "micmute_led", Label: "Mic mute LED", On: false, Writable: false},
+		},
+	}}
+	for _, p := range []string{"Performance", "Balanced", "Quiet"} {
+		m.fanCurves[p] = &[3][8]int{
+			{0, 5, 10, 20, 35, 55, 65, 65},
+			{0, 5, 10, 15, 30, 50, 60, 60},
+			{0, 5, 10, 15, 25, 40, 50, 55},
+		}
+	}
+	m.fanCurves["Performance"][0] = fanPresets["performance"]
+	m.fanCurves["Quiet"][0] = fanPresets["silent"]
+	return m
+}
+
+func (m *MockBackend) IsInstalled() bool { return true }
+func (m *MockBackend) Version() string   { return "asusctl v6.1.0 (demo)" }
+
+// ─── Profile ─────────────────────────────────────────────────────────────────
+
+var mockProfiles = []string{"Performance", "Balanced", "Quiet"}
+
+// cmd records the asusctl 6.x command equivalent to a simulated call.
+func (m *MockBackend) cmd(op string, params ...string) {
+	m.record(append([]string{"asusctl"}, argsFor(latestCliMajor, op, params...)...)...)
+}
+
+// record stores the argv (binary first) for LastCommand.
+func (m *MockBackend) record(argv ...string) {
+	m.rec.set(argv)
+}
+
+func (m *MockBackend) GetProfileInfo() (ProfileInfo, error) {
+	return ParseProfileInfo("Active profile is " + m.GetProfile())
+}
+
+func (m *MockBackend) GetProfile() string {
+	m.cmd("profile.get")
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.profile
+}
+
+func (m *MockBackend) SetProfile(p string) (bool, string) {
+	m.cmd("profile.set", p)
+	if ok, out := m.daemonFailure(); !ok {
+		return ok, out
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, name := range mockProfiles {
+		if strings.EqualFold(name, p) {
+			m.profile = name
+			return true, ""
+		}
+	}
+	return false, "Error: invalid profile " + p
+}
+
+func (m *MockBackend) NextProfile() (bool, string) {
+	m.cmd("profile.next")
+	if ok, out := m.daemonFailure(); !ok {
+		return ok, out
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i, name := range mockProfiles {
+		if name == m.profile {
+			m.profile = mockProfiles[(i+1)%len(mockProfiles)]
+			break
+		}
+	}
+	return true, m.profile
+}
+
+func (m *MockBackend) ProfileSource() string { return "asusd" }
+
+func (m *MockBackend) ListProfiles() (bool, string) {
+	m.cmd("profile.list")
+	return true, strings.Join(mockProfiles, "\n")
+}
+
+// ─── Keyboard Brightness ─────────────────────────────────────────────────────
+
+func (m *MockBackend) GetLedState() (LedState, error) {
+	return ParseLedState("Current keyboard led brightness: " + m.GetKbdBrightness())
+}
+
+func (m *MockBackend) GetKbdBrightness() string {
+	m.cmd("leds.get")
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.kbdLevel
+}
+
+func (m *MockBackend) SetKbdBrightness(level string) (bool, string) {
+	m.cmd("leds.set", level)
+	if ok, out := m.daemonFailure(); !ok {
+		return ok, out
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, v := range kbdValues {
+		if v == level {
+			m.kbdLevel = level
+			return true, ""
+		}
+	}
+	return false, "Error: invalid brightness " + level
+}
+
+func (m *MockBackend) stepKbd(delta int) (bool, string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i, v := range kbdValues {
+		if v == m.kbdLevel {
+			m.kbdLevel = kbdValues[clamp(i+delta, 0, len(kbdValues)-1)]
+			break
+		}
+	}
+	return true, ""
+}
+
+func (m *MockBackend) SetKbdSleepLighting(on bool) (bool, string) {
+	m.cmd("aura.power.sleep", strconv.FormatBool(on))
+	m.mu.Lock()
+	m.kbdSleepLight = on
+	m.mu.Unlock()
+	return true, ""
+}
+
+// GetAuraPower gives the demo a lightbar, logo and lid LEDs.
+func (m *MockBackend) GetAuraPower() []AuraPowerLed {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]AuraPowerLed(nil), m.auraPower...)
+}
+
+func (m *MockBackend) SetAuraPower(zone string, on bool) (bool, string) {
+	m.cmd("aura.power.awake", zone, strconv.FormatBool(on))
+	if ok, out := m.daemonFailure(); !ok {
+		return ok, out
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i := range m.auraPower {
+		if m.auraPower[i].Zone == zone {
+			m.auraPower[i].On = on
+			return true, ""
+		}
+	}
+	return false, zone + " is not on this laptop"
+}
+
+func (m *MockBackend) NextKbdBrightness() (bool, string) {
+	m.cmd("leds.next")
+	return m.stepKbd(1)
+}
+
+func (m *MockBackend) PrevKbdBrightness() (bool, string) {
+	m.cmd("leds.prev")
+	return m.stepKbd(-1)
+}
+
+// ─── Battery ─────────────────────────────────────────────────────────────────
+
+func (m *MockBackend) GetBatteryInfo() (BatteryInfo, error) {
+	return ParseBatteryInfo(fmt.Sprintf("Current battery charge limit: %d%%", m.GetChargeLimit()))
+}
+
+// mockDraw is roughly what a ROG laptop pulls per profile at light load.
+var mockDraw = map[string]float64{"Performance": 31, "Balanced": 15, "Quiet": 9.5}
+
+// GetBatteryStatus simulates a 76 Wh battery whose draw follows the
+// profile, with some wobble. It charges while the simulated charger is in.
+func (m *MockBackend) GetBatteryStatus() BatteryStatus {
+	wobble := float64(time.Now().Unix()%7) - 3
+	status := "Discharging"
+	if ac, _ := m.GetACOnline(); ac {
+		status = "Charging"
+	}
+	percent := 64
+	if p := m.simBattery(); p > 0 {
+		percent = p
+	}
+	m.mu.Lock()
+	draw := mockDraw[m.profile]
+	m.mu.Unlock()
+	return BatteryStatus{
+		Present:  true,
+		Status:   status,
+		Percent:  percent,
+		FullWh:   76,
+		DesignWh: 90,
+		PowerW:   draw + wobble/2,
+		VoltageV: 15.62 + wobble/100,
+		Cycles:   143,
+	}
+}
+
+func (m *MockBackend) GetChargeThreshold() (int, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.oneShot {
+		return 100, true
+	}
+	return m.chargeLimit, true
+}
+
+// GetACOnline plugs the simulated charger in every other five minutes,
+// unless a scenario holds it.
+func (m *MockBackend) GetACOnline() (online, ok bool) {
+	m.tick()
+	if ac, set := m.simAC(); set {
+		return ac, true
+	}
+	return time.Now().Unix()/300%2 == 1, true
+}
+
+func (m *MockBackend) GetChargeLimit() int {
+	m.cmd("battery.info")
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.chargeLimit
+}
+
+func (m *MockBackend) SetChargeLimit(pct int) (bool, string) {
+	m.cmd("battery.limit", strconv.Itoa(clamp(pct, 20, 100)))
+	if ok, out := m.daemonFailure(); !ok {
+		return ok, out
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.chargeLimit = clamp(pct, 20, 100)
+	return true, ""
+}
+
+func (m *MockBackend) ToggleOneShotCharge() (bool, string) {
+	m.cmd("battery.oneshot")
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.oneShot = !m.oneShot
+	return true, ""
+}
+
+// ─── Aura RGB ────────────────────────────────────────────────────────────────
+
+func (m *MockBackend) GetAuraState() *AuraState {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	st := m.aura
+	st.Zones = append([]string(nil), m.aura.Zones...)
+	return &st
+}
+
+func (m *MockBackend) SetAuraMode(mode, colour1, colour2, speed, direction string) (bool, string) {
+	args := append([]string{"asusctl"}, argsFor(latestCliMajor, "aura.effect", strings.ToLower(strings.ReplaceAll(mode, " ", "-")))...)
+	for _, f := range [][2]string{{"--colour", colour1}, {"--colour2", colour2}, {"--speed", speed}, {"--direction", direction}} {
+		if f[1] != "" {
+			args = append(args, f[0], f[1])
+		}
+	}
+	m.record(args...)
+	if ok, out := m.daemonFailure(); !ok {
+		return ok, out
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.aura.Mode = strings.ReplaceAll(mode, " ", "")
+	m.aura.Zones = nil
+	if r, g, b, ok := parseHexColour(colour1); ok {
+		m.aura.R1, m.aura.G1, m.aura.B1 = r, g, b
+	}
+	if r, g, b, ok := parseHexColour(colour2); ok {
+		m.aura.R2, m.aura.G2, m.aura.B2 = r, g, b
+	}
+	if speed != "" {
+		m.aura.Speed = strings.ToUpper(speed[:1]) + speed[1:]
+	}
+	if direction != "" {
+		m.aura.Direction = strings.ToUpper(direction[:1]) + direction[1:]
+	}
+	return true, ""
+}
+
+// GetAuraZones gives the demo a 4-zone keyboard.
+func (m *MockBackend) GetAuraZones() int { return maxAuraZones }
+
+// SetAuraZones runs SetAuraMode per zone; the state keeps zone 1's colour
+// and the zones' colours.
+func (m *MockBackend) SetAuraZones(mode string, colours []string, colour2, speed, direction string) (bool, string) {
+	for i := len(colours) - 1; i >= 0; i-- {
+		if ok, out := m.SetAuraMode(mode, colours[i], colour2, speed, direction); !ok {
+			return ok, out
+		}
+		m.rec.set(append(m.rec.get(), "--zone", strconv.Itoa(i+1)))
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.aura.Zones = append([]string(nil), colours...)
+	return true, ""
+}
+
+// SetAuraPerKey refuses like asusctl does until it has a per-key command.
+func (m *MockBackend) SetAuraPerKey(colours []string) (bool, string) {
+	if !perKeySupported() {
+		return false, errNoPerKey
+	}
+	m.cmd(perKeyOp, strings.Join(colours, ","))
+	return m.daemonFailure()
+}
+
+// WriteAuraOffline behaves like SetAuraMode; the demo has no file to edit.
+func (m *MockBackend) WriteAuraOffline(mode, colour1, colour2, speed, direction string) (bool, string) {
+	m.SetAuraMode(mode, colour1, colour2, speed, direction)
+	m.record("install", "-m", "0644", "/tmp/asusctl-tui-1/aura_19b6-1.ron", "/etc/asusd/aura_19b6.ron")
+	return true, ""
+}
+
+func (m *MockBackend) stepAura(delta int) (bool, string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	cur := 0
+	for i, name := range auraModes {
+		if strings.ReplaceAll(name, " ", "") == m.aura.Mode {
+			cur = i
+			break
+		}
+	}
+	next := auraModes[(cur+len(auraModes)+delta)%len(auraModes)]
+	m.aura.Mode = strings.ReplaceAll(next, " ", "")
+	return true, ""
+}
+
+// GetAuraModes leaves out Pulse, which the demo keyboard lacks (see the
+// asusd journal below).
+func (m *MockBackend) GetAuraModes() []string {
+	var modes []string
+	for _, name := range auraModes {
+		if name != "Pulse" {
+			modes = append(modes, name)
+		}
+	}
+	return modes
+}
+
+func (m *MockBackend) NextAuraMode() (bool, string) {
+	m.cmd("aura.next")
+	return m.stepAura(1)
+}
+
+func (m *MockBackend) PrevAuraMode() (bool, string) {
+	m.cmd("aura.prev")
+	return m.stepAura(-1)
+}
+
+// parseHexColour parses "rrggbb" into components.
+func parseHexColour(hex string) (int, int, int, bool) {
+	if len(hex) != 6 {
+		return 0, 0, 0, false
+	}
+	v, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return 0, 0, 0, false
+	}
+	return int(v >> 16 & 0xff), int(v >> 8 & 0xff), int(v & 0xff), true
+}
+
+// ─── Fan Curves ──────────────────────────────────────────────────────────────
+
+// curves is the profile's fan curves; the caller holds m.mu.
+func (m *MockBackend) curves(profile string) *[3][8]int {
+	if c, ok := m.fanCurves[profile]; ok {
+		return c
+	}
+	return m.fanCurves["Balanced"]
+}
+
+func (m *MockBackend) GetFanCurves(profile string) (bool, string) {
+	m.record("asusctl", "fan-curve", "--mod-profile", profile)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	c := m.curves(profile)
+	var sb strings.Builder
+	for i, fan := range []string{"CPU", "GPU", "MID"} {
+		pwm := make([]string, 8)
+		temp := make([]string, 8)
+		for p := 0; p < 8; p++ {
+			pwm[p] = strconv.Itoa((c[i][p]*255 + 50) / 100)
+			temp[p] = strconv.Itoa(30 + p*10)
+		}
+		fmt.Fprintf(&sb, "fan: %s\nenabled: %v\npwm: (%s)\ntemp: (%s)\n",
+			fan, m.fanEnabled, strings.Join(pwm, ", "), strings.Join(temp, ", "))
+	}
+	return true, strings.TrimSpace(sb.String())
+}
+
+func (m *MockBackend) SetFanCurve(fan, profile, data string) (bool, string) {
+	m.record("asusctl", "fan-curve", "--mod-profile", profile, "--fan", fan, "--data", data)
+	if ok, out := m.daemonFailure(); !ok {
+		return ok, out
+	}
+	idx := indexFold(fanNames, fan)
+	if idx < 0 {
+		return false, "Error: no fan named " + fan
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	c := m.curves(profile)
+	points := strings.Split(data, ",")
+	if len(points) != 8 {
+		return false, "Error: fan curve needs 8 points"
+	}
+	for i, pt := range points {
+		parts := strings.SplitN(pt, ":", 2)
+		if len(parts) != 2 {
+			return false, "Error: bad curve point " + pt
+		}
+		v, err := strconv.Atoi(strings.TrimSuffix(parts[1], "%"))
+		if err != nil {
+			return false, "Error: bad curve point " + pt
+		}
+		c[idx][i] = clamp(v, 0, 100)
+	}
+	return true, ""
+}
+
+func (m *MockBackend) EnableFanCurves(profile string, enable bool) (bool, string) {
+	m.record("asusctl", "fan-curve", "--mod-profile", profile, "--enable-fan-curves", strconv.FormatBool(enable))
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.fanEnabled = enable
+	return true, ""
+}
+
+func (m *MockBackend) GetFanEnabled() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.fanEnabled
+}
+
+func (m *MockBackend) ReadFanCurves(profile string) (FanCurves, error) {
+	_, out := m.GetFanCurves(profile)
+	return ParseFanCurves(out)
+}
+
+// ─── BIOS ────────────────────────────────────────────────────────────────────
+
+// mockArmouryRanges are the min/max of the simulated numeric attributes;
+// the others are 0/1.
+var mockArmouryRanges = map[string][2]int{
+	"ppt_pl1_spl": {15, 80}, "ppt_pl2_sppt": {15, 115}, "ppt_fppt": {15, 115},
+	"nv_dynamic_boost": {5, 25}, "nv_temp_target": {75, 87},
+}
+
+// armouryAttrs returns the attributes as the active profile sees them; the
+// caller holds m.mu.
+func (m *MockBackend) armouryAttrs() map[string]string {
+	attrs := map[string]string{}
+	for k, v := range m.armoury {
+		attrs[k] = v
+	}
+	for k, v := range m.ppt[m.profile] {
+		attrs[k] = v
+	}
+	return attrs
+}
+
+// ListArmoury prints the attributes the way asusctl 6 does, sorted by name.
+func (m *MockBackend) ListArmoury() (bool, string) {
+	m.record("asusctl", "armoury", "list")
+	m.mu.Lock()
+	attrs := m.armouryAttrs()
+	m.mu.Unlock()
+	var names []string
+	for name := range attrs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var sb strings.Builder
+	for _, name := range names {
+		v := attrs[name]
+		if r, ok := mockArmouryRanges[name]; ok {
+			fmt.Fprintf(&sb, "%s: %s, min: %d, max: %d\n", name, v, r[0], r[1])
+			continue
+		}
+		opts := []string{"0", "1"}
+		for i, o := range opts {
+			if o == v {
+				opts[i] = "(" + o + ")"
+			}
+		}
+		fmt.Fprintf(&sb, "%s: [%s]\n", name, strings.Join(opts, ","))
+	}
+	return true, sb.String()
+}
+
+func (m *MockBackend) GetArmoury(attr string) (bool, string) {
+	m.record("asusctl", "armoury", "get", attr)
+	m.mu.Lock()
+	v, ok := m.armouryAttrs()[attr]
+	m.mu.Unlock()
+	if !ok {
+		return false, "Error: attribute " + attr + " not supported"
+	}
+	return true, attr + ": " + v
+}
+
+func (m *MockBackend) SetArmoury(attr, value string) (bool, string) {
+	m.record("asusctl", "armoury", "set", attr, value)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.ppt[m.profile][attr]; ok {
+		m.ppt[m.profile][attr] = value
+		return true, ""
+	}
+	if _, ok := m.armoury[attr]; !ok {
+		return false, "Error: attribute " + attr + " not supported"
+	}
+	m.armoury[attr] = value
+	return true, ""
+}
+
+func (m *MockBackend) GetPanelOverdrive() (bool, string) { return m.GetArmoury("panel_od") }
+
+func (m *MockBackend) SetPanelOverdrive(on bool) (bool, string) {
+	return m.SetArmoury("panel_od", strconv.Itoa(boolInt(on)))
+}
+
+func (m *MockBackend) GetGpuMux() (bool, string) { return m.GetArmoury("gpu_mux_mode") }
+
+func (m *MockBackend) SetGpuMux(dedicated bool) (bool, string) {
+	return m.SetArmoury("gpu_mux_mode", strconv.Itoa(boolInt(dedicated)))
+}
+
+func boolInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// ─── Anime / Slash ───────────────────────────────────────────────────────────
+
+func (m *MockBackend) SetAnimeEnable(on bool) (bool, string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.anime = on
+	return true, ""
+}
+
+func (m *MockBackend) SetAnimeImage(path string) (bool, string) {
+	m.record("asusctl", "anime", "pixel-image", "--path", path)
+	return true, ""
+}
+
+func (m *MockBackend) ClearAnime() (bool, string) {
+	m.record("asusctl", "anime", "clear")
+	return true, ""
+}
+
+func (m *MockBackend) SetSlashEnable(on bool) (bool, string) {
+	if on {
+		m.record("asusctl", "slash", "--enable")
+	} else {
+		m.record("asusctl", "slash", "--disable")
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.slash.Enabled = on
+	return true, ""
+}
+
+func (m *MockBackend) GetSlashState() SlashState {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.slash
+}
+
+func (m *MockBackend) SetSlashBrightness(level int) (bool, string) {
+	m.record("asusctl", "slash", "--brightness", strconv.Itoa(level))
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.slash.Brightness = clamp(level, 0, slashBrightnessMax)
+	return true, ""
+}
+
+func (m *MockBackend) SetSlashInterval(interval int) (bool, string) {
+	m.record("asusctl", "slash", "--interval", strconv.Itoa(interval))
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.slash.Interval = clamp(interval, 0, slashIntervalMax)
+	return true, ""
+}
+
+func (m *MockBackend) SetSlashMode(mode string) (bool, string) {
+	m.record("asusctl", "slash", "--mode", mode)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, name := range defaultSlashModes {
+		if name == mode {
+			m.slash.Mode = mode
+			return true, ""
+		}
+	}
+	return false, "Error: invalid slash mode " + mode
+}
+
+func (m *MockBackend) SlashHelp() (bool, string) {
+	return true, "Usage: asusctl slash [OPTIONS]\n\n" +
+		"Options:\n" +
+		"  -e, --enable                     Enable the Slash Ledbar\n" +
+		"  -d, --disable                    Disable the Slash Ledbar\n" +
+		"  -l, --brightness <BRIGHTNESS>    Set brightness value\n" +
+		"  -i, --interval <INTERVAL>        Set interval value\n" +
+		"  -m, --mode <MODE>                Set SlashMode [possible values: " + strings.Join(defaultSlashModes, ", ") + "]\n"
+}
+
+// ─── Platform ────────────────────────────────────────────────────────────────
+
+func (m *MockBackend) GetPlatformLeds() []PlatformLed {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]PlatformLed(nil), m.platformLeds...)
+}
+
+func (m *MockBackend) SetPlatformLed(id string, on bool) (bool, string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i := range m.platformLeds {
+		if m.platformLeds[i].ID == id {
+			if !m.platformLeds[i].Writable {
+				return false, "open /sys/class/leds/platform::micmute/brightness: permission denied"
+			}
+			m.platformLeds[i].On = on
+			return true, ""
+		}
+	}
+	return false, id + " is not supported on this laptop"
+}
+
+func (m *MockBackend) GetTouchpad() TouchpadState {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return TouchpadState{Present: true, Enabled: m.touchpad, Writable: true}
+}
+
+func (m *MockBackend) SetTouchpad(enabled bool) (bool, string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.touchpad = enabled
+	return true, ""
+}
+
+// ─── GPU (supergfxctl) ───────────────────────────────────────────────────────
+
+func (m *MockBackend) GfxInstalled() bool { return true }
+
+func (m *MockBackend) GetGfxMode() (bool, string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return true, m.gfxMode
+}
+
+func (m *MockBackend) GetGfxSupported() (bool, string) {
+	return true, "[Integrated, Hybrid, AsusMuxDgpu]"
+}
+
+func (m *MockBackend) GetGfxPower() (bool, string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.gfxMode == "Integrated" {
+		return true, "off"
+	}
+	return true, "suspended"
+}
+
+func (m *MockBackend) GetGfxPending() (bool, string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.gfxPending == "" {
+		return true, "No action required"
+	}
+	return true, m.gfxPending
+}
+
+// SetGfxMode switches immediately but reports the action real hardware needs.
+func (m *MockBackend) SetGfxMode(mode string) (bool, string) {
+	m.record("supergfxctl", "--mode", mode)
+	if len(parseGfxModes(mode)) != 1 || mode == "Vfio" || mode == "AsusEgpu" {
+		return false, "Error: mode " + mode + " is not supported"
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.gfxMode = mode
+	m.gfxPending = "Logout"
+	if mode == "AsusMuxDgpu" {
+		m.gfxPending = "Reboot"
+	}
+	return true, "Graphics mode changed to " + mode + ". Required user action is: " + m.gfxPending
+}
+
+// ─── Hotkeys ─────────────────────────────────────────────────────────────────
+
+func (m *MockBackend) SetSuperKey(enabled bool) (bool, string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.superKey = enabled
+	return true, ""
+}
+
+// WatchRogKey never fires: the demo has no ROG key to press.
+func (m *MockBackend) WatchRogKey(onPress func()) (func(), error) {
+	return func() {}, nil
+}
+
+// ─── Supported ───────────────────────────────────────────────────────────────
+
+func (m *MockBackend) GetSupported() (bool, string) {
+	return true, "Demo laptop (simulated)\nSupported: profiles, keyboard brightness, aura, battery limit, fan curves, armoury"
+}
+
+// ─── Raw ─────────────────────────────────────────────────────────────────────
+
+// RunRaw understands the common read/write subcommands so the Console tab
+// behaves plausibly; anything else is echoed back.
+func (m *MockBackend) RunRaw(args string) (bool, string) {
+	parts := strings.Fields(args)
+	if len(parts) == 0 {
+		return false, "no arguments"
+	}
+	defer m.record(append([]string{"asusctl"}, parts...)...)
+	arg := func(i int) string {
+		if i < len(parts) {
+			return parts[i]
+		}
+		return ""
+	}
+	switch parts[0] {
+	case "profile":
+		switch arg(1) {
+		case "get":
+			return true, "Active profile is " + m.GetProfile()
+		case "set":
+			return m.SetProfile(arg(2))
+		case "next":
+			return m.NextProfile()
+		case "list":
+			return m.ListProfiles()
+		}
+	case "leds":
+		switch arg(1) {
+		case "get":
+			return true, "Current keyboard led brightness: " + m.GetKbdBrightness()
+		case "set":
+			return m.SetKbdBrightness(arg(2))
+		case "next":
+			return m.NextKbdBrightness()
+		case "prev":
+			return m.PrevKbdBrightness()
+		}
+	case "battery":
+		switch arg(1) {
+		case "info":
+			return true, fmt.Sprintf("Current battery charge limit: %d%%", m.GetChargeLimit())
+		case "limit":
+			v, err := strconv.Atoi(arg(2))
+			if err != nil {
+				return false, "Error: invalid limit " + arg(2)
+			}
+			return m.SetChargeLimit(v)
+		case "oneshot":
+			return m.ToggleOneShotCharge()
+		}
+	case "fan-curve":
+		return m.GetFanCurves(m.GetProfile())
+	case "info":
+		return m.GetSupported()
+	}
+	return true, "[demo] asusctl " + args
+}
+
+// Nothing changes behind the TUI's back in demo mode.
+func (m *MockBackend) WatchChanges(onChange func(ChangeArea)) error { return nil }
+
+func (m *MockBackend) LastCommand() []string { return m.rec.get() }
+
+func (m *MockBackend) Session() Backend {
+	return &MockBackend{mockState: m.mockState, rec: &commandRecord{}}
+}
+
+// The simulated daemon never refuses a command, so there is nothing to
+// elevate; RunElevated just behaves like RunRaw.
+func (m *MockBackend) RunElevated(argv ...string) (bool, string) {
+	if len(argv) == 0 {
+		return false, "no command to elevate"
+	}
+	return m.RunRaw(strings.Join(argv[1:], " "))
+}
+
+func (m *MockBackend) DaemonStatus() string {
+	m.tick()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.daemon
+}
+
+func (m *MockBackend) RestartDaemon() (bool, string) {
+	m.record("systemctl", "--no-ask-password", "restart", asusdUnit)
+	m.mu.Lock()
+	m.daemon = "active"
+	m.mu.Unlock()
+	return true, ""
+}
+
+// ─── Logs ────────────────────────────────────────────────────────────────────
+
+var mockLogLines = []LogLine{
+	{Priority: logPrioInfo, Message: "[INFO  asusd] Starting version 6.0.12"},
+	{Priority: logPrioInfo, Message: "[INFO  asusd::ctrl_platform] Found platform interface"},
+	{Priority: logPrioWarning, Message: "[WARN  asusd::aura_laptop] Mode Pulse is not supported by this keyboard"},
+	{Priority: logPrioInfo, Message: "[INFO  asusd::ctrl_fancurves] Applied fan curve for Balanced"},
+	{Priority: logPrioErr, Message: "[ERROR asusd::ctrl_fancurves] Fan curve for GPU rejected: point 8 below point 7"},
+}
+
+// FollowLogs replays canned asusd lines, then adds one every few seconds
+// along with any scenario steps that fired.
+func (m *MockBackend) FollowLogs(onLine func(LogLine)) (func(), error) {
+	done := make(chan struct{})
+	go func() {
+		for _, l := range mockLogLines[:3] {
+			l.Time = time.Now().Format("15:04:05")
+			onLine(l)
+		}
+		tick := time.NewTicker(3 * time.Second)
+		defer tick.Stop()
+		for i := 3; ; i++ {
+			select {
+			case <-done:
+				return
+			case now := <-tick.C:
+				m.tick()
+				for _, l := range m.simLogs() {
+					onLine(l)
+				}
+				l := mockLogLines[i%len(mockLogLines)]
+				l.Time = now.Format("15:04:05")
+				onLine(l)
+			}
+		}
+	}()
+	var once sync.Once
+	return func() { once.Do(func() { close(done) }) }, nil
+}
+
+// GetThermalState runs warmer under Performance and throttles now and then,
+// and on every read once a scenario has pushed it past 95 °C.
+func (m *MockBackend) GetThermalState() ThermalState {
+	m.tick()
+	heat := m.simHeat()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	temp := map[string]float64{"Performance": 88, "Balanced": 74, "Quiet": 63}[m.profile] + heat
+	sec := time.Now().Unix()
+	if m.profile == "Performance" && sec%30 < 2 {
+		m.throttles += uint64(1 + sec%5)
+	}
+	if temp >= 95 {
+		m.throttles += uint64(2 + sec%3)
+	}
+	return ThermalState{Supported: true, Core: m.throttles, TempC: temp + float64(sec%5)}
+}
+
+// GetDisplays plugs a simulated HDMI monitor in every other minute.
+func (m *MockBackend) GetDisplays() []string {
+	if time.Now().Unix()/60%2 == 1 {
+		return []string{"HDMI-A-1"}
+	}
+	return nil
+}
+
+// WatchDisplays has no events to send; the automation poll sees the changes.
+func (m *MockBackend) WatchDisplays(onChange func()) error { return nil }
+
+var mockPanelRates = []float64{240, 120, 60}
+
+func (m *MockBackend) GetPanelRates() (PanelRates, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return PanelRates{Tool: "xrandr", Connector: "eDP-1", Mode: "2560x1600", Rates: mockPanelRates, Current: m.panelRate}, nil
+}
+
+func (m *MockBackend) SetPanelRate(p PanelRates, i int) (bool, string) {
+	m.record("xrandr", "--output", p.Connector, "--mode", p.Mode, "--rate", strconv.FormatFloat(p.Rates[i], 'f', 2, 64))
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.panelRate = i
+	return true, ""
+}
+
+func (m *MockBackend) GetScreenPad() ScreenPadState {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.screenpad
+}
+
+func (m *MockBackend) SetScreenPadBrightness(level int) (bool, string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.screenpad.Brightness = clamp(level, 0, m.screenpad.Max)
+	return true, ""
+}
+
+func (m *MockBackend) SetScreenPadPower(on bool) (bool, string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.screenpad.On = on
+	return true, ""
+}
+
+// ReadSensors spins the simulated fans faster under Performance.
+func (m *MockBackend) ReadSensors() SensorReading {
+	m.tick()
+	heat := m.simHeat()
+	m.mu.Lock()
+	profile, gfxMode := m.profile, m.gfxMode
+	m.mu.Unlock()
+	base := map[string]int{"Performance": 4200, "Balanced": 2800, "Quiet": 1600}[profile]
+	temp := map[string]float64{"Performance": 88, "Balanced": 74, "Quiet": 63}[profile] + heat
+	jitter := int(time.Now().Unix() % 5)
+	r := SensorReading{
+		CPUTempC: temp + float64(jitter),
+		GPUTempC: temp - 8 + float64(jitter),
+		Fans: []FanReading{
+			{Label: "cpu_fan", RPM: base + jitter*40},
+			{Label: "gpu_fan", RPM: base - 300 + jitter*40},
+			{Label: "mid_fan", RPM: base - 600 + jitter*40},
+		},
+		DGPU: GpuTelemetry{Present: true, Asleep: gfxMode == "Integrated"},
+	}
+	if !r.DGPU.Asleep {
+		r.DGPU.TempC = r.GPUTempC
+		r.DGPU.PowerW = map[string]float64{"Performance": 95, "Balanced": 40, "Quiet": 12}[profile] + float64(jitter)
+		r.DGPU.Util = 20 + jitter*10
+	}
+	return r
+}
+
+// InhibitSleep holds nothing; the demo cannot suspend the machine.
+func (m *MockBackend) InhibitSleep(why string) (func(), error) {
+	m.record("systemd-inhibit", "--what=sleep:idle", "--why="+why)
+	return func() {}, nil
+}
+
+// WatchGames reports no games; the demo has nothing to launch.
+func (m *MockBackend) WatchGames(onChange func(games int)) error { return nil }
+
+// GetWifiSSID reports a fixed home network.
+func (m *MockBackend) GetWifiSSID() (string, bool) { return "demo-home", true }
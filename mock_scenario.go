@@ -0,0 +1,173 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// Mock scenarios — scripted runs of the simulated laptop for --scenario: the
+// charger pulled and the battery draining, temperatures climbing into
+// throttling, asusd going down and coming back. Steps fire at fixed offsets
+// from the start and set rates the reads integrate, so a run behaves the
+// same every time. Tests drive the clock through mockSim.now.
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// MockScenario is a named script of steps.
+type MockScenario struct {
+	Name  string
+	Desc  string
+	Steps []MockStep
+}
+
+// MockStep changes the simulation At a time after the scenario started.
+type MockStep struct {
+	At   time.Duration
+	What string // logged by asusd in the Logs tab when the step fires
+	Do   func(s *mockSim)
+}
+
+var mockScenarios = []MockScenario{
+	{"drain", "charger pulled at 42%, ~4%/min drain, plugged back in at 8 min", []MockStep{
+		{0, "AC adapter removed", func(s *mockSim) { s.setAC(false); s.percent, s.drain = 42, 4 }},
+		{8 * time.Minute, "AC adapter connected", func(s *mockSim) { s.setAC(true); s.drain = -3 }},
+	}},
+	{"heat", "temperatures climb ~3 °C/min into throttling, then cool off at 10 min", []MockStep{
+		{0, "sustained load started", func(s *mockSim) { s.heatRate = 3 }},
+		{10 * time.Minute, "load finished", func(s *mockSim) { s.heatRate = -6 }},
+	}},
+	{"daemon-down", "asusd stops after 20 s and is restarted by systemd after 2 min", []MockStep{
+		{20 * time.Second, "asusd.service: Main process exited, code=killed, status=6/ABRT", func(s *mockSim) { s.m.daemon = "failed" }},
+		{2 * time.Minute, "asusd.service: Scheduled restart job", func(s *mockSim) { s.m.daemon = "active" }},
+	}},
+}
+
+// mockScenarioNames lists the scenarios for --scenario's usage text.
+func mockScenarioNames() string {
+	var names []string
+	for _, s := range mockScenarios {
+		names = append(names, s.Name)
+	}
+	return strings.Join(names, ", ")
+}
+
+// mockSim is the scenario state of a MockBackend. Guarded by MockBackend.mu.
+type mockSim struct {
+	m        *MockBackend
+	scenario MockScenario
+	now      func() time.Time
+	start    time.Time
+	last     time.Time // reads integrate the rates from here
+	next     int       // first step not yet fired
+	fired    []LogLine // steps fired, for FollowLogs
+
+	acSet    bool // ac overrides the five-minute charger cycle
+	ac       bool
+	percent  float64 // battery charge; 0 keeps the demo's fixed 64%
+	drain    float64 // percent per minute, negative while charging
+	heat     float64 // °C added to every temperature
+	heatRate float64 // °C per minute
+}
+
+func (s *mockSim) setAC(on bool) { s.acSet, s.ac = true, on }
+
+// NewMockScenarioBackend is NewMockBackend playing the named scenario.
+// now is the clock, time.Now unless a test steps it.
+func NewMockScenarioBackend(name string, now func() time.Time) (*MockBackend, error) {
+	for _, sc := range mockScenarios {
+		if sc.Name != name {
+			continue
+		}
+		m := NewMockBackend()
+		if now == nil {
+			now = time.Now
+		}
+		t := now()
+		m.sim = &mockSim{m: m, scenario: sc, now: now, start: t, last: t}
+		return m, nil
+	}
+	return nil, fmt.Errorf("unknown scenario %q (available: %s)", name, mockScenarioNames())
+}
+
+// tick brings the scenario up to the current time: rates are integrated
+// over the time since the last read, then due steps fire. Every simulated
+// read of a scripted quantity calls it first.
+func (m *MockBackend) tick() {
+	if m.sim == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s := m.sim
+	now := s.now()
+	mins := now.Sub(s.last).Minutes()
+	s.last = now
+	if s.percent > 0 {
+		s.percent = math.Max(1, math.Min(100, s.percent-s.drain*mins))
+	}
+	s.heat = math.Max(0, math.Min(30, s.heat+s.heatRate*mins))
+	for s.next < len(s.scenario.Steps) && now.Sub(s.start) >= s.scenario.Steps[s.next].At {
+		st := s.scenario.Steps[s.next]
+		st.Do(s)
+		s.fired = append(s.fired, LogLine{Time: now.Format("15:04:05"), Priority: logPrioWarning,
+			Message: "[WARN  scenario " + s.scenario.Name + "] " + st.What})
+		s.next++
+	}
+}
+
+// simAC returns the scenario's charger state, if it sets one.
+func (m *MockBackend) simAC() (online, set bool) {
+	if m.sim == nil {
+		return false, false
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.sim.ac, m.sim.acSet
+}
+
+// simBattery returns the scenario's battery charge, 0 if it sets none.
+func (m *MockBackend) simBattery() int {
+	if m.sim == nil {
+		return 0
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return int(math.Round(m.sim.percent))
+}
+
+// simHeat returns the °C the scenario adds to every temperature.
+func (m *MockBackend) simHeat() float64 {
+	if m.sim == nil {
+		return 0
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.sim.heat
+}
+
+// simLogs returns the scenario steps fired since the last call.
+func (m *MockBackend) simLogs() []LogLine {
+	if m.sim == nil {
+		return nil
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	l := m.sim.fired
+	m.sim.fired = nil
+	return l
+}
+
+// daemonFailure is what asusctl prints when asusd is not on the bus; the
+// simulated setters return it while a scenario has the daemon down.
+func (m *MockBackend) daemonFailure() (bool, string) {
+	m.tick()
+	m.mu.Lock()
+	down := daemonDown(m.daemon)
+	m.mu.Unlock()
+	if down {
+		return false, "Error: org.freedesktop.DBus.Error.ServiceUnknown: The name xyz.ljones.Asusd was not provided by any .service files"
+	}
+	return true, ""
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// scenarioClock is a clock the test steps by hand.
+type scenarioClock struct{ t time.Time }
+
+func (c *scenarioClock) now() time.Time       { return c.t }
+func (c *scenarioClock) step(d time.Duration) { c.t = c.t.Add(d) }
+
+func newScenarioClock() *scenarioClock {
+	return &scenarioClock{t: time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)}
+}
+
+// startScenario is the named scenario on clock c.
+func startScenario(t *testing.T, name string, c *scenarioClock) *MockBackend {
+	t.Helper()
+	m, err := NewMockScenarioBackend(name, c.now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+func TestScenarioDrain(t *testing.T) {
+	c := newScenarioClock()
+	m := startScenario(t, "drain", c)
+	tests := []struct {
+		after   time.Duration
+		ac      bool
+		percent int
+	}{
+		{0, false, 42},
+		{5 * time.Minute, false, 22}, // 4%/min
+		{2 * time.Minute, false, 14}, // 7 min in
+		{time.Minute, true, 10},      // plugged in at 8 min, after draining to it
+		{2 * time.Minute, true, 16},  // charging at 3%/min
+	}
+	for _, tt := range tests {
+		c.step(tt.after)
+		st := m.GetBatteryStatus()
+		if ac, _ := m.GetACOnline(); ac != tt.ac || st.Percent != tt.percent {
+			t.Errorf("%s in: ac %v at %d%%, want %v at %d%%", c.t.Sub(m.sim.start), ac, st.Percent, tt.ac, tt.percent)
+		}
+	}
+}
+
+func TestScenarioHeat(t *testing.T) {
+	c := newScenarioClock()
+	m := startScenario(t, "heat", c)
+	for _, tt := range []struct {
+		after time.Duration
+		heat  float64
+	}{
+		{0, 0},
+		{4 * time.Minute, 12},
+		{6 * time.Minute, 30}, // held at the cap
+		{3 * time.Minute, 12}, // cooling at 6 °C/min since 10 min
+		{10 * time.Minute, 0},
+	} {
+		c.step(tt.after)
+		m.tick()
+		if got := m.simHeat(); got != tt.heat {
+			t.Errorf("%s in: heat %.1f, want %.1f", c.t.Sub(m.sim.start), got, tt.heat)
+		}
+	}
+}
+
+func TestScenarioDaemonDown(t *testing.T) {
+	c := newScenarioClock()
+	m := startScenario(t, "daemon-down", c)
+	c.step(19 * time.Second)
+	if st := m.DaemonStatus(); st != "active" {
+		t.Fatalf("daemon %s before the step", st)
+	}
+	c.step(time.Second)
+	if st := m.DaemonStatus(); st != "failed" {
+		t.Fatalf("daemon %s after the step", st)
+	}
+	if ok, out := m.SetProfile("Quiet"); ok || !strings.Contains(out, "ServiceUnknown") {
+		t.Errorf("SetProfile with asusd down: %v, %q", ok, out)
+	}
+	logs := m.simLogs()
+	if len(logs) != 1 || logs[0].Time != "12:00:20" || !strings.Contains(logs[0].Message, "code=killed") {
+		t.Errorf("logs = %+v", logs)
+	}
+	c.step(2 * time.Minute)
+	if st := m.DaemonStatus(); st != "active" {
+		t.Errorf("daemon %s after the restart", st)
+	}
+	if ok, out := m.SetProfile("Quiet"); !ok {
+		t.Errorf("SetProfile after the restart: %s", out)
+	}
+}
+
+func TestScenarioUnknown(t *testing.T) {
+	if _, err := NewMockScenarioBackend("flood", nil); err == nil || !strings.Contains(err.Error(), "drain, heat, daemon-down") {
+		t.Errorf("err = %v", err)
+	}
+}
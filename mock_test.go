@@ -0,0 +1,42 @@
+package main
+
+import (
+	"sync"
+	"testing"
+)
+
+// TestMockConcurrent drives the mock from several goroutines at once, as
+// the pollers and the command queue do; run with -race.
+func TestMockConcurrent(t *testing.T) {
+	m := NewMockBackend()
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			b := m.Session()
+			for i := 0; i < 50; i++ {
+				b.SetProfile(mockProfiles[(g+i)%len(mockProfiles)])
+				b.GetProfile()
+				b.SetKbdBrightness(kbdValues[i%len(kbdValues)])
+				b.GetKbdBrightness()
+				b.SetChargeLimit(60 + i%40)
+				b.GetChargeLimit()
+				b.GetBatteryStatus()
+				b.GetAuraState()
+				b.SetAuraMode("Static", "ff0000", "", "", "")
+				b.GetFanCurves(b.GetProfile())
+				b.EnableFanCurves(b.GetProfile(), i%2 == 0)
+				b.ListArmoury()
+				b.GetThermalState()
+				b.ReadSensors()
+				b.GetPlatformLeds()
+				b.GetTouchpad()
+				b.SetTouchpad(i%2 == 0)
+				b.GetSlashState()
+				b.RunRaw("profile --profile-get")
+			}
+		}(g)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,159 @@
+package main
+
+import "strings"
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// Modal — dialogs drawn over the active tab: yes/no confirmation and a
+// one-line text prompt
+// ═══════════════════════════════════════════════════════════════════════════════
+
+type Confirm struct {
+	Title string
+	Lines []string
+	OnYes func()
+}
+
+func (a *App) renderConfirm() {
+	c := a.confirm
+	t := a.term
+	W, H := t.Width(), t.Height()
+
+	w := len([]rune(c.Title)) + 6
+	for _, l := range c.Lines {
+		w = max(w, len([]rune(l))+6)
+	}
+	w = min(w, W-4)
+	h := len(c.Lines) + 6
+	x := (W - w) / 2
+	y := (H - h) / 2
+
+	t.ResetStyle()
+	t.FillRect(x, y, w, h, ColCard)
+	t.Bg(ColCard)
+	t.DrawBox(x, y, w, h, ColWarning)
+
+	t.ResetStyle()
+	t.Bg(ColCard)
+	t.Bold()
+	t.Fg(ColWarning)
+	t.MoveTo(x+2, y+1)
+	t.Write(pad(c.Title, w-4))
+
+	for i, l := range c.Lines {
+		t.ResetStyle()
+		t.Bg(ColCard)
+		t.Fg(ColText)
+		t.MoveTo(x+2, y+3+i)
+		t.Write(pad(l, w-4))
+	}
+
+	t.DrawButton(x+2, y+h-2, "y: Yes", true, t.Accent())
+	t.DrawButton(x+12, y+h-2, "n: No", false, t.Accent())
+	t.ResetStyle()
+}
+
+// ask shows c, or runs c.OnYes straight away when confirm_prompts is off.
+// Only for "are you sure?" checks: offers of something else to try
+// (pkexec, restarting asusd, suggested fan curves) always set a.confirm
+// themselves.
+func (a *App) ask(c *Confirm) {
+	if !a.cfg.ConfirmPrompts {
+		c.OnYes()
+		return
+	}
+	a.confirm = c
+}
+
+// handleConfirm consumes every key while a dialog is open.
+func (a *App) handleConfirm(key KeyEvent) {
+	c := a.confirm
+	switch key.Type {
+	case KeyEnter:
+		a.confirm = nil
+		c.OnYes()
+	case KeyEscape, KeyCtrlC:
+		a.confirm = nil
+		a.SetStatus("Cancelled", true)
+	case KeyChar:
+		switch key.Char {
+		case 'y', 'Y':
+			a.confirm = nil
+			c.OnYes()
+		case 'n', 'N', 'q':
+			a.confirm = nil
+			a.SetStatus("Cancelled", true)
+		}
+	}
+}
+
+// ─── Prompt ──────────────────────────────────────────────────────────────────
+
+// Prompt asks for one line of text, e.g. a name to save something under.
+type Prompt struct {
+	Title string
+	Label string
+	Input LineEdit
+	OnOk  func(text string) // not called for empty input
+	// Swatch, when set, previews the colour the input names beside it;
+	// ok is false while the input is not a colour
+	Swatch func(text string) (c Color, ok bool)
+}
+
+func (a *App) renderPrompt() {
+	p := a.prompt
+	t := a.term
+	W, H := t.Width(), t.Height()
+
+	w := min(max(len([]rune(p.Title)), len([]rune(p.Label)))+6, W-4)
+	w = max(w, min(44, W-4))
+	h := 7
+	x := (W - w) / 2
+	y := (H - h) / 2
+
+	t.ResetStyle()
+	t.FillRect(x, y, w, h, ColCard)
+	t.Bg(ColCard)
+	t.DrawBox(x, y, w, h, t.Accent())
+
+	t.ResetStyle()
+	t.Bg(ColCard)
+	t.Bold()
+	t.Fg(ColText)
+	t.MoveTo(x+2, y+1)
+	t.Write(pad(p.Title, w-4))
+	if p.Label != "" {
+		t.TextBg(x+2, y+2, ColTextDim, ColCard, pad(p.Label, w-4))
+	}
+	if p.Swatch == nil {
+		p.Input.Render(t, x+2, y+3, w-4, true)
+	} else {
+		p.Input.Render(t, x+2, y+3, w-10, true)
+		if c, ok := p.Swatch(strings.TrimSpace(p.Input.String())); ok {
+			t.TextBg(x+w-7, y+3, ColText, c, "    ")
+		} else {
+			t.TextBg(x+w-7, y+3, ColTextMut, ColCard, " ?? ")
+		}
+	}
+	t.TextBg(x+2, y+5, ColTextMut, ColCard, pad("Enter save  Esc cancel", w-4))
+	t.ResetStyle()
+}
+
+// handlePrompt consumes every key while a prompt is open.
+func (a *App) handlePrompt(key KeyEvent) {
+	p := a.prompt
+	if p.Input.HandleKey(key) {
+		return
+	}
+	switch key.Type {
+	case KeyEnter:
+		text := strings.TrimSpace(p.Input.String())
+		if text == "" {
+			return
+		}
+		a.prompt = nil
+		p.OnOk(text)
+	case KeyEscape, KeyCtrlC:
+		a.prompt = nil
+		a.SetStatus("Cancelled", true)
+	}
+}
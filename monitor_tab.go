@@ -0,0 +1,324 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// Page: Monitor
+// ═══════════════════════════════════════════════════════════════════════════════
+
+const (
+	thermalSampleEvery = 2 * time.Second
+	maxThrottleEvents  = 200
+	// defaultThrottleC stands in for the throttle temperature when no event
+	// recorded one
+	defaultThrottleC = 85
+)
+
+// throttleEvent is one rise of the throttle counters, with what was active
+// at the time so curve changes can be compared against throttling.
+type throttleEvent struct {
+	Time    string
+	Kind    string // "core", "package" or "core+package"
+	Count   uint64 // new events since the previous sample
+	TempC   float64
+	Profile string
+	Curve   string // "custom" or "firmware"
+}
+
+type monitorState struct {
+	thermal ThermalState // latest reading
+	primed  bool         // thermal holds a reading to diff against
+	events  []throttleEvent
+	scroll  int
+	stop    chan struct{}
+
+	// A recording session keeps the laptop awake; zero when none runs
+	session       time.Time
+	sessionEvents int // throttle events recorded during the session
+}
+
+// startMonitor samples the throttle counters in the background. It runs
+// from startup, so events are caught whichever tab is open.
+func (a *App) startMonitor() {
+	a.monitor.stop = make(chan struct{})
+	stop := a.monitor.stop
+	go func() {
+		tick := time.NewTicker(thermalSampleEvery)
+		defer tick.Stop()
+		for {
+			st := a.backend.GetThermalState()
+			a.Post(func() { a.recordThermal(st) })
+			select {
+			case <-stop:
+				return
+			case <-tick.C:
+			}
+		}
+	}()
+}
+
+func (a *App) stopMonitor() {
+	if a.monitor.stop != nil {
+		close(a.monitor.stop)
+		a.monitor.stop = nil
+	}
+}
+
+// recordThermal logs an event when either counter went up.
+func (a *App) recordThermal(st ThermalState) {
+	m := &a.monitor
+	prev, primed := m.thermal, m.primed
+	m.thermal, m.primed = st, true
+	if !primed || !st.Supported {
+		return
+	}
+	var kind string
+	var n uint64
+	if st.Core > prev.Core {
+		kind, n = "core", st.Core-prev.Core
+	}
+	if st.Package > prev.Package {
+		if kind != "" {
+			kind += "+"
+		}
+		kind += "package"
+		n += st.Package - prev.Package
+	}
+	if kind == "" {
+		return
+	}
+	curve := "firmware"
+	if a.fanEnabled {
+		curve = "custom"
+	}
+	m.events = append(m.events, throttleEvent{
+		Time:    time.Now().Format("15:04:05"),
+		Kind:    kind,
+		Count:   n,
+		TempC:   st.TempC,
+		Profile: a.profile,
+		Curve:   curve,
+	})
+	if !m.session.IsZero() {
+		m.sessionEvents++
+	}
+	if len(m.events) > maxThrottleEvents {
+		m.events = m.events[len(m.events)-maxThrottleEvents:]
+	}
+	if m.scroll > 0 {
+		m.scroll++ // keep the same events on screen
+	}
+}
+
+func (a *App) renderMonitor(y, h int) {
+	t := a.term
+	W := t.Width()
+	cx := a.padX()
+	m := &a.monitor
+
+	t.TextBold(cx, y+1, ColText, "Monitor")
+	t.Text(cx, y+2, ColTextDim, "Thermal throttling events, to compare against fan curve changes")
+
+	if !m.session.IsZero() {
+		sleep := "sleep inhibited"
+		if a.inhibitRelease == nil {
+			sleep = "could not inhibit sleep"
+		}
+		rec := fmt.Sprintf("● Recording %s · %d events · %s", formatElapsed(time.Since(m.session)), m.sessionEvents, sleep)
+		t.TextBold(cx+24, y+1, ColError, rec)
+	}
+
+	t.TextBold(cx, y+4, a.accent(), "Thermal Throttling")
+	if g := a.sensors.DGPU; g.Present {
+		t.Text(cx+24, y+4, ColTextDim, "NVIDIA dGPU  ")
+		t.Text(cx+37, y+4, ColText, g.String())
+	}
+	t.Text(cx+2, y+5, ColTextDim, "CPU package  ")
+	t.Text(cx+15, y+5, ColText, formatTemp(m.thermal.TempC))
+	if !m.thermal.Supported {
+		t.Text(cx+2, y+6, ColTextMut, "No throttle counters on this CPU (Intel thermal_throttle sysfs only)")
+		return
+	}
+	t.Text(cx+24, y+5, ColTextDim, fmt.Sprintf("counters: core %d · package %d", m.thermal.Core, m.thermal.Package))
+
+	t.Text(cx, y+7, ColTextMut, fmt.Sprintf("%-9s %-13s %6s %6s  %-12s %s", "Time", "Throttled", "Events", "Temp", "Profile", "Fan curve"))
+	t.HLine(cx, y+8, min(W-6, 70), ColBorder)
+	listY, listH := y+9, max(h-11, 1)
+	if len(m.events) == 0 {
+		t.Text(cx, listY, ColTextMut, "No throttling since the app started")
+	}
+	// newest first
+	for i := 0; i < listH; i++ {
+		idx := len(m.events) - 1 - m.scroll - i
+		if idx < 0 {
+			break
+		}
+		e := m.events[idx]
+		col := ColWarning
+		if e.Kind != "core" {
+			col = ColError // package throttling slows every core
+		}
+		t.Text(cx, listY+i, col, fmt.Sprintf("%-9s %-13s %6d %6s  %-12s %s",
+			e.Time, e.Kind, e.Count, formatTemp(e.TempC), e.Profile, e.Curve))
+	}
+	if n, _ := a.throttleStats(a.profile); n > 0 {
+		t.Text(cx+2, y+6, ColWarning, fmt.Sprintf("%d throttle events under %s — press f for a suggested fan curve", n, a.profile))
+	}
+	t.Text(cx, y+h-1, ColTextMut, "↑↓ scroll  c clear  f suggest fan curve  s start/stop recording")
+}
+
+func (a *App) handleMonitor(key KeyEvent) {
+	m := &a.monitor
+	switch key.Type {
+	case KeyUp:
+		m.scroll = max(m.scroll-1, 0)
+	case KeyDown:
+		m.scroll = min(m.scroll+1, max(len(m.events)-1, 0))
+	case KeyChar:
+		switch key.Char {
+		case 'c':
+			m.events, m.scroll = nil, 0
+			a.SetStatus("Throttle log cleared", true)
+		case 'f':
+			a.offerCurveRecommendation()
+		case 's':
+			if m.session.IsZero() {
+				a.startMonitorSession()
+			} else {
+				a.endMonitorSession()
+			}
+		}
+	}
+}
+
+// startMonitorSession marks the start of a measurement and keeps the
+// laptop from suspending until it ends.
+func (a *App) startMonitorSession() {
+	m := &a.monitor
+	m.session, m.sessionEvents = time.Now(), 0
+	if err := a.holdSleepInhibit("thermal monitoring session"); err != nil {
+		a.SetStatus("Recording, but sleep could not be inhibited: "+err.Error(), false)
+		return
+	}
+	a.SetStatus("Recording — sleep is inhibited until you press s again", true)
+}
+
+func (a *App) endMonitorSession() {
+	m := &a.monitor
+	if m.session.IsZero() {
+		return
+	}
+	n := m.sessionEvents
+	a.addLog("monitor session", fmt.Sprintf("%s, %d throttle events", formatElapsed(time.Since(m.session)), n), true)
+	a.SetStatus(fmt.Sprintf("Recording stopped after %s: %d throttle events", formatElapsed(time.Since(m.session)), n), true)
+	m.session = time.Time{}
+	a.releaseSleepInhibit()
+}
+
+// formatElapsed is a session length such as "4m12s" or "1h05m".
+func formatElapsed(d time.Duration) string {
+	d = d.Round(time.Second)
+	if d >= time.Hour {
+		return fmt.Sprintf("%dh%02dm", int(d.Hours()), int(d.Minutes())%60)
+	}
+	return fmt.Sprintf("%dm%02ds", int(d.Minutes()), int(d.Seconds())%60)
+}
+
+// ─── Curve recommendation ────────────────────────────────────────────────────
+
+// throttleStats returns how many events were logged under profile and the
+// lowest temperature any of them throttled at (0 if none was read).
+func (a *App) throttleStats(profile string) (n int, minC float64) {
+	for _, e := range a.monitor.events {
+		if e.Profile != profile {
+			continue
+		}
+		n++
+		if e.TempC > 0 && (minC == 0 || e.TempC < minC) {
+			minC = e.TempC
+		}
+	}
+	return n, minC
+}
+
+// recommendCurve raises the points near where the CPU throttled: +20% from
+// 10°C below the throttle temperature, +10% for the 10°C before that. The
+// result never falls between points, as asusd rejects such curves.
+func recommendCurve(temps, speeds [8]int, throttleC float64) [8]int {
+	out := speeds
+	for i, temp := range temps {
+		switch {
+		case float64(temp) >= throttleC-10:
+			out[i] += 20
+		case float64(temp) >= throttleC-20:
+			out[i] += 10
+		}
+		out[i] = min(out[i], 100)
+		if i > 0 {
+			out[i] = max(out[i], out[i-1])
+		}
+	}
+	return out
+}
+
+// formatCurveRow lays out one curve row for the preview table.
+func formatCurveRow(label string, vals [8]int) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%-9s", label)
+	for _, v := range vals {
+		fmt.Fprintf(&b, "%5d", v)
+	}
+	return b.String()
+}
+
+// offerCurveRecommendation previews raised curves for the active profile,
+// built from the throttling seen under it, and applies them on confirm.
+func (a *App) offerCurveRecommendation() {
+	if a.locked("fan_curves") {
+		return
+	}
+	n, throttleC := a.throttleStats(a.profile)
+	if n == 0 {
+		a.SetStatus("No throttling recorded under "+a.profile, false)
+		return
+	}
+	if throttleC == 0 {
+		throttleC = defaultThrottleC
+	}
+
+	var recs [][8]int
+	lines := []string{
+		fmt.Sprintf("%d throttle events under %s, from %s.", n, a.profile, formatTemp(throttleC)),
+	}
+	changed := false
+	for i, fan := range a.fans() {
+		rec := recommendCurve(a.fanTemps[i], a.fanSpeeds[i], throttleC)
+		recs = append(recs, rec)
+		changed = changed || rec != a.fanSpeeds[i]
+		name := strings.ToUpper(fan)
+		lines = append(lines, "",
+			formatCurveRow(name+" "+tempUnit(), displayTemps(a.fanTemps[i])),
+			formatCurveRow(name+" now", a.fanSpeeds[i]),
+			formatCurveRow(name+" new", rec))
+	}
+	if !changed {
+		a.SetStatus("Fan curves for "+a.profile+" are already at maximum near "+formatTemp(throttleC), false)
+		return
+	}
+	lines = append(lines, "", "Apply the raised curves to every fan?")
+	// a suggestion to look at, not an "are you sure?", so always shown
+	a.confirm = &Confirm{
+		Title: "Suggested fan curves — " + a.profile,
+		Lines: lines,
+		OnYes: func() {
+			for i := range recs {
+				a.fanSpeeds[i] = recs[i]
+			}
+			a.applyAllFans("Suggested curves")
+		},
+	}
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// NVIDIA telemetry — dGPU temperature, power draw and load from nvidia-smi
+// The proprietary driver has no hwmon node, so nvidia-smi is the source.
+// Querying wakes a runtime-suspended dGPU, which costs more battery than the
+// reading is worth: a sleeping GPU is reported as asleep and left alone.
+// ═══════════════════════════════════════════════════════════════════════════════
+
+const nvidiaVendor = "0x10de"
+
+// GpuTelemetry is one nvidia-smi reading.
+type GpuTelemetry struct {
+	Present bool // an NVIDIA GPU is on the PCI bus
+	Asleep  bool // runtime-suspended; the other fields are not read
+	TempC   float64
+	PowerW  float64
+	Util    int // percent
+}
+
+// String is the one-line summary the Fans and Monitor tabs show.
+func (g GpuTelemetry) String() string {
+	switch {
+	case !g.Present:
+		return "—"
+	case g.Asleep:
+		return "asleep"
+	}
+	return fmt.Sprintf("%s · %.1f W · %d%% load", formatTemp(g.TempC), g.PowerW, g.Util)
+}
+
+// nvidiaPowerState returns the runtime PM status of the first NVIDIA display
+// controller ("active", "suspended"), or "" when there is none.
+func nvidiaPowerState() string {
+	devs, _ := filepath.Glob("/sys/bus/pci/devices/*")
+	for _, d := range devs {
+		if readSysfsString(filepath.Join(d, "vendor")) != nvidiaVendor {
+			continue
+		}
+		// class 0x03xxxx is a display controller; skip the HDMI audio function
+		if !strings.HasPrefix(readSysfsString(filepath.Join(d, "class")), "0x03") {
+			continue
+		}
+		st := readSysfsString(filepath.Join(d, "power", "runtime_status"))
+		if st == "" {
+			st = "active"
+		}
+		return st
+	}
+	return ""
+}
+
+// parseNvidiaSmi reads one line of
+// --query-gpu=temperature.gpu,power.draw,utilization.gpu --format=csv,noheader,nounits
+// e.g. "54, 23.41, 17". Fields the GPU does not report read "[N/A]".
+func parseNvidiaSmi(out string) (GpuTelemetry, bool) {
+	line := strings.TrimSpace(strings.SplitN(out, "\n", 2)[0])
+	f := strings.Split(line, ",")
+	if len(f) != 3 {
+		return GpuTelemetry{}, false
+	}
+	g := GpuTelemetry{Present: true}
+	temp, err := strconv.ParseFloat(strings.TrimSpace(f[0]), 64)
+	if err != nil {
+		return GpuTelemetry{}, false
+	}
+	g.TempC = temp
+	g.PowerW, _ = strconv.ParseFloat(strings.TrimSpace(f[1]), 64)
+	g.Util, _ = strconv.Atoi(strings.TrimSpace(f[2]))
+	return g, true
+}
+
+func (b *ExecBackend) readNvidia() GpuTelemetry {
+	switch nvidiaPowerState() {
+	case "":
+		return GpuTelemetry{}
+	case "suspended", "suspending":
+		return GpuTelemetry{Present: true, Asleep: true}
+	}
+	if _, err := hostLookPath("nvidia-smi"); err != nil {
+		return GpuTelemetry{}
+	}
+	ok, out := execWithTimeout(hostCommand("nvidia-smi",
+		"--query-gpu=temperature.gpu,power.draw,utilization.gpu",
+		"--format=csv,noheader,nounits"), b.policy.Timeout)
+	if !ok {
+		return GpuTelemetry{Present: true}
+	}
+	g, _ := parseNvidiaSmi(out)
+	g.Present = true
+	return g
+}
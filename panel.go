@@ -0,0 +1,152 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// Panel refresh rate — the built-in panel's mode belongs to the display
+// server, not to asusd: xrandr under X11, kscreen-doctor on KDE Wayland.
+// Other Wayland compositors have no common CLI, so the Display tab shows
+// the rate as unavailable there.
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// PanelRates are the refresh rates of the internal panel's current
+// resolution.
+type PanelRates struct {
+	Tool      string // "xrandr" or "kscreen-doctor"
+	Connector string // e.g. "eDP-1"
+	Mode      string // resolution, e.g. "2560x1600"
+	Rates     []float64
+	Current   int      // index into Rates, -1 if unknown
+	ids       []string // kscreen-doctor mode id per rate
+}
+
+// isInternalConnector reports whether name is a built-in panel connector.
+func isInternalConnector(name string) bool {
+	for _, p := range internalConnectors {
+		if strings.HasPrefix(name, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseXrandrRates reads the internal panel from `xrandr` output: the
+// connector line, then one line per resolution with its rates, the current
+// one marked '*'.
+func parseXrandrRates(out string) (PanelRates, bool) {
+	p := PanelRates{Tool: "xrandr", Current: -1}
+	inPanel := false
+	for _, line := range strings.Split(out, "\n") {
+		if !strings.HasPrefix(line, " ") {
+			f := strings.Fields(line)
+			inPanel = len(f) > 1 && isInternalConnector(f[0]) && f[1] == "connected"
+			if inPanel {
+				p.Connector = f[0]
+			}
+			continue
+		}
+		if !inPanel || !strings.Contains(line, "*") {
+			continue
+		}
+		f := strings.Fields(line)
+		p.Mode = f[0]
+		for _, r := range f[1:] {
+			hz, err := strconv.ParseFloat(strings.Trim(r, "*+"), 64)
+			if err != nil {
+				continue
+			}
+			if strings.Contains(r, "*") {
+				p.Current = len(p.Rates)
+			}
+			p.Rates = append(p.Rates, hz)
+		}
+		return p, len(p.Rates) > 0
+	}
+	return p, false
+}
+
+// parseKscreenRates reads the internal panel from `kscreen-doctor -j`.
+func parseKscreenRates(out string) (PanelRates, bool) {
+	var doc struct {
+		Outputs []struct {
+			Name          string `json:"name"`
+			Connected     bool   `json:"connected"`
+			CurrentModeID string `json:"currentModeId"`
+			Modes         []struct {
+				ID          string  `json:"id"`
+				RefreshRate float64 `json:"refreshRate"`
+				Size        struct {
+					Width  int `json:"width"`
+					Height int `json:"height"`
+				} `json:"size"`
+			} `json:"modes"`
+		} `json:"outputs"`
+	}
+	p := PanelRates{Tool: "kscreen-doctor", Current: -1}
+	if json.Unmarshal([]byte(out), &doc) != nil {
+		return p, false
+	}
+	for _, o := range doc.Outputs {
+		if !o.Connected || !isInternalConnector(o.Name) {
+			continue
+		}
+		p.Connector = o.Name
+		for _, m := range o.Modes {
+			if m.ID == o.CurrentModeID {
+				p.Mode = fmt.Sprintf("%dx%d", m.Size.Width, m.Size.Height)
+			}
+		}
+		for _, m := range o.Modes {
+			if fmt.Sprintf("%dx%d", m.Size.Width, m.Size.Height) != p.Mode {
+				continue
+			}
+			if m.ID == o.CurrentModeID {
+				p.Current = len(p.Rates)
+			}
+			p.Rates = append(p.Rates, m.RefreshRate)
+			p.ids = append(p.ids, m.ID)
+		}
+		return p, len(p.Rates) > 0
+	}
+	return p, false
+}
+
+func (b *ExecBackend) GetPanelRates() (PanelRates, error) {
+	if os.Getenv("WAYLAND_DISPLAY") == "" && os.Getenv("DISPLAY") != "" {
+		ok, out := execWithTimeout(hostCommand("xrandr"), b.policy.Timeout)
+		if !ok {
+			return PanelRates{}, errors.New(out)
+		}
+		if p, ok := parseXrandrRates(out); ok {
+			return p, nil
+		}
+		return PanelRates{}, errors.New("xrandr lists no built-in panel")
+	}
+	if _, err := hostLookPath("kscreen-doctor"); err != nil {
+		return PanelRates{}, errors.New("needs xrandr (X11) or kscreen-doctor (KDE); use your desktop's display settings")
+	}
+	ok, out := execWithTimeout(hostCommand("kscreen-doctor", "-j"), b.policy.Timeout)
+	if !ok {
+		return PanelRates{}, errors.New(out)
+	}
+	if p, ok := parseKscreenRates(out); ok {
+		return p, nil
+	}
+	return PanelRates{}, errors.New("kscreen-doctor lists no built-in panel")
+}
+
+// SetPanelRate switches the panel to p.Rates[i] at its current resolution.
+func (b *ExecBackend) SetPanelRate(p PanelRates, i int) (bool, string) {
+	if p.Tool == "kscreen-doctor" {
+		return b.runBin("kscreen-doctor", "output."+p.Connector+".mode."+p.ids[i])
+	}
+	return b.runBin("xrandr", "--output", p.Connector, "--mode", p.Mode,
+		"--rate", strconv.FormatFloat(p.Rates[i], 'f', 2, 64))
+}
@@ -0,0 +1,253 @@
+package main
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// Output parsing — asusctl stdout into typed values
+// Each parser accepts the text of every supported CLI version and returns a
+// *ParseError instead of guessing when nothing recognisable is found.
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// ParseError means the output did not contain the expected value.
+type ParseError struct {
+	What   string // what was being parsed, e.g. "profile"
+	Output string
+}
+
+func (e *ParseError) Error() string {
+	line := strings.TrimSpace(strings.SplitN(e.Output, "\n", 2)[0])
+	if line == "" {
+		line = "(empty output)"
+	}
+	return "cannot parse " + e.What + " from: " + line
+}
+
+// knownProfiles are the platform profile names asusctl prints, in its casing.
+var knownProfiles = []string{"Performance", "Balanced", "Quiet", "LowPower"}
+
+// ProfileInfo is the output of `asusctl profile get`.
+type ProfileInfo struct {
+	Active string
+	OnAC   string // "" when not reported (asusctl < 6)
+	OnBat  string
+}
+
+// matchProfile returns the known profile named by the last word of line.
+func matchProfile(line string) string {
+	f := strings.Fields(line)
+	if len(f) == 0 {
+		return ""
+	}
+	word := strings.Trim(f[len(f)-1], ".:,")
+	for _, p := range knownProfiles {
+		if strings.EqualFold(word, p) {
+			return p
+		}
+	}
+	return ""
+}
+
+// ParseProfileInfo reads lines such as "Active profile is Balanced" and, on
+// 6.x, "Profile on AC is Performance" / "Profile on Battery is Quiet".
+func ParseProfileInfo(out string) (ProfileInfo, error) {
+	var info ProfileInfo
+	for _, line := range strings.Split(out, "\n") {
+		lo := strings.ToLower(line)
+		p := matchProfile(line)
+		if p == "" {
+			continue
+		}
+		switch {
+		case strings.Contains(lo, "on ac"):
+			info.OnAC = p
+		case strings.Contains(lo, "on battery"):
+			info.OnBat = p
+		case info.Active == "":
+			info.Active = p
+		}
+	}
+	if info.Active == "" {
+		return info, &ParseError{What: "profile", Output: out}
+	}
+	return info, nil
+}
+
+// ParseProfileList reads `asusctl profile list`, one name per line.
+func ParseProfileList(out string) []string {
+	var names []string
+	for _, line := range strings.Split(out, "\n") {
+		if p := matchProfile(line); p != "" {
+			names = append(names, p)
+		}
+	}
+	return names
+}
+
+// ParseAuraModes finds the effects named at the start of each line, as in
+// the builtins of asusd's aura config ("RainbowCycle: ("). Names are
+// returned as in auraModes, in its order.
+func ParseAuraModes(out string) []string {
+	found := map[string]bool{}
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		name := strings.ToLower(strings.Trim(fields[0], "{(:,"))
+		name = strings.ReplaceAll(name, "-", "")
+		if name == "star" { // asusd's name for Stars
+			name = "stars"
+		}
+		found[name] = true
+	}
+	var modes []string
+	for _, m := range auraModes {
+		if found[strings.ToLower(strings.ReplaceAll(m, " ", ""))] {
+			modes = append(modes, m)
+		}
+	}
+	return modes
+}
+
+// LedState is the output of `asusctl leds get`.
+type LedState struct {
+	Brightness string // one of kbdValues
+	Level      int    // index into kbdValues
+}
+
+// ParseLedState reads "Current keyboard led brightness: Med".
+func ParseLedState(out string) (LedState, error) {
+	for _, field := range strings.Fields(strings.ToLower(out)) {
+		field = strings.Trim(field, ".:,")
+		for i, v := range kbdValues {
+			if field == v {
+				return LedState{Brightness: v, Level: i}, nil
+			}
+		}
+	}
+	return LedState{}, &ParseError{What: "keyboard brightness", Output: out}
+}
+
+// BatteryInfo is the output of `asusctl battery info`.
+type BatteryInfo struct {
+	ChargeLimit int // percent, 20-100
+}
+
+// ParseBatteryInfo reads "Current battery charge limit: 70%".
+func ParseBatteryInfo(out string) (BatteryInfo, error) {
+	for _, field := range strings.Fields(out) {
+		field = strings.TrimSuffix(strings.Trim(field, ".:,"), "%")
+		if v, err := strconv.Atoi(field); err == nil && v >= 20 && v <= 100 {
+			return BatteryInfo{ChargeLimit: v}, nil
+		}
+	}
+	return BatteryInfo{}, &ParseError{What: "charge limit", Output: out}
+}
+
+// FanCurves is the curve asusd holds for each fan of one profile, indexed
+// like fanNames.
+type FanCurves struct {
+	Temps  [3][8]int // °C
+	Speeds [3][8]int // percent
+	Mid    bool      // the laptop has a mid fan: asusd listed its curve
+}
+
+// fanCurveField matches the "fan: CPU", "pwm: (…)" and "temp: (…)" fields
+// of a curve, whether printed by asusctl or stored in fan_curves.ron.
+var fanCurveField = regexp.MustCompile(`\b(fan|pwm|temp)\s*:\s*(\w+|[(\[][^)\]]*[)\]])`)
+
+// parseCurveTuple reads "(38, 64, …)" into 8 values.
+func parseCurveTuple(s string) ([8]int, bool) {
+	var vals [8]int
+	parts := strings.Split(strings.Trim(s, "()[]"), ",")
+	if len(parts) < 8 {
+		return vals, false
+	}
+	for i := range vals {
+		v, err := strconv.Atoi(strings.TrimSpace(parts[i]))
+		if err != nil {
+			return vals, false
+		}
+		vals[i] = v
+	}
+	return vals, true
+}
+
+// ParseFanCurves reads the curves of `asusctl fan-curve --mod-profile <p>`
+// or one profile's section of /etc/asusd/fan_curves.ron. Each curve names
+// its fan and lists 8 pwm values (0-255) and 8 temperatures. Fans the TUI
+// does not edit are skipped; the CPU curve is required.
+func ParseFanCurves(out string) (FanCurves, error) {
+	var fc FanCurves
+	var seen [3]bool
+	fan := -1
+	for _, m := range fanCurveField.FindAllStringSubmatch(out, -1) {
+		key, val := m[1], m[2]
+		if key == "fan" {
+			fan = -1
+			for i, name := range fanNames {
+				if strings.EqualFold(val, name) {
+					fan = i
+				}
+			}
+			continue
+		}
+		if fan < 0 {
+			continue
+		}
+		vals, ok := parseCurveTuple(val)
+		if !ok {
+			continue
+		}
+		if key == "temp" {
+			fc.Temps[fan] = vals
+			continue
+		}
+		for i, v := range vals {
+			fc.Speeds[fan][i] = (clamp(v, 0, 255)*100 + 127) / 255 // pwm → percent
+		}
+		seen[fan] = true
+	}
+	if !seen[0] {
+		return fc, &ParseError{What: "fan curves", Output: out}
+	}
+	fc.Mid = seen[2]
+	for i := range fc.Temps {
+		if fc.Temps[i] == [8]int{} {
+			fc.Temps[i] = defaultFanTemps
+		}
+	}
+	return fc, nil
+}
+
+// fanCurvesRonSection returns the part of fan_curves.ron holding profile's
+// curves, e.g. the list after "balanced: [".
+func fanCurvesRonSection(ron, profile string) string {
+	key := strings.ToLower(profile) + ":"
+	start := strings.Index(ron, key)
+	if start < 0 {
+		return ""
+	}
+	rest := ron[start+len(key):]
+	open := strings.IndexAny(rest, "[(")
+	if open < 0 {
+		return ""
+	}
+	depth := 0
+	for i, ch := range rest[open:] {
+		switch ch {
+		case '[', '(':
+			depth++
+		case ']', ')':
+			depth--
+			if depth == 0 {
+				return rest[open : open+i+1]
+			}
+		}
+	}
+	return ""
+}
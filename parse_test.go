@@ -0,0 +1,188 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseAuraModes(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want []string
+	}{
+		{"builtins", ronBlock(auraRonSample, "builtins"), []string{"Static", "Rainbow Wave"}},
+		{"asusd names", "{\n  Star: (\n  RainbowCycle: (\n  Breathe: (\n  Pulse: (\n}", []string{"Breathe", "Rainbow Cycle", "Stars", "Pulse"}},
+		{"unknown effects", "{\n  Disco: (\n  Strobe: (\n}", nil},
+		{"empty", "", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ParseAuraModes(tt.in); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseAuraModes = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseProfileInfo(t *testing.T) {
+	tests := []struct {
+		name string
+		out  string
+		want ProfileInfo
+	}{
+		{"asusctl 6", "Active profile is Balanced\nProfile on AC is Performance\nProfile on Battery is Quiet\n",
+			ProfileInfo{Active: "Balanced", OnAC: "Performance", OnBat: "Quiet"}},
+		{"asusctl 5", "Active profile is Performance\n", ProfileInfo{Active: "Performance"}},
+		{"lowercase", "active profile is quiet.", ProfileInfo{Active: "Quiet"}},
+		{"low power", "Active profile is LowPower", ProfileInfo{Active: "LowPower"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseProfileInfo(tt.out)
+			if err != nil || got != tt.want {
+				t.Errorf("got %+v, %v, want %+v", got, err, tt.want)
+			}
+		})
+	}
+	_, err := ParseProfileInfo("Error: zbus error: org.freedesktop.DBus.Error.ServiceUnknown\n")
+	if err == nil || err.Error() != "cannot parse profile from: Error: zbus error: org.freedesktop.DBus.Error.ServiceUnknown" {
+		t.Errorf("err = %v", err)
+	}
+}
+
+func TestParseProfileList(t *testing.T) {
+	got := ParseProfileList("Quiet\nBalanced\nPerformance\n")
+	if want := []string{"Quiet", "Balanced", "Performance"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if got := ParseProfileList("Starting version 6.1.4\n"); got != nil {
+		t.Errorf("got %v from no profiles", got)
+	}
+}
+
+func TestParseLedState(t *testing.T) {
+	tests := []struct {
+		out   string
+		level int
+	}{
+		{"Current keyboard led brightness: Med\n", 2},
+		{"Current keyboard led brightness: Off", 0},
+		{"Looking for asusd... asusd 6.1.4\nCurrent keyboard led brightness: High\n", 3},
+		{"current keyboard led brightness: low.", 1},
+	}
+	for _, tt := range tests {
+		got, err := ParseLedState(tt.out)
+		if err != nil || got.Level != tt.level || got.Brightness != kbdValues[tt.level] {
+			t.Errorf("%q: got %+v, %v, want level %d", tt.out, got, err, tt.level)
+		}
+	}
+	if _, err := ParseLedState(""); err == nil || !strings.Contains(err.Error(), "(empty output)") {
+		t.Errorf("err = %v", err)
+	}
+}
+
+func TestParseBatteryInfo(t *testing.T) {
+	tests := []struct {
+		out   string
+		limit int
+		ok    bool
+	}{
+		{"Current battery charge limit: 80%\n", 80, true},
+		{"Battery charge limit: 100%", 100, true},
+		{"Charge limit 60", 60, true},
+		{"Current battery charge limit: 10%", 0, false}, // below what asusd accepts
+		{"Error: no battery", 0, false},
+	}
+	for _, tt := range tests {
+		got, err := ParseBatteryInfo(tt.out)
+		if (err == nil) != tt.ok || got.ChargeLimit != tt.limit {
+			t.Errorf("%q: got %d, %v", tt.out, got.ChargeLimit, err)
+		}
+	}
+}
+
+// fanCurveOutput is `asusctl fan-curve --mod-profile balanced` from
+// asusctl 6 on a G14 (CPU and GPU fans).
+const fanCurveOutput = `[
+    (
+        fan: CPU,
+        pwm: (2, 22, 45, 68, 91, 153, 153, 153),
+        temp: (20, 30, 40, 50, 70, 80, 90, 100),
+        enabled: false,
+    ),
+    (
+        fan: GPU,
+        pwm: (0, 25, 51, 76, 127, 178, 204, 255),
+        temp: (25, 35, 45, 55, 65, 75, 85, 95),
+        enabled: true,
+    ),
+]
+`
+
+// fanCurvesRonSample is /etc/asusd/fan_curves.ron from a Strix with a mid
+// fan, trimmed to two profiles.
+const fanCurvesRonSample = `(
+    profiles: (
+        balanced: [
+            (fan: CPU, pwm: (2, 22, 45, 68, 91, 153, 153, 153), temp: (20, 30, 40, 50, 70, 80, 90, 100), enabled: false),
+            (fan: GPU, pwm: (0, 25, 51, 76, 127, 178, 204, 255), temp: (25, 35, 45, 55, 65, 75, 85, 95), enabled: false),
+            (fan: MID, pwm: (0, 0, 0, 26, 51, 77, 102, 128), temp: (30, 40, 50, 60, 70, 80, 90, 100), enabled: false),
+        ],
+        performance: [
+            (fan: CPU, pwm: (51, 64, 89, 115, 140, 178, 217, 255), temp: (30, 40, 50, 60, 70, 80, 90, 100), enabled: true),
+        ],
+    ),
+)`
+
+func TestParseFanCurves(t *testing.T) {
+	cpu := [8]int{1, 9, 18, 27, 36, 60, 60, 60}
+	gpu := [8]int{0, 10, 20, 30, 50, 70, 80, 100}
+	tests := []struct {
+		name   string
+		out    string
+		speeds [3][8]int
+		temps  [3][8]int
+		mid    bool
+	}{
+		{"asusctl 6", fanCurveOutput,
+			[3][8]int{cpu, gpu}, [3][8]int{{20, 30, 40, 50, 70, 80, 90, 100}, {25, 35, 45, 55, 65, 75, 85, 95}, defaultFanTemps}, false},
+		{"asusctl 5 debug print", "[CurveData { fan: CPU, pwm: [2, 22, 45, 68, 91, 153, 153, 153], temp: [20, 30, 40, 50, 70, 80, 90, 100], enabled: false }]",
+			[3][8]int{cpu}, [3][8]int{{20, 30, 40, 50, 70, 80, 90, 100}, defaultFanTemps, defaultFanTemps}, false},
+		{"fan_curves.ron with a mid fan", fanCurvesRonSection(fanCurvesRonSample, "Balanced"),
+			[3][8]int{cpu, gpu, {0, 0, 0, 10, 20, 30, 40, 50}},
+			[3][8]int{{20, 30, 40, 50, 70, 80, 90, 100}, {25, 35, 45, 55, 65, 75, 85, 95}, {30, 40, 50, 60, 70, 80, 90, 100}}, true},
+		{"other profile's section", fanCurvesRonSection(fanCurvesRonSample, "performance"),
+			[3][8]int{{20, 25, 35, 45, 55, 70, 85, 100}}, [3][8]int{{30, 40, 50, 60, 70, 80, 90, 100}, defaultFanTemps, defaultFanTemps}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fc, err := ParseFanCurves(tt.out)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if fc.Speeds != tt.speeds || fc.Temps != tt.temps || fc.Mid != tt.mid {
+				t.Errorf("got %+v\nwant speeds %v temps %v mid %v", fc, tt.speeds, tt.temps, tt.mid)
+			}
+		})
+	}
+}
+
+func TestParseFanCurvesErrors(t *testing.T) {
+	for _, out := range []string{
+		"",
+		"Error: fan curves are not supported on this laptop",
+		// a GPU curve alone: the CPU one is required
+		"(fan: GPU, pwm: (0, 25, 51, 76, 127, 178, 204, 255), temp: (25, 35, 45, 55, 65, 75, 85, 95))",
+		// too few points
+		"(fan: CPU, pwm: (2, 22, 45), temp: (20, 30, 40))",
+	} {
+		if _, err := ParseFanCurves(out); err == nil {
+			t.Errorf("%q parsed", out)
+		}
+	}
+	if got := fanCurvesRonSection(fanCurvesRonSample, "quiet"); got != "" {
+		t.Errorf("missing profile gave %q", got)
+	}
+}
@@ -0,0 +1,212 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// Runtime planner — battery runtime per profile and charge limit
+// While on battery the draw is sampled and averaged per active profile; the
+// Battery tab turns those averages and the battery's full capacity into
+// runtime estimates. Averages persist across runs in the state directory.
+// ═══════════════════════════════════════════════════════════════════════════════
+
+const (
+	drawSampleEvery = 10 * time.Second
+	// drawWeight is how much one new sample moves the average; small, so a
+	// short spike does not swamp hours of history
+	drawWeight = 0.05
+)
+
+// drawStat is the average draw measured under one profile.
+type drawStat struct {
+	AvgW    float64 `toml:"avg_w"`
+	Samples int     `toml:"samples"`
+}
+
+type plannerState struct {
+	Profiles map[string]drawStat `toml:"profiles"`
+}
+
+type planner struct {
+	state   plannerState
+	battery BatteryStatus // latest reading
+	stop    chan struct{}
+	persist bool // false in demo mode, so fake draws never reach the file
+}
+
+// stateDir returns $XDG_STATE_HOME/asusctl-tui (or ~/.local/state/asusctl-tui).
+func stateDir() string {
+	base := os.Getenv("XDG_STATE_HOME")
+	if base == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return ""
+		}
+		base = filepath.Join(home, ".local", "state")
+	}
+	return filepath.Join(base, "asusctl-tui")
+}
+
+func plannerPath() string {
+	dir := stateDir()
+	if dir == "" {
+		return ""
+	}
+	return filepath.Join(dir, "power.toml")
+}
+
+func loadPlanner() plannerState {
+	st := plannerState{Profiles: map[string]drawStat{}}
+	data, err := os.ReadFile(plannerPath())
+	if err != nil {
+		return st
+	}
+	decodeToml(string(data), &st) // a damaged file just restarts the averages
+	return st
+}
+
+func (st plannerState) save() error {
+	path := plannerPath()
+	if path == "" {
+		return errors.New("no state directory")
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	data := "# asusctl-tui measured battery draw per profile\n\n" + encodeToml(st)
+	err := os.WriteFile(path+".tmp", []byte(data), 0o644)
+	if err != nil {
+		return err
+	}
+	return os.Rename(path+".tmp", path)
+}
+
+// startPlanner samples the battery in the background until stopPlanner.
+func (a *App) startPlanner() {
+	_, demo := a.backend.(*MockBackend)
+	a.planner.persist = !demo
+	a.planner.state = plannerState{Profiles: map[string]drawStat{}}
+	if a.planner.persist {
+		a.planner.state = loadPlanner()
+	}
+	a.planner.stop = make(chan struct{})
+	stop := a.planner.stop
+	go func() {
+		tick := time.NewTicker(drawSampleEvery)
+		defer tick.Stop()
+		for {
+			st := a.backend.GetBatteryStatus()
+			a.Post(func() { a.recordDraw(st) })
+			select {
+			case <-stop:
+				return
+			case <-tick.C:
+			}
+		}
+	}()
+}
+
+func (a *App) stopPlanner() {
+	if a.planner.stop == nil {
+		return
+	}
+	close(a.planner.stop)
+	a.planner.stop = nil
+	if !a.planner.persist {
+		return
+	}
+	if err := a.planner.state.save(); err != nil {
+		a.addLog("save "+plannerPath(), err.Error(), false)
+	}
+}
+
+// recordDraw folds a discharging sample into the active profile's average.
+func (a *App) recordDraw(st BatteryStatus) {
+	a.planner.battery = st
+	a.checkOneShot()
+	if !st.Discharging() || st.PowerW <= 0 || a.profile == "" {
+		return
+	}
+	s := a.planner.state.Profiles[a.profile]
+	if s.Samples == 0 {
+		s.AvgW = st.PowerW
+	} else {
+		s.AvgW += (st.PowerW - s.AvgW) * drawWeight
+	}
+	s.Samples++
+	a.planner.state.Profiles[a.profile] = s
+}
+
+// plannerLimits are the charge limits to estimate for: full, the current
+// limit and the usual longevity settings.
+func (a *App) plannerLimits() []int {
+	limits := []int{100, 80, 60}
+	found := false
+	for _, l := range limits {
+		found = found || l == a.chargeLimit
+	}
+	if !found {
+		limits = append(limits, a.chargeLimit)
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(limits)))
+	return limits
+}
+
+func formatRuntime(h float64) string {
+	if h <= 0 {
+		return "—"
+	}
+	m := int(h*60 + 0.5)
+	return fmt.Sprintf("%dh%02d", m/60, m%60)
+}
+
+// renderPlanner draws the planner panel at x,y; 9 rows tall.
+func (a *App) renderPlanner(x, y int) {
+	t := a.term
+	bat := a.planner.battery
+
+	t.TextBold(x, y, ColText, "Runtime Planner")
+	if !bat.Present || bat.FullWh <= 0 {
+		t.Text(x, y+2, ColTextMut, "No battery capacity reported by the kernel")
+		return
+	}
+	now := "plugged in"
+	if bat.Discharging() {
+		now = fmt.Sprintf("drawing %.1f W", bat.PowerW)
+	}
+	t.Text(x, y+1, ColTextDim, fmt.Sprintf("Full capacity %.1f Wh · %s", bat.FullWh, now))
+
+	limits := a.plannerLimits()
+	t.Text(x, y+3, ColTextMut, fmt.Sprintf("%-12s %8s", "Profile", "Avg draw"))
+	for i, l := range limits {
+		col := ColTextMut
+		if l == a.chargeLimit {
+			col = a.accent()
+		}
+		t.Text(x+22+i*7, y+3, col, fmt.Sprintf("%6s", fmt.Sprintf("@%d%%", l)))
+	}
+	for r, p := range []string{"Performance", "Balanced", "Quiet"} {
+		row := y + 4 + r
+		col := ColTextDim
+		if p == a.profile {
+			col = ColText
+		}
+		t.Text(x, row, col, fmt.Sprintf("%-12s", p))
+		s, ok := a.planner.state.Profiles[p]
+		if !ok || s.Samples == 0 {
+			t.Text(x+13, row, ColTextMut, "not measured yet")
+			continue
+		}
+		t.Text(x+13, row, col, fmt.Sprintf("%6.1f W", s.AvgW))
+		for i, l := range limits {
+			t.Text(x+22+i*7, row, col, fmt.Sprintf("%6s", formatRuntime(bat.FullWh*float64(l)/100/s.AvgW)))
+		}
+	}
+	t.Text(x, y+8, ColTextMut, "Averages build up while you use each profile on battery.")
+}
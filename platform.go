@@ -0,0 +1,182 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// Platform controls — camera/mic privacy state and the touchpad, via sysfs
+// asusctl does not cover these, so they are read (and, where the node is
+// writable, set) directly. Node names differ between kernel versions.
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// PlatformLed is one camera/mic indicator exposed by the platform driver.
+type PlatformLed struct {
+	ID       string
+	Label    string
+	On       bool
+	Writable bool
+}
+
+type PlatformControl interface {
+	// GetPlatformLeds returns only the indicators this machine exposes.
+	GetPlatformLeds() []PlatformLed
+	SetPlatformLed(id string, on bool) (bool, string)
+	GetTouchpad() TouchpadState
+	SetTouchpad(enabled bool) (bool, string)
+}
+
+// platformLedSources lists candidate sysfs nodes per indicator; the first
+// one that exists is used.
+var platformLedSources = []struct {
+	id    string
+	label string
+	paths []string
+}{
+	{"camera", "Camera enabled", []string{
+		"/sys/devices/platform/asus-nb-wmi/camera",
+		"/sys/bus/platform/devices/asus-nb-wmi/camera",
+	}},
+	{"camera_led", "Camera privacy LED", []string{
+		"/sys/class/leds/platform::cameramute/brightness",
+		"/sys/class/leds/asus::camera/brightness",
+	}},
+	{"micmute_led", "Mic mute LED", []string{
+		"/sys/class/leds/platform::micmute/brightness",
+	}},
+}
+
+func platformLedPath(id string) string {
+	for _, src := range platformLedSources {
+		if src.id != id {
+			continue
+		}
+		for _, p := range src.paths {
+			if _, err := os.Stat(p); err == nil {
+				return p
+			}
+		}
+	}
+	return ""
+}
+
+// readSysfsLeds reads every indicator that has a node on this machine.
+func readSysfsLeds() []PlatformLed {
+	var leds []PlatformLed
+	for _, src := range platformLedSources {
+		path := platformLedPath(src.id)
+		if path == "" {
+			continue
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		val := strings.TrimSpace(string(data))
+		leds = append(leds, PlatformLed{
+			ID:       src.id,
+			Label:    src.label,
+			On:       val != "" && val != "0",
+			Writable: syscall.Access(path, 2) == nil, // W_OK
+		})
+	}
+	return leds
+}
+
+func writeSysfsLed(id string, on bool) (bool, string) {
+	path := platformLedPath(id)
+	if path == "" {
+		return false, id + " is not supported on this laptop"
+	}
+	val := "0"
+	if on {
+		val = "1"
+	}
+	if err := os.WriteFile(path, []byte(val), 0); err != nil {
+		return false, err.Error()
+	}
+	return true, ""
+}
+
+func (b *ExecBackend) GetPlatformLeds() []PlatformLed { return readSysfsLeds() }
+
+func (b *ExecBackend) SetPlatformLed(id string, on bool) (bool, string) {
+	return writeSysfsLed(id, on)
+}
+
+// ─── Touchpad ────────────────────────────────────────────────────────────────
+
+// TouchpadState describes the built-in touchpad, if one was found.
+type TouchpadState struct {
+	Present  bool
+	Enabled  bool
+	Writable bool
+	path     string // sysfs node that was read
+	inhibit  bool   // path is an input "inhibited" node (1 = disabled)
+}
+
+// asusTouchpadPaths are asus-wmi toggles (1 = enabled) on models that have one.
+var asusTouchpadPaths = []string{
+	"/sys/devices/platform/asus-nb-wmi/touchpad",
+}
+
+// findTouchpad prefers the asus-wmi toggle and falls back to inhibiting the
+// input device, which libinput honours like unplugging it (Linux 5.11+).
+func findTouchpad() TouchpadState {
+	for _, p := range asusTouchpadPaths {
+		if _, err := os.Stat(p); err == nil {
+			return readTouchpad(p, false)
+		}
+	}
+	names, _ := filepath.Glob("/sys/class/input/input*/name")
+	for _, n := range names {
+		data, err := os.ReadFile(n)
+		if err != nil || !strings.Contains(strings.ToLower(string(data)), "touchpad") {
+			continue
+		}
+		p := filepath.Join(filepath.Dir(n), "inhibited")
+		if _, err := os.Stat(p); err == nil {
+			return readTouchpad(p, true)
+		}
+	}
+	return TouchpadState{}
+}
+
+func readTouchpad(path string, inhibit bool) TouchpadState {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return TouchpadState{}
+	}
+	set := strings.TrimSpace(string(data)) == "1"
+	return TouchpadState{
+		Present:  true,
+		Enabled:  set != inhibit,
+		Writable: syscall.Access(path, 2) == nil,
+		path:     path,
+		inhibit:  inhibit,
+	}
+}
+
+func writeTouchpad(enabled bool) (bool, string) {
+	tp := findTouchpad()
+	if !tp.Present {
+		return false, "no touchpad toggle is supported on this laptop"
+	}
+	val := "0"
+	if enabled != tp.inhibit {
+		val = "1"
+	}
+	if err := os.WriteFile(tp.path, []byte(val), 0); err != nil {
+		return false, err.Error()
+	}
+	return true, ""
+}
+
+func (b *ExecBackend) GetTouchpad() TouchpadState { return findTouchpad() }
+
+func (b *ExecBackend) SetTouchpad(enabled bool) (bool, string) {
+	return writeTouchpad(enabled)
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// Power source — charger plug and unplug from /sys/class/power_supply
+// The "Mains" supply (AC, ACAD, ADP1 depending on the firmware) is polled
+// with the automation policies; a change refreshes the battery reading and
+// runs the [power_source] profile.
+// ═══════════════════════════════════════════════════════════════════════════════
+
+const acPollEvery = 2 * time.Second
+
+func (p PowerSourceConfig) Validate() error {
+	if v := p.ACProfile; v != "" && matchProfile(v) == "" {
+		return fmt.Errorf("power_source.ac_profile: unknown profile %q", v)
+	}
+	if v := p.BatteryProfile; v != "" && matchProfile(v) == "" {
+		return fmt.Errorf("power_source.battery_profile: unknown profile %q", v)
+	}
+	return nil
+}
+
+// findMains returns the sysfs directory of the AC adapter, or "".
+func findMains() string {
+	dirs, _ := filepath.Glob(filepath.Join(powerSupplyDir, "*"))
+	for _, d := range dirs {
+		if readSysfsString(filepath.Join(d, "type")) == "Mains" {
+			return d
+		}
+	}
+	return ""
+}
+
+func (b *ExecBackend) GetACOnline() (online, ok bool) {
+	dir := findMains()
+	if dir == "" {
+		return false, false
+	}
+	switch readSysfsString(filepath.Join(dir, "online")) {
+	case "1":
+		return true, true
+	case "0":
+		return false, true
+	}
+	return false, false
+}
+
+// watchPowerSource polls the AC adapter until stop is closed.
+func (a *App) watchPowerSource(stop chan struct{}) {
+	tick := time.NewTicker(acPollEvery)
+	defer tick.Stop()
+	for {
+		if online, ok := a.backend.GetACOnline(); ok {
+			a.Post(func() { a.checkPowerSource(online) })
+		}
+		select {
+		case <-stop:
+			return
+		case <-tick.C:
+		}
+	}
+}
+
+// checkPowerSource compares a reading with the last one; the first reading
+// only records where the app started.
+func (a *App) checkPowerSource(online bool) {
+	au := &a.automation
+	prev, primed := au.acOnline, au.acRead
+	au.acOnline, au.acRead = online, true
+	if primed && online != prev {
+		a.onPowerSourceChanged(online)
+	}
+}
+
+func (a *App) onPowerSourceChanged(online bool) {
+	a.planner.battery = a.backend.GetBatteryStatus()
+	what, msg, p := "charger removed", "On battery", a.cfg.PowerSource.BatteryProfile
+	if online {
+		what, msg, p = "charger connected", "Charger connected", a.cfg.PowerSource.ACProfile
+	}
+	au := &a.automation
+	au.acChanged = time.Now().Format("15:04:05")
+	a.automationLog(what, fmt.Sprintf("battery %d%%", a.planner.battery.Percent), true)
+	a.showAnimeBattery(a.planner.battery)
+	a.SetStatus(msg, true)
+	if p := matchProfile(p); p != "" && p != a.profile {
+		a.automationProfile(what, p, func() { a.SetStatus(msg+" → "+p, true) })
+	}
+}
@@ -0,0 +1,125 @@
+package main
+
+import "strings"
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// power-profiles-daemon fallback — profiles via powerprofilesctl when asusd
+// has no profile support (daemon missing or the model is not supported)
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// ppdProfiles maps powerprofilesctl names to the names the Profile tab uses.
+var ppdProfiles = []struct{ ppd, ui string }{
+	{"performance", "Performance"},
+	{"balanced", "Balanced"},
+	{"power-saver", "Quiet"},
+}
+
+func ppdToUI(name string) string {
+	name = strings.TrimSpace(name)
+	for _, p := range ppdProfiles {
+		if p.ppd == name {
+			return p.ui
+		}
+	}
+	return ""
+}
+
+func uiToPpd(name string) string {
+	for _, p := range ppdProfiles {
+		if strings.EqualFold(p.ui, name) || p.ppd == name {
+			return p.ppd
+		}
+	}
+	return ""
+}
+
+// parsePpdList reads `powerprofilesctl list`, where each profile starts a
+// line like "* balanced:" (the star marks the active one).
+func parsePpdList(out string) []string {
+	var names []string
+	for _, line := range strings.Split(out, "\n") {
+		line = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(line), "*"))
+		if !strings.HasSuffix(line, ":") {
+			continue
+		}
+		if ui := ppdToUI(strings.TrimSuffix(line, ":")); ui != "" {
+			names = append(names, ui)
+		}
+	}
+	return names
+}
+
+// usePpd decides once whether profile calls go to powerprofilesctl.
+func (b *ExecBackend) usePpd() bool {
+	b.ppdOnce.Do(func() {
+		if _, err := hostLookPath("powerprofilesctl"); err != nil {
+			return
+		}
+		ok, out := b.runOp("profile.get")
+		if ok {
+			return
+		}
+		switch classifyFailure(out).Kind {
+		case ErrUnsupported, ErrDaemonDown, ErrNotInstalled:
+			b.ppd = true
+		}
+	})
+	return b.ppd
+}
+
+func (b *ExecBackend) ProfileSource() string {
+	if b.usePpd() {
+		return "power-profiles-daemon"
+	}
+	return "asusd"
+}
+
+func (b *ExecBackend) ppdGet() (ProfileInfo, error) {
+	ok, out := b.runBin("powerprofilesctl", "get")
+	if !ok {
+		return ProfileInfo{}, classifyFailure(out)
+	}
+	ui := ppdToUI(out)
+	if ui == "" {
+		return ProfileInfo{}, &ParseError{What: "profile", Output: out}
+	}
+	return ProfileInfo{Active: ui}, nil
+}
+
+func (b *ExecBackend) ppdSet(name string) (bool, string) {
+	p := uiToPpd(name)
+	if p == "" {
+		return false, "Error: invalid profile " + name
+	}
+	return b.runBin("powerprofilesctl", "set", p)
+}
+
+func (b *ExecBackend) ppdNext() (bool, string) {
+	info, err := b.ppdGet()
+	if err != nil {
+		return false, err.Error()
+	}
+	ok, out := b.runBin("powerprofilesctl", "list")
+	if !ok {
+		return false, out
+	}
+	names := parsePpdList(out)
+	for i, n := range names {
+		if n == info.Active {
+			next := names[(i+1)%len(names)]
+			if ok, out := b.ppdSet(next); !ok {
+				return false, out
+			}
+			return true, next
+		}
+	}
+	return false, "current profile " + info.Active + " not in powerprofilesctl list"
+}
+
+func (b *ExecBackend) ppdList() (bool, string) {
+	ok, out := b.runBin("powerprofilesctl", "list")
+	if !ok {
+		return false, out
+	}
+	return true, strings.Join(parsePpdList(out), "\n")
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// Power limits — the PPT and dGPU armoury attributes as sliders on the
+// Profile tab, with the ranges the firmware reports. asusd keeps a set per
+// platform profile and writes it on every profile switch, so the sliders
+// always show (and tune) the active profile's limits.
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// pptAttrs are the attributes the Profile tab tunes, in display order.
+// ppt_pl3_fppt is the name newer kernels give ppt_fppt.
+var pptAttrs = []string{"ppt_pl1_spl", "ppt_pl2_sppt", "ppt_fppt", "ppt_pl3_fppt", "nv_dynamic_boost", "nv_temp_target"}
+
+// pptBigStep is how far PgUp/PgDn move a power limit slider.
+const pptBigStep = 10
+
+// readPptView reads the power limits this laptop has.
+func readPptView(b Backend) armouryView {
+	all := readArmouryView(b)
+	v := armouryView{err: all.err}
+	for _, name := range pptAttrs {
+		for _, at := range all.attrs {
+			if at.Name == name && at.Kind() == "int" {
+				v.attrs = append(v.attrs, at)
+			}
+		}
+	}
+	if len(v.attrs) == 0 && len(all.attrs) > 0 {
+		v.err = "this laptop exposes no power limits"
+	}
+	return v
+}
+
+// armouryUnit formats a numeric attribute's value with its unit.
+func armouryUnit(name, val string) string {
+	n, err := strconv.Atoi(val)
+	switch {
+	case err != nil:
+		return val
+	case strings.HasSuffix(name, "temp_target"):
+		return formatTemp(float64(n))
+	case strings.HasPrefix(name, "ppt_") || name == "nv_dynamic_boost" || name == "nv_tgp":
+		return val + " W"
+	}
+	return val
+}
+
+// armouryRange formats a ranged attribute's limits in its value's unit.
+func armouryRange(at ArmouryAttr) string {
+	if strings.HasSuffix(at.Name, "temp_target") {
+		return formatTempDeg(at.Min) + "–" + formatTempLimit(at.Max)
+	}
+	return fmt.Sprintf("%d–%d", at.Min, at.Max)
+}
+
+// renderPowerLimits draws the sliders from row down; focus rows follow the
+// profiles. Returns the row after the section.
+func (a *App) renderPowerLimits(cx, row int) int {
+	t := a.term
+	v := &a.ppt
+	t.TextBold(cx, row, a.accent(), "Power Limits")
+	t.Text(cx+14, row, ColTextMut, "saved by asusd for the "+a.profile+" profile")
+	row += 2
+	if v.err != "" {
+		t.Text(cx+2, row, ColTextMut, v.err)
+		return row + 2
+	}
+	for i, at := range v.attrs {
+		label := armouryLabel(at.Name)
+		if a.focusIdx == len(profileNames)+i {
+			a.focusText(cx, row, "▸ "+label)
+		} else {
+			t.Text(cx, row, ColTextDim, "  "+label)
+		}
+		t.Text(cx+26, row, ColTextMut, armouryRange(at))
+		a.renderArmouryWidget(v, cx+37, row, at) // past "167°–189°F"
+		row++
+	}
+	return row + 1
+}
+
+// readPpt re-reads the power limits and keeps the Profile tab's focus on a
+// row that is still there: a failed read lists none.
+func (a *App) readPpt() {
+	a.ppt = readPptView(a.backend)
+	if a.activeTab == TabProfile {
+		a.focusIdx = min(a.focusIdx, len(profileNames)+len(a.ppt.attrs)-1)
+	}
+}
+
+// handlePowerLimit handles a key on power limit slider i.
+func (a *App) handlePowerLimit(i int, key KeyEvent) {
+	v := &a.ppt
+	if i >= len(v.attrs) {
+		return
+	}
+	switch key.Type {
+	case KeyLeft:
+		v.step(i, -1)
+	case KeyRight:
+		v.step(i, 1)
+	case KeyPgDn:
+		v.step(i, -pptBigStep)
+	case KeyPgUp:
+		v.step(i, pptBigStep)
+	case KeyEnter:
+		if p, ok := v.pending[v.attrs[i].Name]; ok {
+			a.setArmoury(v, v.attrs[i].Name, p, nil)
+		} else {
+			a.SetStatus("Pick a value with ←→ first", false)
+		}
+	}
+}
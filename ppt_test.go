@@ -0,0 +1,27 @@
+package main
+
+import (
+	"io"
+	"testing"
+)
+
+// TestPowerLimitsShrink checks keys on a power limit row that a re-read
+// took away do nothing, and a re-read moves the focus back onto the tab.
+func TestPowerLimitsShrink(t *testing.T) {
+	a := NewApp(NewFakeTerminal(80, 24, io.Discard), NewMockBackend(), DefaultConfig())
+	a.switchTab(TabProfile)
+	rows := len(profileNames) + len(a.ppt.attrs)
+	if rows == len(profileNames) {
+		t.Fatal("the mock lists no power limits")
+	}
+	a.focusIdx = rows - 1
+	a.ppt = armouryView{err: "Error: org.freedesktop.DBus.Error.NoReply"}
+	for _, k := range []KeyEvent{{Type: KeyLeft}, {Type: KeyPgUp}, {Type: KeyEnter}} {
+		a.HandleKey(k)
+	}
+	a.focusIdx = rows + 3
+	a.readPpt()
+	if a.focusIdx != rows-1 {
+		t.Errorf("focusIdx = %d after the re-read, want %d", a.focusIdx, rows-1)
+	}
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"sync"
+	"time"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// Command queue — one asusctl write at a time, off the main loop
+// Every hardware write (profile, keyboard, Aura, fans, display, GPU, …) is
+// queued rather than run inline, so rapid key presses never start overlapping
+// asusctl processes and the UI stays responsive while asusd works. A newer
+// request for the same setting replaces one still waiting: only the last
+// value matters. Each job runs on a session of the backend of its own, so
+// the argv it reports is its own whatever else runs meanwhile, and its
+// completion waits for the main loop rather than being dropped.
+// ═══════════════════════════════════════════════════════════════════════════════
+
+type queuedJob struct {
+	key  string // setting this job writes; equal keys coalesce
+	run  func(b Backend) (bool, string)
+	done func(ok bool, out string, argv []string) // runs on the main loop
+}
+
+type CommandQueue struct {
+	backend Backend
+	done    chan func() // completions, read by the main loop
+
+	mu      sync.Mutex
+	jobs    []queuedJob
+	running bool // the worker is alive
+	busy    bool // a job is running
+}
+
+func NewCommandQueue(backend Backend) *CommandQueue {
+	return &CommandQueue{backend: backend, done: make(chan func(), 16)}
+}
+
+// Done delivers each finished job's done callback; the main loop runs them.
+func (q *CommandQueue) Done() <-chan func() { return q.done }
+
+// Submit queues run, which makes its calls on the backend it is given.
+// done receives the result and the argv that ran, for the console log and
+// pkexec retries. Returns false when the job replaced one for the same
+// setting that had not started yet.
+func (q *CommandQueue) Submit(key string, run func(b Backend) (bool, string), done func(ok bool, out string, argv []string)) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	job := queuedJob{key: key, run: run, done: done}
+	if n := len(q.jobs); n > 0 && q.jobs[n-1].key == key {
+		q.jobs[n-1] = job
+		return false
+	}
+	q.jobs = append(q.jobs, job)
+	if !q.running {
+		q.running = true
+		go q.work()
+	}
+	return true
+}
+
+// work drains the queue, then exits; Submit starts it again when needed.
+func (q *CommandQueue) work() {
+	for {
+		q.mu.Lock()
+		if len(q.jobs) == 0 {
+			q.running = false
+			q.mu.Unlock()
+			return
+		}
+		job := q.jobs[0]
+		q.jobs = q.jobs[1:]
+		q.busy = true
+		q.mu.Unlock()
+
+		b := q.backend.Session()
+		ok, out := job.run(b)
+		argv := b.LastCommand()
+		q.mu.Lock()
+		q.busy = false
+		q.mu.Unlock()
+		q.done <- func() { job.done(ok, out, argv) }
+	}
+}
+
+// Pending is the number of jobs waiting or running.
+func (q *CommandQueue) Pending() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	n := len(q.jobs)
+	if q.busy {
+		n++
+	}
+	return n
+}
+
+// Wait gives queued writes up to d to finish, so quitting straight after a
+// key press does not drop the change.
+func (q *CommandQueue) Wait(d time.Duration) {
+	deadline := time.Now().Add(d)
+	for q.Pending() > 0 && time.Now().Before(deadline) {
+		time.Sleep(20 * time.Millisecond)
+	}
+}
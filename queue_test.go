@@ -0,0 +1,146 @@
+package main
+
+import (
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestQueueArgvIsTheJobs checks a job reports its own argv while other
+// goroutines keep calling the backend.
+func TestQueueArgvIsTheJobs(t *testing.T) {
+	m := NewMockBackend()
+	q := NewCommandQueue(m)
+	stop := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+				m.SetKbdBrightness("low")
+			}
+		}
+	}()
+	defer close(stop)
+
+	for i := 0; i < 20; i++ {
+		q.Submit("charge", func(b Backend) (bool, string) {
+			return b.SetChargeLimit(60)
+		}, func(ok bool, out string, argv []string) {
+			if !strings.Contains(strings.Join(argv, " "), "60") {
+				t.Errorf("argv = %q, want the charge limit write", argv)
+			}
+		})
+		select {
+		case done := <-q.Done():
+			done()
+		case <-time.After(5 * time.Second):
+			t.Fatal("no completion")
+		}
+	}
+}
+
+// TestQueueKeepsCompletions checks completions wait for the main loop
+// instead of being dropped.
+func TestQueueKeepsCompletions(t *testing.T) {
+	q := NewCommandQueue(NewMockBackend())
+	const n = 40
+	for i := 0; i < n; i++ {
+		q.Submit(string(rune('a'+i)), func(Backend) (bool, string) { return true, "" }, func(bool, string, []string) {})
+	}
+	for i := 0; i < n; i++ {
+		select {
+		case <-q.Done():
+		case <-time.After(5 * time.Second):
+			t.Fatalf("completion %d of %d lost", i+1, n)
+		}
+	}
+}
+
+// TestTabWritesQueued checks the tabs' writes go through the queue: the
+// App follows them once they complete, not before.
+func TestTabWritesQueued(t *testing.T) {
+	tests := []struct {
+		name  string
+		do    func(a *App)
+		state func(a *App) any
+	}{
+		{"touchpad", func(a *App) { a.toggleTouchpad() }, func(a *App) any { return a.touchpad.Enabled }},
+		{"keyboard sleep lighting", func(a *App) { a.toggleKbdSleepLighting() }, func(a *App) any { return a.kbdSleepLighting }},
+		{"slash", func(a *App) { a.setSlashEnabled(!a.slash.Enabled) }, func(a *App) any { return a.slash.Enabled }},
+		{"slash mode", func(a *App) { a.setSlashMode("Flow") }, func(a *App) any { return a.slash.Mode }},
+		{"panel overdrive", func(a *App) { a.setPanelOverdrive(!a.panel.od) }, func(a *App) any { return a.panel.od }},
+		{"screenpad", func(a *App) { a.setScreenPadPower(!a.panel.screenpad.On) }, func(a *App) any { return a.panel.screenpad.On }},
+		{"platform LED", func(a *App) { a.focusIdx = 1; a.togglePlatformLed() }, func(a *App) any { return a.platformLeds[0].On }},
+		{"GPU mode", func(a *App) { a.writeGfxMode("Integrated") }, func(a *App) any { return a.gfx.mode }},
+		{"fan curve", func(a *App) {
+			a.fanSpeeds[0] = fanPresets["full"]
+			a.HandleKey(KeyEvent{Type: KeyEnter})
+		}, func(a *App) any { return a.fanDirty }},
+		{"game mode", func(a *App) { a.setGameMode(true) }, func(a *App) any { return a.profile }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.GameMode = GameModeConfig{DisableSuper: true, Profile: "Performance"}
+			a := NewApp(NewFakeTerminal(80, 24, io.Discard), NewMockBackend(), cfg)
+			a.installed = true
+			a.switchTab(TabFans)
+			a.touchpad = a.backend.GetTouchpad()
+			a.slash = readSlashView(a.backend)
+			a.panel = readPanelView(a.backend)
+			a.platformLeds = a.backend.GetPlatformLeds()
+			a.readGfx()
+			a.fanDirty = true
+			before := tt.state(a)
+			tt.do(a)
+			if a.queue.Pending() == 0 {
+				t.Fatal("nothing queued")
+			}
+			if got := tt.state(a); got != before {
+				t.Fatalf("changed to %v before the write completed", got)
+			}
+			drainQueue(t, a)
+			if got := tt.state(a); got == before {
+				t.Errorf("still %v after the write", got)
+			}
+		})
+	}
+}
+
+// TestPresetAllFansOneJob checks Shift+preset writes every fan in one
+// queued job and reports each fan's result once it completes.
+func TestPresetAllFansOneJob(t *testing.T) {
+	m := NewMockBackend()
+	a := NewApp(NewFakeTerminal(80, 24, io.Discard), m, DefaultConfig())
+	a.installed = true
+	a.switchTab(TabFans)
+	a.HandleKey(KeyEvent{Type: KeyChar, Char: 'F'})
+	if n := a.queue.Pending(); n != 1 {
+		t.Fatalf("%d jobs queued, want 1", n)
+	}
+	drainQueue(t, a)
+	if want := "Preset Full Speed on all fans: CPU ✓  GPU ✓  MID ✓"; !strings.HasPrefix(a.statusMsg, want) {
+		t.Errorf("status %q, want %q", a.statusMsg, want)
+	}
+	fc, err := m.ReadFanCurves(a.profile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range a.fans() {
+		if fc.Speeds[i] != fanPresets["full"] {
+			t.Errorf("%s curve %v, want the full preset", fanNames[i], fc.Speeds[i])
+		}
+	}
+
+	m.mu.Lock()
+	m.daemon = "failed"
+	m.mu.Unlock()
+	a.HandleKey(KeyEvent{Type: KeyChar, Char: 'S'})
+	drainQueue(t, a)
+	if !strings.Contains(a.statusMsg, "CPU ✗  GPU ✗  MID ✗ — ") {
+		t.Errorf("status %q does not report the failed fans", a.statusMsg)
+	}
+}
@@ -0,0 +1,168 @@
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// Page: Quick panel — the everyday settings in one small box (--quick)
+// Meant to be bound to a hotkey in a dropdown terminal: ←→ changes a setting
+// right away and Esc closes. The writes go through the same paths and
+// policy checks as the tabs.
+// ═══════════════════════════════════════════════════════════════════════════════
+
+const (
+	quickRowProfile = iota
+	quickRowKbd
+	quickRowAura
+	quickRowCharge
+	quickRows
+)
+
+const (
+	quickW = 46
+	quickH = 13
+)
+
+func (a *App) renderQuick() {
+	t := a.term
+	W, H := t.Width(), t.Height()
+	w, h := min(quickW, W), min(quickH, H)
+	x, y := (W-w)/2, (H-h)/2
+
+	t.FillRect(0, 0, W, H, ColBg)
+	t.FillRect(x, y, w, h, ColCard)
+	t.Bg(ColCard)
+	t.DrawBox(x, y, w, h, a.accent())
+
+	t.ResetStyle()
+	t.Bg(ColCard)
+	t.Bold()
+	t.Fg(ColText)
+	t.MoveTo(x+2, y+1)
+	t.Write("AsusCtl Quick Panel")
+
+	if a.loading {
+		t.TextBg(x+2, y+3, ColTextDim, ColCard, "Reading hardware state…")
+		t.ResetStyle()
+		t.Flush()
+		return
+	}
+
+	lit := "on"
+	if a.kbdLevel == 0 {
+		lit = "off"
+	}
+	rows := [quickRows][2]string{
+		quickRowProfile: {"Profile", a.profile},
+		quickRowKbd:     {"Keyboard", kbdLabels[a.kbdLevel]},
+		quickRowAura:    {"Aura", auraModes[a.auraMode] + " · " + lit},
+		quickRowCharge:  {"Charge limit", fmt.Sprintf("%d%%", a.chargeLimit)},
+	}
+	for i, r := range rows {
+		ry := y + 3 + i*2
+		fg, mark := ColTextDim, "  "
+		if a.focusIdx == i {
+			fg, mark = a.accent(), "▸ "
+		}
+		t.ResetStyle()
+		t.Fg(fg)
+		t.Bg(ColCard)
+		t.MoveTo(x+2, ry)
+		a.writeFocused(mark + r[0])
+		val := "◂ " + r[1] + " ▸"
+		if a.focusIdx != i {
+			val = "  " + r[1]
+		}
+		t.TextBg(x+18, ry, ColText, ColCard, pad(val, w-20))
+	}
+
+	if a.statusMsg != "" && time.Since(a.statusTime) < 4*time.Second {
+		col := ColSuccess
+		if !a.statusOk {
+			col = ColError
+		}
+		msg := []rune(a.statusMsg)
+		if len(msg) > w-4 {
+			msg = append(msg[:w-5], '…')
+		}
+		t.TextBg(x+2, y+h-2, col, ColCard, string(msg))
+	} else {
+		t.TextBg(x+2, y+h-2, ColTextMut, ColCard, "←→ change  Enter lights on/off  Esc close")
+	}
+
+	if a.confirm != nil {
+		a.renderConfirm()
+	}
+	t.ResetStyle()
+	t.Flush()
+}
+
+func (a *App) handleQuick(key KeyEvent) {
+	if key.Type == KeyEscape || key.Type == KeyCtrlC || key.Type == KeyCtrlQ ||
+		(key.Type == KeyChar && key.Char == 'q') {
+		a.running = false
+		return
+	}
+	if a.loading {
+		return
+	}
+	switch key.Type {
+	case KeyUp:
+		a.focusIdx = (a.focusIdx + quickRows - 1) % quickRows
+	case KeyDown:
+		a.focusIdx = (a.focusIdx + 1) % quickRows
+	case KeyLeft:
+		a.quickAdjust(-1)
+	case KeyRight:
+		a.quickAdjust(1)
+	case KeyEnter:
+		if a.focusIdx == quickRowAura {
+			a.toggleLighting()
+		}
+	}
+}
+
+// quickAdjust steps the focused setting by dir and writes it.
+func (a *App) quickAdjust(dir int) {
+	switch a.focusIdx {
+	case quickRowProfile:
+		i := 1 // Balanced when the current profile is not in the list
+		for j, p := range profileNames {
+			if p == a.profile {
+				i = (j + dir + len(profileNames)) % len(profileNames)
+			}
+		}
+		a.selectProfile(profileNames[i])
+	case quickRowKbd:
+		a.setKbdLevel(clamp(a.kbdLevel+dir, 0, len(kbdValues)-1))
+	case quickRowAura:
+		if a.locked("aura") {
+			return
+		}
+		n := len(auraModes)
+		a.auraMode = (a.auraMode + dir + n) % n
+		for !a.auraModeOK(a.auraMode) { // skip effects the keyboard lacks
+			a.auraMode = (a.auraMode + dir + n) % n
+		}
+		a.applyAura()
+	case quickRowCharge:
+		if a.locked("charge_limit") {
+			return
+		}
+		a.chargeLimit = clamp(a.chargeLimit+5*dir, 20, 100)
+		a.applyChargeLimit()
+	}
+}
+
+// toggleLighting turns the keyboard backlight off, or back on at the level
+// it had before.
+func (a *App) toggleLighting() {
+	if a.kbdLevel > 0 {
+		a.litLevel = a.kbdLevel
+		a.setKbdLevel(0)
+		return
+	}
+	a.setKbdLevel(max(a.litLevel, 1))
+}
@@ -0,0 +1,23 @@
+package main
+
+import (
+	"io"
+	"testing"
+)
+
+// TestQuickAdjustLocked checks a locked setting keeps its value on the
+// quick panel instead of showing a change that was never written.
+func TestQuickAdjustLocked(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Lockdown.Actions = []string{"aura", "charge_limit"}
+	a := NewApp(NewFakeTerminal(80, 24, io.Discard), NewMockBackend(), cfg)
+	mode, limit := a.auraMode, a.chargeLimit
+	for _, row := range []int{quickRowAura, quickRowCharge} {
+		a.focusIdx = row
+		a.quickAdjust(-1)
+	}
+	drainQueue(t, a)
+	if a.auraMode != mode || a.chargeLimit != limit {
+		t.Errorf("aura %d→%d, limit %d→%d under lockdown", mode, a.auraMode, limit, a.chargeLimit)
+	}
+}
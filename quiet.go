@@ -0,0 +1,253 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// Quiet hours — Quiet profile and capped fans during a nightly window
+// On entry the profile switches to Quiet and its fan curves are capped at
+// max_fan; both are put back when the window ends, on override and on quit.
+// Only the custom curves are capped: with them off the firmware's own Quiet
+// curve applies, which is already the quietest the laptop offers.
+// ═══════════════════════════════════════════════════════════════════════════════
+
+const quietProfile = "Quiet"
+
+type quietState struct {
+	active      bool   // inside the window and enforcing
+	overridden  bool   // enforcement suspended until the window ends
+	prevProfile string // profile to return to afterwards
+	saved       *FanCurves
+}
+
+// parseClock reads "HH:MM" as minutes after midnight.
+func parseClock(s string) (int, error) {
+	h, m, ok := strings.Cut(s, ":")
+	hh, herr := strconv.Atoi(h)
+	mm, merr := strconv.Atoi(m)
+	if !ok || herr != nil || merr != nil || hh < 0 || hh > 23 || mm < 0 || mm > 59 {
+		return 0, fmt.Errorf("invalid time %q, want HH:MM", s)
+	}
+	return hh*60 + mm, nil
+}
+
+func (q QuietHoursConfig) Enabled() bool { return q.Start != "" && q.End != "" }
+
+func (q QuietHoursConfig) Validate() error {
+	if !q.Enabled() {
+		return nil
+	}
+	start, err := parseClock(q.Start)
+	if err != nil {
+		return fmt.Errorf("quiet_hours.start: %w", err)
+	}
+	end, err := parseClock(q.End)
+	if err != nil {
+		return fmt.Errorf("quiet_hours.end: %w", err)
+	}
+	if start == end {
+		return fmt.Errorf("quiet_hours: start and end are both %s", q.Start)
+	}
+	if q.MaxFan < 0 || q.MaxFan > 100 {
+		return fmt.Errorf("quiet_hours.max_fan: %d is not a percentage", q.MaxFan)
+	}
+	return nil
+}
+
+// Contains reports whether t falls inside the window.
+func (q QuietHoursConfig) Contains(t time.Time) bool {
+	if !q.Enabled() {
+		return false
+	}
+	start, err1 := parseClock(q.Start)
+	end, err2 := parseClock(q.End)
+	if err1 != nil || err2 != nil {
+		return false
+	}
+	m := t.Hour()*60 + t.Minute()
+	if start < end {
+		return m >= start && m < end
+	}
+	return m >= start || m < end // spans midnight
+}
+
+// capCurves limits every point to max percent.
+func capCurves(speeds [3][8]int, max int) [3][8]int {
+	for i := range speeds {
+		for p := range speeds[i] {
+			speeds[i][p] = min(speeds[i][p], max)
+		}
+	}
+	return speeds
+}
+
+// checkQuietHours starts, keeps up or ends enforcement for the time now.
+func (a *App) checkQuietHours(now time.Time) {
+	q := &a.quiet
+	in := a.cfg.QuietHours.Contains(now)
+	switch {
+	case !in:
+		q.overridden = false
+		if q.active {
+			a.endQuietHours()
+			a.SetStatus("Quiet hours over — "+a.profile+" profile restored", true)
+		}
+	case q.overridden:
+	case !q.active:
+		a.beginQuietHours()
+		a.SetStatus("Quiet hours until "+a.cfg.QuietHours.End, true)
+	case a.profile != quietProfile:
+		// switched away from outside the TUI; hold the line
+		a.setQuietProfile(quietProfile)
+	}
+}
+
+func (a *App) beginQuietHours() {
+	q := &a.quiet
+	q.active = true
+	q.prevProfile = a.profile
+	a.setQuietProfile(quietProfile)
+
+	fc, err := a.backend.ReadFanCurves(quietProfile)
+	if err != nil {
+		a.automationLog("quiet hours: read fan curves", err.Error(), false)
+		return
+	}
+	capped := capCurves(fc.Speeds, a.cfg.QuietHours.MaxFan)
+	if capped == fc.Speeds {
+		return
+	}
+	q.saved = &fc
+	a.writeQuietCurves(fc.Temps, capped)
+}
+
+// endQuietHours puts back the curves and profile beginQuietHours changed.
+func (a *App) endQuietHours() {
+	q := &a.quiet
+	if !q.active {
+		return
+	}
+	q.active = false
+	if q.saved != nil {
+		a.writeQuietCurves(q.saved.Temps, q.saved.Speeds)
+		q.saved = nil
+	}
+	if q.prevProfile != "" && q.prevProfile != quietProfile && a.profile == quietProfile {
+		a.setQuietProfile(q.prevProfile)
+	}
+}
+
+func (a *App) setQuietProfile(p string) {
+	if a.profile == p {
+		return
+	}
+	a.submit("profile", func(b Backend) (bool, string) {
+		return b.SetProfile(p)
+	}, func(ok bool, out string, _ []string) {
+		a.automationLog("quiet hours: profile "+p, out, ok)
+		if ok {
+			a.profile = p
+			a.loadFanCurves()
+			a.followProfile()
+		}
+	})
+}
+
+// writeQuietCurves queues the Quiet profile's curves as one job; each
+// fan's result is logged.
+func (a *App) writeQuietCurves(temps, speeds [3][8]int) {
+	fans := a.fans()
+	oks, outs := make([]bool, len(fans)), make([]string, len(fans))
+	a.submit("quiet_curves", func(b Backend) (bool, string) {
+		for i, fan := range fans {
+			oks[i], outs[i] = b.SetFanCurve(fan, quietProfile, FormatFanCurve(temps[i][:], speeds[i][:]))
+		}
+		return true, ""
+	}, func(bool, string, []string) {
+		for i, fan := range fans {
+			a.automationLog("quiet hours: "+fan+" fan curve", outs[i], oks[i])
+		}
+		if a.profile == quietProfile && !a.fanDirty {
+			a.loadFanCurves()
+		}
+	})
+}
+
+// quietCapFor returns the speeds a fan curve write may use: capped while
+// quiet hours hold the Quiet profile. The uncapped curve is remembered so
+// the end of the window restores the user's latest edit.
+func (a *App) quietCapFor(fanIdx int, profile string, speeds [8]int) [8]int {
+	q := &a.quiet
+	if !q.active || profile != quietProfile {
+		return speeds
+	}
+	if q.saved == nil {
+		q.saved = &FanCurves{Temps: a.fanTemps, Speeds: a.fanSpeeds}
+	}
+	q.saved.Temps[fanIdx], q.saved.Speeds[fanIdx] = a.fanTemps[fanIdx], speeds
+	for p := range speeds {
+		speeds[p] = min(speeds[p], a.cfg.QuietHours.MaxFan)
+	}
+	return speeds
+}
+
+// toggleQuietOverride suspends enforcement for the rest of the window, or
+// resumes it.
+func (a *App) toggleQuietOverride() {
+	q := &a.quiet
+	switch {
+	case q.active:
+		if a.locked("quiet_override") {
+			return
+		}
+		a.endQuietHours()
+		q.overridden = true
+		a.SetStatus("Quiet hours overridden until "+a.cfg.QuietHours.End, true)
+	case q.overridden:
+		q.overridden = false
+		a.beginQuietHours()
+		a.SetStatus("Quiet hours resumed until "+a.cfg.QuietHours.End, true)
+	case a.cfg.QuietHours.Enabled():
+		a.SetStatus("Quiet hours start at "+a.cfg.QuietHours.Start, true)
+	default:
+		a.SetStatus("No quiet hours configured ([quiet_hours] in config.toml)", false)
+	}
+}
+
+// quietBannerH is the banner's height: one row while quiet hours are
+// active or overridden.
+func (a *App) quietBannerH() int {
+	if !a.quiet.active && !a.quiet.overridden {
+		return 0
+	}
+	return 1
+}
+
+// renderQuietBanner draws the one-line banner at y while quiet hours are
+// active or overridden. Returns the rows used.
+func (a *App) renderQuietBanner(y int) int {
+	q := &a.quiet
+	if a.quietBannerH() == 0 {
+		return 0
+	}
+	t := a.term
+	W := t.Width()
+	qh := a.cfg.QuietHours
+	msg := fmt.Sprintf(" Quiet hours until %s · %s profile, fans capped at %d%% · Ctrl-O override", qh.End, quietProfile, qh.MaxFan)
+	col := ColWarning
+	if q.overridden {
+		msg = fmt.Sprintf(" Quiet hours overridden until %s · Ctrl-O resume", qh.End)
+		col = ColTextDim
+	}
+	t.ResetStyle()
+	t.Bg(ColPanel)
+	t.Fg(col)
+	t.MoveTo(0, y)
+	t.Write(pad(msg, W))
+	t.ResetStyle()
+	return 1
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"testing"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// Renderer benchmarks — full frames into a headless FakeTerminal
+// Run with: go test -run '^$' -bench . -benchmem
+// ═══════════════════════════════════════════════════════════════════════════════
+
+var benchSizes = []struct{ w, h int }{
+	{80, 24},
+	{120, 40},
+	{200, 60},
+}
+
+// countWriter discards output but remembers how many bytes the last frame had.
+type countWriter struct{ n int }
+
+func (c *countWriter) Write(p []byte) (int, error) {
+	c.n += len(p)
+	return len(p), nil
+}
+
+func newBenchApp(w, h int, out io.Writer) *App {
+	app := NewApp(NewFakeTerminal(w, h, out), NewBackend(), DefaultConfig())
+	app.installed = true
+	for i := 0; i < 6; i++ {
+		app.addLog(fmt.Sprintf("profile get %d", i), "Active profile is Balanced", i%2 == 0)
+	}
+	return app
+}
+
+func BenchmarkRenderTabs(b *testing.B) {
+	for tab := Tab(0); tab < TabCount; tab++ {
+		for _, sz := range benchSizes {
+			name := fmt.Sprintf("%s/%dx%d", tabNames[tab], sz.w, sz.h)
+			b.Run(name, func(b *testing.B) {
+				cw := &countWriter{}
+				app := newBenchApp(sz.w, sz.h, cw)
+				app.activeTab = tab
+				app.Render()
+				b.SetBytes(int64(cw.n))
+				b.ReportAllocs()
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					app.Render()
+				}
+			})
+		}
+	}
+}
+
+// ─── Drawing helpers ─────────────────────────────────────────────────────────
+
+func BenchmarkDrawBox(b *testing.B) {
+	t := NewFakeTerminal(120, 40, io.Discard)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		t.Clear()
+		t.DrawBox(0, 0, 120, 40, ColBorder)
+	}
+}
+
+func BenchmarkFillRect(b *testing.B) {
+	t := NewFakeTerminal(120, 40, io.Discard)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		t.Clear()
+		t.FillRect(0, 0, 120, 40, ColBg)
+	}
+}
+
+func BenchmarkDrawBar(b *testing.B) {
+	t := NewFakeTerminal(120, 40, io.Discard)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		t.Clear()
+		t.DrawBar(0, 0, 100, float64(i%100)/100, ColAccent, ColInput)
+	}
+}
+
+func BenchmarkBigText(b *testing.B) {
+	t := NewFakeTerminal(120, 40, io.Discard)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		t.Clear()
+		t.DrawBigGauge(0, 0, 30, fmt.Sprintf("%d°C", 40+i%60), "CPU", ColAccent)
+	}
+}
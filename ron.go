@@ -0,0 +1,318 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// RON — the subset asusd's /etc/asusd/*.ron configs use, stdlib only.
+// Supports: structs "(a: 1)", named structs and enum variants "Some(x)",
+// tuples, lists, maps, strings, numbers, identifiers and // comments.
+// Documents are edited as a tree and written back in asusd's pretty layout;
+// comments are not preserved.
+// ═══════════════════════════════════════════════════════════════════════════════
+
+type ronKind int
+
+const (
+	ronScalar ronKind = iota // number, string, bool or bare identifier
+	ronStruct                // (key: value, …)
+	ronTuple                 // (value, …)
+	ronList                  // [value, …]
+	ronMap                   // {key: value, …}
+)
+
+type ronValue struct {
+	Kind   ronKind
+	Name   string // struct/tuple prefix such as "Some"; the token for scalars
+	Fields []ronField
+	Items  []*ronValue
+}
+
+// ronField is a struct field or map entry. Keys are kept as written.
+type ronField struct {
+	Key   string
+	Value *ronValue
+}
+
+func ronIdent(s string) *ronValue { return &ronValue{Kind: ronScalar, Name: s} }
+func ronInt(n int) *ronValue      { return ronIdent(fmt.Sprint(n)) }
+
+// Field returns the value under key in a struct or map, or nil.
+func (v *ronValue) Field(key string) *ronValue {
+	if v == nil {
+		return nil
+	}
+	for _, f := range v.Fields {
+		if f.Key == key {
+			return f.Value
+		}
+	}
+	return nil
+}
+
+// items are the entries of a list or tuple; nil for anything else.
+func (v *ronValue) items() []*ronValue {
+	if v == nil {
+		return nil
+	}
+	return v.Items
+}
+
+// name is a scalar's token, or "" for a missing value.
+func (v *ronValue) name() string {
+	if v == nil {
+		return ""
+	}
+	return v.Name
+}
+
+// SetField replaces the value under key, adding it at the end if missing.
+func (v *ronValue) SetField(key string, val *ronValue) {
+	for i, f := range v.Fields {
+		if f.Key == key {
+			v.Fields[i].Value = val
+			return
+		}
+	}
+	v.Fields = append(v.Fields, ronField{key, val})
+}
+
+// ─── Decoding ────────────────────────────────────────────────────────────────
+
+type ronParser struct {
+	src  []rune
+	pos  int
+	line int
+}
+
+// parseRon parses a whole document.
+func parseRon(src string) (*ronValue, error) {
+	p := &ronParser{src: []rune(src), line: 1}
+	v, err := p.value()
+	if err != nil {
+		return nil, err
+	}
+	p.skip()
+	if p.pos < len(p.src) {
+		return nil, p.errorf("unexpected %q after value", p.src[p.pos])
+	}
+	return v, nil
+}
+
+func (p *ronParser) errorf(format string, args ...any) error {
+	return fmt.Errorf("ron line %d: %s", p.line, fmt.Sprintf(format, args...))
+}
+
+// skip passes over whitespace and comments.
+func (p *ronParser) skip() {
+	for p.pos < len(p.src) {
+		c := p.src[p.pos]
+		switch {
+		case c == '\n':
+			p.line++
+			p.pos++
+		case unicode.IsSpace(c):
+			p.pos++
+		case c == '/' && p.pos+1 < len(p.src) && p.src[p.pos+1] == '/':
+			for p.pos < len(p.src) && p.src[p.pos] != '\n' {
+				p.pos++
+			}
+		default:
+			return
+		}
+	}
+}
+
+func (p *ronParser) peek() rune {
+	p.skip()
+	if p.pos >= len(p.src) {
+		return 0
+	}
+	return p.src[p.pos]
+}
+
+func (p *ronParser) expect(c rune) error {
+	if p.peek() != c {
+		return p.errorf("expected %q", c)
+	}
+	p.pos++
+	return nil
+}
+
+func (p *ronParser) value() (*ronValue, error) {
+	switch c := p.peek(); {
+	case c == 0:
+		return nil, p.errorf("unexpected end of input")
+	case c == '(':
+		return p.group("")
+	case c == '[':
+		p.pos++
+		v := &ronValue{Kind: ronList}
+		err := p.items(']', func() error {
+			item, err := p.value()
+			v.Items = append(v.Items, item)
+			return err
+		})
+		return v, err
+	case c == '{':
+		p.pos++
+		v := &ronValue{Kind: ronMap}
+		err := p.items('}', func() error {
+			key, err := p.value()
+			if err != nil {
+				return err
+			}
+			if err := p.expect(':'); err != nil {
+				return err
+			}
+			val, err := p.value()
+			v.Fields = append(v.Fields, ronField{encodeRon(key, 0), val})
+			return err
+		})
+		return v, err
+	case c == '"':
+		return p.str()
+	default:
+		tok := p.token()
+		if tok == "" {
+			return nil, p.errorf("unexpected %q", c)
+		}
+		if p.pos < len(p.src) && p.src[p.pos] == '(' {
+			return p.group(tok)
+		}
+		return ronIdent(tok), nil
+	}
+}
+
+// group parses "(…)" as a struct when the first entry is "key:", else as
+// a tuple.
+func (p *ronParser) group(name string) (*ronValue, error) {
+	p.pos++ // (
+	v := &ronValue{Kind: ronTuple, Name: name}
+	first := true
+	err := p.items(')', func() error {
+		if first {
+			first = false
+			save, line := p.pos, p.line
+			if key := p.token(); key != "" && p.peek() == ':' {
+				v.Kind = ronStruct
+			}
+			p.pos, p.line = save, line
+		}
+		if v.Kind == ronStruct {
+			p.skip()
+			key := p.token()
+			if key == "" {
+				return p.errorf("expected field name")
+			}
+			if err := p.expect(':'); err != nil {
+				return err
+			}
+			val, err := p.value()
+			v.Fields = append(v.Fields, ronField{key, val})
+			return err
+		}
+		item, err := p.value()
+		v.Items = append(v.Items, item)
+		return err
+	})
+	return v, err
+}
+
+// items calls each for every comma-separated entry up to close; a trailing
+// comma is allowed.
+func (p *ronParser) items(close rune, each func() error) error {
+	for {
+		if p.peek() == close {
+			p.pos++
+			return nil
+		}
+		if err := each(); err != nil {
+			return err
+		}
+		switch p.peek() {
+		case ',':
+			p.pos++
+		case close:
+		default:
+			return p.errorf("expected ',' or %q", close)
+		}
+	}
+}
+
+// token reads an identifier or number.
+func (p *ronParser) token() string {
+	start := p.pos
+	for p.pos < len(p.src) {
+		c := p.src[p.pos]
+		if !unicode.IsLetter(c) && !unicode.IsDigit(c) && c != '_' && c != '.' && c != '-' && c != '+' {
+			break
+		}
+		p.pos++
+	}
+	return string(p.src[start:p.pos])
+}
+
+func (p *ronParser) str() (*ronValue, error) {
+	start := p.pos
+	p.pos++ // opening quote
+	for p.pos < len(p.src) {
+		switch p.src[p.pos] {
+		case '\\':
+			p.pos++
+		case '"':
+			p.pos++
+			return ronIdent(string(p.src[start:p.pos])), nil
+		case '\n':
+			p.line++
+		}
+		p.pos++
+	}
+	return nil, p.errorf("unterminated string")
+}
+
+// ─── Encoding ────────────────────────────────────────────────────────────────
+
+const ronIndent = "    "
+
+// encodeRon writes v in asusd's pretty layout: one entry per line, trailing
+// commas. Entries that are all scalars stay on one line.
+func encodeRon(v *ronValue, depth int) string {
+	if v.Kind == ronScalar {
+		return v.Name
+	}
+	open, close := "(", ")"
+	switch v.Kind {
+	case ronList:
+		open, close = "[", "]"
+	case ronMap:
+		open, close = "{", "}"
+	}
+	var parts []string
+	flat := true
+	for _, f := range v.Fields {
+		parts = append(parts, f.Key+": "+encodeRon(f.Value, depth+1))
+		flat = flat && f.Value.Kind == ronScalar
+	}
+	for _, it := range v.Items {
+		parts = append(parts, encodeRon(it, depth+1))
+		flat = flat && it.Kind == ronScalar
+	}
+	if len(parts) == 0 {
+		return v.Name + open + close
+	}
+	if flat && (v.Kind == ronTuple || len(parts) <= 4) && v.Kind != ronMap {
+		return v.Name + open + strings.Join(parts, ", ") + close
+	}
+	in := strings.Repeat(ronIndent, depth+1)
+	var b strings.Builder
+	b.WriteString(v.Name + open + "\n")
+	for _, s := range parts {
+		b.WriteString(in + s + ",\n")
+	}
+	b.WriteString(strings.Repeat(ronIndent, depth) + close)
+	return b.String()
+}
@@ -0,0 +1,160 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+// auraRonSample is the shape of asusd 6's aura_*.ron, trimmed to two effects.
+const auraRonSample = `(
+    config_name: "aura_19b6.ron",
+    brightness: Med,
+    current_mode: Static,
+    // per-effect settings
+    builtins: {
+        Static: (
+            mode: Static,
+            zone: None,
+            colour1: (r: 255, g: 0, b: 0),
+            colour2: (r: 0, g: 0, b: 0),
+            speed: Med,
+            direction: Right,
+        ),
+        RainbowWave: (
+            mode: RainbowWave,
+            zone: None,
+            colour1: (r: 255, g: 0, b: 0),
+            colour2: (r: 0, g: 0, b: 0),
+            speed: Med,
+            direction: Right,
+        ),
+    },
+    multizone: None,
+    multizone_on: false,
+    enabled: (states: [(zone: Keyboard, boot: true, awake: true), (zone: Logo, boot: true, awake: false)]),
+)`
+
+func TestParseRon(t *testing.T) {
+	doc, err := parseRon(auraRonSample)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if doc.Kind != ronStruct {
+		t.Fatalf("document kind = %v, want struct", doc.Kind)
+	}
+	for _, c := range []struct {
+		v    *ronValue
+		want string
+	}{
+		{doc.Field("config_name"), `"aura_19b6.ron"`},
+		{doc.Field("current_mode"), "Static"},
+		{doc.Field("builtins").Field("Static").Field("colour1").Field("r"), "255"},
+		{doc.Field("builtins").Field("RainbowWave").Field("direction"), "Right"},
+		{doc.Field("multizone_on"), "false"},
+	} {
+		if c.v == nil || c.v.Name != c.want {
+			t.Errorf("got %+v, want %s", c.v, c.want)
+		}
+	}
+	states := doc.Field("enabled").Field("states")
+	if states == nil || states.Kind != ronList || len(states.Items) != 2 {
+		t.Fatalf("enabled.states = %+v, want a list of 2", states)
+	}
+	if got := states.Items[1].Field("zone").Name; got != "Logo" {
+		t.Errorf("states[1].zone = %s, want Logo", got)
+	}
+	if doc.Field("missing") != nil {
+		t.Error("missing field is not nil")
+	}
+}
+
+func TestParseRonErrors(t *testing.T) {
+	for _, src := range []string{
+		"",
+		"(a: 1",
+		"(a: 1) x",
+		`(a: "open)`,
+		"(a 1)",
+		"[1, 2",
+		"{a: }",
+	} {
+		if _, err := parseRon(src); err == nil {
+			t.Errorf("parseRon(%q) = nil error", src)
+		}
+	}
+	_, err := parseRon("(\n  a: 1,\n  b: ]\n)")
+	if err == nil || !strings.Contains(err.Error(), "line 3") {
+		t.Errorf("error %v does not name line 3", err)
+	}
+}
+
+func TestEncodeRonRoundTrip(t *testing.T) {
+	doc, err := parseRon(auraRonSample)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := encodeRon(doc, 0)
+	if strings.Contains(out, "//") {
+		t.Error("comments are written back")
+	}
+	again, err := parseRon(out)
+	if err != nil {
+		t.Fatalf("encoded document does not parse: %v\n%s", err, out)
+	}
+	if encodeRon(again, 0) != out {
+		t.Errorf("encoding is not stable:\n%s\n---\n%s", out, encodeRon(again, 0))
+	}
+}
+
+func TestEncodeRonLayout(t *testing.T) {
+	for _, c := range []struct{ src, want string }{
+		{"(r: 1, g: 2, b: 3)", "(r: 1, g: 2, b: 3)"},
+		{"Some(Static)", "Some(Static)"},
+		{"[]", "[]"},
+		{"[1, 2, 3]", "[1, 2, 3]"},
+		{"(a: (b: 1))", "(\n    a: (b: 1),\n)"},
+		{"{a: 1}", "{\n    a: 1,\n}"},
+	} {
+		doc, err := parseRon(c.src)
+		if err != nil {
+			t.Fatalf("%s: %v", c.src, err)
+		}
+		if got := encodeRon(doc, 0); got != c.want {
+			t.Errorf("encodeRon(%s) = %q, want %q", c.src, got, c.want)
+		}
+	}
+}
+
+func TestSetAuraRon(t *testing.T) {
+	doc, err := parseRon(auraRonSample)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := setAuraRon(doc, "Rainbow Wave", "1e90ff", "", "high", "left"); err != nil {
+		t.Fatal(err)
+	}
+	wave := doc.Field("builtins").Field("RainbowWave")
+	if got := doc.Field("current_mode").Name; got != "RainbowWave" {
+		t.Errorf("current_mode = %s, want RainbowWave", got)
+	}
+	c1 := wave.Field("colour1")
+	if c1.Field("r").Name != "30" || c1.Field("g").Name != "144" || c1.Field("b").Name != "255" {
+		t.Errorf("colour1 = %s, want (r: 30, g: 144, b: 255)", encodeRon(c1, 0))
+	}
+	if got := encodeRon(wave.Field("colour2"), 0); got != "(r: 0, g: 0, b: 0)" {
+		t.Errorf("empty colour2 changed it to %s", got)
+	}
+	if wave.Field("speed").Name != "High" || wave.Field("direction").Name != "Left" {
+		t.Errorf("speed, direction = %s, %s; want High, Left", wave.Field("speed").Name, wave.Field("direction").Name)
+	}
+	if got := doc.Field("builtins").Field("Static").Field("speed").Name; got != "Med" {
+		t.Errorf("Static's speed changed to %s", got)
+	}
+
+	if err := setAuraRon(doc, "Stars", "", "", "", ""); err == nil {
+		t.Error("an effect missing from the config was accepted")
+	}
+	if err := setAuraRon(doc, "Static", "zzzzzz", "", "", ""); err == nil {
+		t.Error("an invalid colour was accepted")
+	}
+}
@@ -0,0 +1,227 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// Rules — [[rules]] from the config: a trigger (on battery, on AC, profile
+// changed, battery below N%) and the actions it runs. A goroutine posts
+// checkRules, which compares the state with the last check and fires the
+// rules whose trigger changed. The first check only records the state, so
+// starting the app fires nothing. Actions are queued like the other
+// policies' writes: quiet hours and lockdown hold them back.
+// ═══════════════════════════════════════════════════════════════════════════════
+
+const rulesEvery = 5 * time.Second
+
+var ruleTriggers = []string{"battery", "ac", "profile", "battery_below"}
+
+type rulesState struct {
+	read    bool   // the fields below hold a check to diff against
+	profile string // a.profile at the last check
+	ac      bool
+	acRead  bool // ac holds a reading
+	percent int
+	fired   map[int]string // rule index → time it last ran
+}
+
+func (r Rule) Validate() error {
+	if indexFold(ruleTriggers, r.When) < 0 {
+		return fmt.Errorf("when: %q must be one of %s", r.When, strings.Join(ruleTriggers, ", "))
+	}
+	if r.Profile != "" && matchProfile(r.Profile) == "" {
+		return fmt.Errorf("profile: unknown profile %q", r.Profile)
+	}
+	if r.When == "battery_below" && (r.Below < 1 || r.Below > 99) {
+		return errors.New("below: must be 1-99")
+	}
+	if r.SetProfile != "" && matchProfile(r.SetProfile) == "" {
+		return fmt.Errorf("set_profile: unknown profile %q", r.SetProfile)
+	}
+	if r.ChargeLimit != 0 && (r.ChargeLimit < 20 || r.ChargeLimit > 100) {
+		return fmt.Errorf("charge_limit: %d must be 20-100", r.ChargeLimit)
+	}
+	if r.Keyboard != "" && indexFold(kbdValues, r.Keyboard) < 0 {
+		return fmt.Errorf("keyboard: %q must be off, low, med or high", r.Keyboard)
+	}
+	if r.AuraMode != "" && indexFold(auraModes, r.AuraMode) < 0 {
+		return fmt.Errorf("aura_mode: unknown effect %q", r.AuraMode)
+	}
+	if _, _, _, ok := parseHexColour(r.Colour); r.Colour != "" && !ok {
+		return fmt.Errorf("colour: %q is not an rrggbb colour", r.Colour)
+	}
+	if r.SetProfile == "" && r.ChargeLimit == 0 && r.Keyboard == "" && r.AuraMode == "" {
+		return errors.New("rule does nothing; set set_profile, charge_limit, keyboard or aura_mode")
+	}
+	return nil
+}
+
+// Trigger describes when the rule fires, for the Automation tab.
+func (r Rule) Trigger() string {
+	switch r.When {
+	case "battery":
+		return "on battery"
+	case "ac":
+		return "on AC"
+	case "profile":
+		if p := matchProfile(r.Profile); p != "" {
+			return "profile → " + p
+		}
+		return "profile changed"
+	}
+	return fmt.Sprintf("battery < %d%%", r.Below)
+}
+
+// Describe is the one-line summary shown on the Automation tab.
+func (r Rule) Describe() string {
+	var acts []string
+	if p := matchProfile(r.SetProfile); p != "" {
+		acts = append(acts, p+" profile")
+	}
+	if r.ChargeLimit > 0 {
+		acts = append(acts, fmt.Sprintf("charge limit %d%%", r.ChargeLimit))
+	}
+	if i := indexFold(kbdValues, r.Keyboard); i >= 0 {
+		acts = append(acts, "keyboard "+strings.ToLower(kbdLabels[i]))
+	}
+	if i := indexFold(auraModes, r.AuraMode); i >= 0 {
+		acts = append(acts, "Aura "+auraModes[i])
+	}
+	return r.Trigger() + " → " + strings.Join(acts, ", ")
+}
+
+// watchRules reads the battery and posts checkRules with it until stop is
+// closed. It runs whether or not rules are set, so rules added by a config
+// reload start firing without a restart.
+func (a *App) watchRules(stop chan struct{}) {
+	tick := time.NewTicker(rulesEvery)
+	defer tick.Stop()
+	for {
+		bat := a.backend.GetBatteryStatus()
+		a.Post(func() { a.checkRules(bat) })
+		select {
+		case <-stop:
+			return
+		case <-tick.C:
+		}
+	}
+}
+
+// checkRules fires the rules whose trigger changed since the last check.
+// The AC state comes from the power source poller.
+func (a *App) checkRules(bat BatteryStatus) {
+	rs := &a.rules
+	ac := a.automation.acOnline
+	prev := *rs
+	rs.profile, rs.ac, rs.acRead, rs.percent = a.profile, ac, a.automation.acRead, bat.Percent
+	if !prev.read {
+		rs.read = true
+		return
+	}
+	for i, r := range a.cfg.Rules {
+		if !a.ruleActive(r) {
+			continue
+		}
+		fire := false
+		switch r.When {
+		case "battery":
+			fire = prev.acRead && prev.ac && !ac
+		case "ac":
+			fire = prev.acRead && !prev.ac && ac
+		case "profile":
+			want := matchProfile(r.Profile)
+			fire = a.profile != prev.profile && (want == "" || want == a.profile)
+		case "battery_below":
+			fire = bat.Present && prev.percent >= r.Below && bat.Percent < r.Below
+		}
+		if fire {
+			a.runRule(i, r)
+		}
+	}
+}
+
+// runRule queues one rule's actions, logging each to the Automation tab as
+// it completes. The App follows a write only once it succeeded.
+func (a *App) runRule(i int, r Rule) {
+	if a.rules.fired == nil {
+		a.rules.fired = map[int]string{}
+	}
+	a.rules.fired[i] = time.Now().Format("15:04:05")
+	what := "rule " + r.Trigger()
+	if p := matchProfile(r.SetProfile); p != "" && p != a.profile {
+		a.holdAuraFollow(p)
+		// Changes made by the rules themselves are not triggers
+		a.automationProfile(what, p, func() { a.rules.profile = p })
+	}
+	locked := func(action, desc string) bool {
+		if a.cfg.Lockdown.ActionLocked(action) {
+			a.automationLog(what+": "+desc, lockActions[action]+" is disabled by your administrator", false)
+			return true
+		}
+		return false
+	}
+	if pct := r.ChargeLimit; pct > 0 && !locked("charge_limit", "charge limit") {
+		a.submit("charge_limit", func(b Backend) (bool, string) {
+			return b.SetChargeLimit(pct)
+		}, func(ok bool, out string, argv []string) {
+			a.automationLog(fmt.Sprintf("%s: charge limit %d%%", what, pct), out, ok)
+			if ok {
+				a.chargeLimit, a.chargeLimitSet = pct, pct
+			}
+		})
+	}
+	if lvl := indexFold(kbdValues, r.Keyboard); lvl >= 0 {
+		a.submit("kbd", func(b Backend) (bool, string) {
+			return b.SetKbdBrightness(kbdValues[lvl])
+		}, func(ok bool, out string, argv []string) {
+			a.automationLog(what+": keyboard "+kbdValues[lvl], out, ok)
+			if ok {
+				a.kbdLevel = lvl
+			}
+		})
+	}
+	if m := indexFold(auraModes, r.AuraMode); m >= 0 && !locked("aura", "Aura") {
+		a.runRuleAura(what, m, r.Colour)
+	}
+}
+
+// runRuleAura queues effect m of auraModes with colour, or the Aura tab's
+// colours when colour is empty, and selects it on the tab once applied.
+func (a *App) runRuleAura(what string, m int, colour string) {
+	mode := auraModes[m]
+	var colour1, colour2, speed, direction string
+	red, green, blue, custom := parseHexColour(colour)
+	if auraEffectNeedsColour1(mode) {
+		colour1 = a.selectedAuraColour(0).Hex
+		if custom {
+			colour1 = fmt.Sprintf("%02x%02x%02x", red, green, blue)
+		}
+	}
+	if auraEffectNeedsColour2(mode) {
+		colour2 = a.selectedAuraColour(1).Hex
+	}
+	if auraEffectNeedsSpeed(mode) {
+		speed = auraSpeeds[a.auraSpeed]
+	}
+	if auraEffectNeedsDirection(mode) {
+		direction = auraDirections[a.auraDirection]
+	}
+	a.submit("aura", func(b Backend) (bool, string) {
+		return b.SetAuraMode(mode, colour1, colour2, speed, direction)
+	}, func(ok bool, out string, argv []string) {
+		a.automationLog(what+": Aura "+mode, out, ok)
+		if !ok {
+			return
+		}
+		a.auraMode = m
+		if custom {
+			a.auraColour1 = a.matchAuraColour(0, red, green, blue)
+		}
+		a.auraAppliedZones = nil
+		a.readAuraApplied()
+	})
+}
@@ -0,0 +1,185 @@
+package main
+
+import (
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRulesParse(t *testing.T) {
+	var cfg Config
+	err := decodeToml(`
+[[rules]]
+when = "battery"
+set_profile = "quiet"
+charge_limit = 60
+
+[[rules]]
+when = "battery_below"
+below = 20
+keyboard = "off"
+aura_mode = "Static"
+colour = "ff8800"
+workspace = "Office"
+`, &cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cfg.Rules) != 2 {
+		t.Fatalf("got %d rules, want 2", len(cfg.Rules))
+	}
+	r := cfg.Rules[1]
+	if r.When != "battery_below" || r.Below != 20 || r.Keyboard != "off" || r.Colour != "ff8800" || r.Workspace != "Office" {
+		t.Errorf("rules[1] = %+v", r)
+	}
+	for i, r := range cfg.Rules {
+		if err := r.Validate(); err != nil {
+			t.Errorf("rules[%d]: %v", i, err)
+		}
+	}
+	if got, want := cfg.Rules[0].Describe(), "on battery → Quiet profile, charge limit 60%"; got != want {
+		t.Errorf("Describe = %q, want %q", got, want)
+	}
+}
+
+func TestRuleValidateErrors(t *testing.T) {
+	tests := []struct {
+		rule Rule
+		want string
+	}{
+		{Rule{When: "lid", SetProfile: "Quiet"}, "when:"},
+		{Rule{When: "profile", Profile: "Turbo", Keyboard: "low"}, "profile:"},
+		{Rule{When: "battery_below", Keyboard: "low"}, "below:"},
+		{Rule{When: "battery_below", Below: 100, Keyboard: "low"}, "below:"},
+		{Rule{When: "ac", SetProfile: "Turbo"}, "set_profile:"},
+		{Rule{When: "ac", ChargeLimit: 10}, "charge_limit:"},
+		{Rule{When: "ac", Keyboard: "dim"}, "keyboard:"},
+		{Rule{When: "ac", AuraMode: "Disco"}, "aura_mode:"},
+		{Rule{When: "ac", AuraMode: "Static", Colour: "red"}, "colour:"},
+		{Rule{When: "ac"}, "does nothing"},
+	}
+	for _, tt := range tests {
+		err := tt.rule.Validate()
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%+v: err = %v, want %q", tt.rule, err, tt.want)
+		}
+	}
+}
+
+// newRulesApp is an App on the mock with rules and a first check done, so
+// the next checkRules diffs against it.
+func newRulesApp(t *testing.T, rules ...Rule) (*App, *MockBackend) {
+	t.Helper()
+	m := NewMockBackend()
+	cfg := DefaultConfig()
+	cfg.Rules = rules
+	a := NewApp(NewFakeTerminal(80, 24, io.Discard), m, cfg)
+	a.automation.acOnline, a.automation.acRead = true, true
+	a.checkRules(BatteryStatus{Present: true, Percent: 50})
+	return a, m
+}
+
+// drainQueue runs the completions of the queued writes.
+func drainQueue(t *testing.T, a *App) {
+	t.Helper()
+	for {
+		select {
+		case done := <-a.queue.Done():
+			done()
+		case <-time.After(200 * time.Millisecond):
+			if a.queue.Pending() == 0 {
+				return
+			}
+		}
+	}
+}
+
+func TestRulesTriggers(t *testing.T) {
+	bat := BatteryStatus{Present: true, Percent: 50}
+	tests := []struct {
+		name   string
+		rule   Rule
+		change func(a *App, bat *BatteryStatus)
+		fire   bool
+	}{
+		{"unplugged", Rule{When: "battery", Keyboard: "off"}, func(a *App, _ *BatteryStatus) { a.automation.acOnline = false }, true},
+		{"still on AC", Rule{When: "battery", Keyboard: "off"}, func(*App, *BatteryStatus) {}, false},
+		{"plugged in while on AC", Rule{When: "ac", Keyboard: "off"}, func(*App, *BatteryStatus) {}, false},
+		{"profile", Rule{When: "profile", Keyboard: "off"}, func(a *App, _ *BatteryStatus) { a.profile = "Quiet" }, true},
+		{"other profile", Rule{When: "profile", Profile: "Performance", Keyboard: "off"}, func(a *App, _ *BatteryStatus) { a.profile = "Quiet" }, false},
+		{"below", Rule{When: "battery_below", Below: 20, Keyboard: "off"}, func(_ *App, b *BatteryStatus) { b.Percent = 19 }, true},
+		{"above", Rule{When: "battery_below", Below: 20, Keyboard: "off"}, func(_ *App, b *BatteryStatus) { b.Percent = 20 }, false},
+		{"other workspace", Rule{When: "battery", Keyboard: "off", Workspace: "Office"}, func(a *App, _ *BatteryStatus) { a.automation.acOnline = false }, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, _ := newRulesApp(t, tt.rule)
+			b := bat
+			tt.change(a, &b)
+			a.checkRules(b)
+			drainQueue(t, a)
+			if _, fired := a.rules.fired[0]; fired != tt.fire {
+				t.Errorf("fired = %v, want %v", fired, tt.fire)
+			}
+			if fired := a.kbdLevel == 0; fired != tt.fire {
+				t.Errorf("kbdLevel = %d after firing = %v", a.kbdLevel, tt.fire)
+			}
+		})
+	}
+}
+
+func TestRulesBatteryBelowFiresOnce(t *testing.T) {
+	a, _ := newRulesApp(t, Rule{When: "battery_below", Below: 20, ChargeLimit: 60})
+	a.checkRules(BatteryStatus{Present: true, Percent: 19})
+	drainQueue(t, a)
+	if a.chargeLimit != 60 {
+		t.Fatalf("chargeLimit = %d, want 60", a.chargeLimit)
+	}
+	a.chargeLimit = 80
+	a.checkRules(BatteryStatus{Present: true, Percent: 15})
+	drainQueue(t, a)
+	if a.chargeLimit != 80 {
+		t.Error("the rule fired again while the battery stayed below")
+	}
+}
+
+// TestRulesOwnProfileIsNoTrigger checks a rule's profile switch does not
+// fire profile rules on the next check.
+func TestRulesOwnProfileIsNoTrigger(t *testing.T) {
+	a, _ := newRulesApp(t,
+		Rule{When: "battery", SetProfile: "Quiet"},
+		Rule{When: "profile", Keyboard: "off"},
+	)
+	a.automation.acOnline = false
+	a.checkRules(BatteryStatus{Present: true, Percent: 50})
+	drainQueue(t, a)
+	if a.profile != "Quiet" {
+		t.Fatalf("profile = %s, want Quiet", a.profile)
+	}
+	a.checkRules(BatteryStatus{Present: true, Percent: 50})
+	drainQueue(t, a)
+	if _, fired := a.rules.fired[1]; fired {
+		t.Error("the rule's own profile switch fired the profile rule")
+	}
+}
+
+// TestRulesFailedWriteKeepsState checks the App only follows writes that
+// succeeded.
+func TestRulesFailedWriteKeepsState(t *testing.T) {
+	a, m := newRulesApp(t, Rule{When: "battery", Keyboard: "off", AuraMode: "Pulse", ChargeLimit: 60})
+	m.mu.Lock()
+	m.daemon = "failed"
+	m.mu.Unlock()
+	mode, level, limit := a.auraMode, a.kbdLevel, a.chargeLimit
+	a.automation.acOnline = false
+	a.checkRules(BatteryStatus{Present: true, Percent: 50})
+	drainQueue(t, a)
+	if a.auraMode != mode || a.kbdLevel != level || a.chargeLimit != limit {
+		t.Errorf("state changed by failed writes: aura %d→%d, kbd %d→%d, limit %d→%d",
+			mode, a.auraMode, level, a.kbdLevel, limit, a.chargeLimit)
+	}
+	if n := len(a.automation.events); n != 3 {
+		t.Errorf("logged %d events, want one per action", n)
+	}
+}
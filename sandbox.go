@@ -0,0 +1,59 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// Sandbox escape — run host tools from a Flatpak or toolbox container
+// Inside a sandbox asusctl and friends live on the host, so every command is
+// started through `flatpak-spawn --host`. Outside one these are plain exec.
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// inSandbox is true when host binaries must go through flatpak-spawn.
+var inSandbox = detectSandbox()
+
+func detectSandbox() bool {
+	if _, err := os.Stat("/.flatpak-info"); err == nil {
+		return true
+	}
+	// toolbox/podman containers ship flatpak-spawn for the same purpose
+	if _, err := os.Stat("/run/.containerenv"); err == nil {
+		_, err := exec.LookPath("flatpak-spawn")
+		return err == nil
+	}
+	return false
+}
+
+// hostCommand returns a command that runs name on the host.
+func hostCommand(name string, args ...string) *exec.Cmd {
+	if !inSandbox {
+		return exec.Command(name, args...)
+	}
+	// --watch-bus ends the host process when we exit
+	return exec.Command("flatpak-spawn", append([]string{"--host", "--watch-bus", name}, args...)...)
+}
+
+// hostPaths memoises hostLookPath; each sandboxed lookup spawns a process.
+var hostPaths sync.Map
+
+// hostLookPath is exec.LookPath against the host's PATH.
+func hostLookPath(name string) (string, error) {
+	if !inSandbox {
+		return exec.LookPath(name)
+	}
+	if p, ok := hostPaths.Load(name); ok {
+		return p.(string), nil
+	}
+	out, err := exec.Command("flatpak-spawn", "--host", "sh", "-c", `command -v "$1"`, "sh", name).Output()
+	p := strings.TrimSpace(string(out))
+	if err != nil || p == "" {
+		return "", errors.New(name + ": executable file not found on the host")
+	}
+	hostPaths.Store(name, p)
+	return p, nil
+}
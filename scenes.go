@@ -0,0 +1,300 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// Scenes — named bundles of profile, keyboard, Aura, charge limit and fan
+// curves in config.toml ("gaming", "meeting", "travel"). Applying one queues
+// each setting like its own tab would; parts under lockdown, and a profile
+// quiet hours would refuse, are skipped, and the status line names them and
+// any write that failed.
+// ═══════════════════════════════════════════════════════════════════════════════
+
+func (s Scene) Validate() error {
+	if strings.TrimSpace(s.Name) == "" {
+		return errors.New("name: must not be empty")
+	}
+	if s.Profile != "" && matchProfile(s.Profile) == "" {
+		return fmt.Errorf("profile: unknown profile %q", s.Profile)
+	}
+	if s.Keyboard != "" && indexFold(kbdValues, s.Keyboard) < 0 {
+		return fmt.Errorf("keyboard: %q must be off, low, med or high", s.Keyboard)
+	}
+	if s.AuraMode != "" && indexFold(auraModes, s.AuraMode) < 0 {
+		return fmt.Errorf("aura_mode: unknown effect %q", s.AuraMode)
+	}
+	for _, c := range []struct{ key, hex string }{{"colour1", s.Colour1}, {"colour2", s.Colour2}} {
+		if _, _, _, ok := parseHexColour(c.hex); c.hex != "" && !ok {
+			return fmt.Errorf("%s: %q is not an rrggbb colour", c.key, c.hex)
+		}
+	}
+	if s.AuraSpeed != "" && indexFold(auraSpeeds, s.AuraSpeed) < 0 {
+		return fmt.Errorf("aura_speed: %q must be low, med or high", s.AuraSpeed)
+	}
+	if err := checkZones(s.Zones); err != nil {
+		return err
+	}
+	if s.ChargeLimit != 0 && (s.ChargeLimit < 20 || s.ChargeLimit > 100) {
+		return fmt.Errorf("charge_limit: %d must be 20-100", s.ChargeLimit)
+	}
+	for i, curve := range s.curves() {
+		if _, _, err := ParseFanCurveData(curve); curve != "" && err != nil {
+			return fmt.Errorf("%s_curve: %w", fanNames[i], err)
+		}
+	}
+	return nil
+}
+
+// curves are the scene's fan curves, indexed like fanNames.
+func (s Scene) curves() []string {
+	return []string{s.CPUCurve, s.GPUCurve, s.MidCurve}
+}
+
+// Describe is the one-line summary shown on the Scenes tab.
+func (s Scene) Describe() string {
+	var parts []string
+	if p := matchProfile(s.Profile); p != "" {
+		parts = append(parts, p)
+	}
+	if i := indexFold(kbdValues, s.Keyboard); i >= 0 {
+		parts = append(parts, "keyboard "+strings.ToLower(kbdLabels[i]))
+	}
+	if i := indexFold(auraModes, s.AuraMode); i >= 0 {
+		aura := auraModes[i]
+		switch {
+		case !auraEffectNeedsColour1(aura):
+		case len(s.Zones) > 0:
+			aura += " zones #" + strings.Join(s.Zones, " #")
+		case s.Colour1 != "":
+			aura += " #" + s.Colour1
+		}
+		parts = append(parts, aura)
+	}
+	if s.ChargeLimit > 0 {
+		parts = append(parts, fmt.Sprintf("charge %d%%", s.ChargeLimit))
+	}
+	if s.CPUCurve != "" || s.GPUCurve != "" || s.MidCurve != "" {
+		parts = append(parts, "fan curves")
+	}
+	if len(parts) == 0 {
+		return "nothing saved"
+	}
+	return strings.Join(parts, " · ")
+}
+
+// indexFold is the index of the entry equal to s ignoring case, or -1.
+func indexFold(list []string, s string) int {
+	for i, v := range list {
+		if strings.EqualFold(v, s) {
+			return i
+		}
+	}
+	return -1
+}
+
+// sceneIndex is the index of the named scene in the config, or -1.
+func (a *App) sceneIndex(name string) int {
+	for i, sc := range a.cfg.Scenes {
+		if strings.EqualFold(sc.Name, name) {
+			return i
+		}
+	}
+	return -1
+}
+
+// captureScene snapshots the current settings under name. The fan curves
+// are re-read from asusd so unapplied edits on the Fans tab are not saved.
+func (a *App) captureScene(name string) Scene {
+	sc := Scene{
+		Name:        name,
+		Profile:     a.profile,
+		Keyboard:    kbdValues[a.kbdLevel],
+		AuraMode:    auraModes[a.auraMode],
+		Colour1:     a.selectedAuraColour(0).Hex,
+		Colour2:     a.selectedAuraColour(1).Hex,
+		AuraSpeed:   auraSpeeds[a.auraSpeed],
+		Zones:       a.savedZones(),
+		ChargeLimit: a.chargeLimit,
+	}
+	if fc, err := a.backend.ReadFanCurves(a.profile); err == nil {
+		sc.CPUCurve = FormatFanCurve(fc.Temps[0][:], fc.Speeds[0][:])
+		sc.GPUCurve = FormatFanCurve(fc.Temps[1][:], fc.Speeds[1][:])
+		if fc.Mid {
+			sc.MidCurve = FormatFanCurve(fc.Temps[2][:], fc.Speeds[2][:])
+		}
+	}
+	return sc
+}
+
+// saveScene stores the current settings under name, replacing a scene of
+// the same name.
+func (a *App) saveScene(name string) {
+	sc := a.captureScene(name)
+	if i := a.sceneIndex(name); i >= 0 {
+		a.cfg.Scenes[i] = sc
+	} else {
+		a.cfg.Scenes = append(a.cfg.Scenes, sc)
+	}
+	if err := a.cfg.Persist(); err != nil {
+		a.SetStatus("Scene "+name+" not saved: "+err.Error(), false)
+		return
+	}
+	a.SetStatus("Scene "+name+" saved", true)
+}
+
+func (a *App) deleteScene(i int) {
+	name := a.cfg.Scenes[i].Name
+	a.cfg.Scenes = append(a.cfg.Scenes[:i], a.cfg.Scenes[i+1:]...)
+	if err := a.cfg.Persist(); err != nil {
+		a.SetStatus("Scene "+name+" removed for this session only: "+err.Error(), false)
+		return
+	}
+	a.SetStatus("Scene "+name+" deleted", true)
+}
+
+// applyScene queues every setting the scene holds. A last job writes the
+// fan curves and reports the result once everything before it has run.
+// sceneSteps collects the results of the writes a scene queues through the
+// tabs' setters, for the scene's own status line.
+type sceneSteps struct {
+	ran    int
+	failed []string // names of the settings that were not written
+}
+
+var sceneStepNames = map[string]string{
+	"profile": "profile", "kbd": "keyboard", "aura": "Aura", "charge_limit": "charge limit",
+}
+
+// add records the result of the write for key; a nil s records nothing.
+func (s *sceneSteps) add(key string, ok bool) {
+	if s == nil {
+		return
+	}
+	s.ran++
+	if !ok {
+		s.failed = append(s.failed, sceneStepNames[key])
+	}
+}
+
+// applyScene queues the scene's settings. then, if set, learns once they
+// are done whether every setting was written.
+func (a *App) applyScene(sc Scene, then func(ok bool)) {
+	var skipped []string
+	skip := func(action string) bool {
+		if a.cfg.Lockdown.ActionLocked(action) {
+			skipped = append(skipped, strings.ReplaceAll(action, "_", " "))
+			return true
+		}
+		return false
+	}
+
+	// The setters queue their writes ahead of the scene's own job, so each
+	// has reported by the time it completes
+	steps := &sceneSteps{}
+	a.sceneSteps = steps
+	profile := a.profile
+	if p := matchProfile(sc.Profile); p != "" && !skip("profile") {
+		if a.quiet.active && p != quietProfile {
+			skipped = append(skipped, "profile (quiet hours)")
+		} else {
+			a.holdAuraFollow(p)
+			a.selectProfile(p)
+			profile = p
+		}
+	}
+	if i := indexFold(kbdValues, sc.Keyboard); i >= 0 {
+		a.setKbdLevel(i)
+	}
+	if i := indexFold(auraModes, sc.AuraMode); i >= 0 && !a.auraModeOK(i) {
+		skipped = append(skipped, "Aura "+auraModes[i]+" (not supported by this keyboard)")
+	} else if i >= 0 && !skip("aura") {
+		a.auraMode = i
+		for n, hex := range []string{sc.Colour1, sc.Colour2} {
+			if r, g, b, ok := parseHexColour(hex); ok {
+				a.setAuraColourIndex(n, a.matchAuraColour(n, r, g, b))
+			}
+		}
+		if s := indexFold(auraSpeeds, sc.AuraSpeed); s >= 0 {
+			a.auraSpeed = s
+		}
+		a.setZoneColours(sc.Zones)
+		a.applyAura()
+	}
+	if sc.ChargeLimit > 0 && !skip("charge_limit") {
+		a.chargeLimit = sc.ChargeLimit
+		a.applyChargeLimit()
+	}
+	a.sceneSteps = nil
+
+	// Curves are written for the scene's profile and replace the curves on
+	// the Fans tab; quiet hours cap them like any other Quiet curve write
+	var fans []int
+	var data []string
+	if (sc.CPUCurve != "" || sc.GPUCurve != "" || sc.MidCurve != "") && !skip("fan_curves") {
+		for i, curve := range sc.curves() {
+			temps, speeds, err := ParseFanCurveData(curve)
+			if curve == "" || err != nil || i >= len(a.fans()) { // mid_curve without a mid fan
+				continue
+			}
+			if profile == a.profile {
+				a.fanTemps[i], a.fanSpeeds[i] = temps, speeds
+				a.fanDirty = false
+			}
+			speeds = a.quietCapFor(i, profile, speeds)
+			fans = append(fans, i)
+			data = append(data, FormatFanCurve(temps[:], speeds[:]))
+		}
+	}
+	outs := make([]string, len(fans))
+	oks := make([]bool, len(fans))
+	ran := 0
+	a.submit("scene", func(b Backend) (bool, string) {
+		for j, i := range fans {
+			oks[j], outs[j] = b.SetFanCurve(fanNames[i], profile, data[j])
+			ran++
+			if !oks[j] {
+				return false, outs[j]
+			}
+		}
+		if len(fans) == 0 {
+			return true, ""
+		}
+		return b.EnableFanCurves(profile, true)
+	}, func(ok bool, out string, argv []string) {
+		for j := 0; j < ran; j++ {
+			a.addLog("fan-curve --mod-profile "+profile+" --fan "+fanNames[fans[j]]+" --data "+data[j], outs[j], oks[j])
+		}
+		if len(fans) > 0 && ran == len(fans) && oks[ran-1] {
+			a.logCommand(argv, out, ok) // --enable-fan-curves
+		}
+		if len(fans) > 0 && profile == a.profile {
+			a.fanEnabled = a.fanEnabled || ok
+			a.loadFanCurves()
+		}
+		ran, failed := steps.ran, steps.failed
+		if len(fans) > 0 {
+			ran++
+			if !ok {
+				failed = append(failed, "fan curves")
+			}
+		}
+		msg := "Scene " + sc.Name + " applied"
+		switch {
+		case len(failed) > 0 && len(failed) == ran:
+			msg = "Scene " + sc.Name + " not applied; failed: " + strings.Join(failed, ", ")
+		case len(failed) > 0:
+			msg = "Scene " + sc.Name + " partly applied; failed: " + strings.Join(failed, ", ")
+		}
+		if len(skipped) > 0 {
+			msg += "; skipped " + strings.Join(skipped, ", ")
+		}
+		a.SetStatus(msg, len(failed) == 0 && len(skipped) == 0)
+		if then != nil {
+			then(len(failed) == 0 && len(skipped) == 0)
+		}
+	})
+}
@@ -0,0 +1,103 @@
+package main
+
+import "fmt"
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// Page: Scenes
+// ═══════════════════════════════════════════════════════════════════════════════
+
+func (a *App) renderScenes(y, h int) {
+	t := a.term
+	W := t.Width()
+	cx := a.padX()
+
+	t.TextBold(cx, y+1, ColText, "Scenes")
+	t.Text(cx, y+2, ColTextDim, "Saved bundles of profile, lighting, charge limit and fan curves")
+
+	scenes := a.cfg.Scenes
+	row := y + 4
+	if len(scenes) == 0 {
+		t.Text(cx, row, ColTextMut, "No scenes yet — press n to save the current settings as one")
+	}
+	for i, sc := range scenes {
+		if row >= y+h-2 {
+			break
+		}
+		name := pad(sc.Name, 14)
+		if a.focusIdx == i {
+			a.focusText(cx, row, "▸ "+name)
+		} else {
+			t.Text(cx, row, ColTextDim, "  "+name)
+		}
+		t.Text(cx+18, row, ColTextDim, pad(sc.Describe(), W-cx-20))
+		row += 2
+	}
+	t.Text(cx, y+h-1, ColTextMut, "↑↓ select  Enter apply  n save current as…  s overwrite with current  d delete")
+}
+
+func (a *App) handleScenes(key KeyEvent) {
+	n := len(a.cfg.Scenes)
+	switch key.Type {
+	case KeyUp:
+		if a.focusIdx > 0 {
+			a.focusIdx--
+		}
+	case KeyDown:
+		if a.focusIdx < n-1 {
+			a.focusIdx++
+		}
+	case KeyEnter:
+		if n > 0 {
+			a.applyScene(a.cfg.Scenes[a.focusIdx], nil)
+		}
+	case KeyChar:
+		switch key.Char {
+		case 'n':
+			a.promptSceneName()
+		case 's':
+			if n == 0 {
+				return
+			}
+			name := a.cfg.Scenes[a.focusIdx].Name
+			a.ask(&Confirm{
+				Title: "Overwrite scene " + name + "?",
+				Lines: []string{"It will hold the current settings instead."},
+				OnYes: func() { a.saveScene(name) },
+			})
+		case 'd':
+			if n == 0 {
+				return
+			}
+			i := a.focusIdx
+			a.ask(&Confirm{
+				Title: "Delete scene " + a.cfg.Scenes[i].Name + "?",
+				Lines: []string{"It is removed from config.toml."},
+				OnYes: func() {
+					a.deleteScene(i)
+					a.focusIdx = clamp(a.focusIdx, 0, max(len(a.cfg.Scenes)-1, 0))
+				},
+			})
+		}
+	}
+}
+
+// promptSceneName asks for a name and saves the current settings under it,
+// confirming before a scene of that name is replaced.
+func (a *App) promptSceneName() {
+	a.prompt = &Prompt{
+		Title: "Save scene",
+		Label: "Name for the current settings, e.g. gaming",
+		OnOk: func(name string) {
+			if i := a.sceneIndex(name); i >= 0 {
+				a.ask(&Confirm{
+					Title: fmt.Sprintf("Replace scene %s?", a.cfg.Scenes[i].Name),
+					Lines: []string{"A scene with this name already exists."},
+					OnYes: func() { a.saveScene(name) },
+				})
+				return
+			}
+			a.saveScene(name)
+			a.focusIdx = len(a.cfg.Scenes) - 1
+		},
+	}
+}
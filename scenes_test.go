@@ -0,0 +1,33 @@
+package main
+
+import (
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestApplySceneReportsFailures(t *testing.T) {
+	sc := Scene{Name: "travel", Profile: "Quiet", Keyboard: "off", ChargeLimit: 60}
+	tests := []struct {
+		name   string
+		daemon string
+		want   string
+	}{
+		{"all written", "active", "Scene travel applied"},
+		{"daemon down", "failed", "Scene travel not applied; failed: profile, keyboard, charge limit"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewMockBackend()
+			m.mu.Lock()
+			m.daemon = tt.daemon
+			m.mu.Unlock()
+			a := NewApp(NewFakeTerminal(80, 24, io.Discard), m, DefaultConfig())
+			a.applyScene(sc, nil)
+			drainQueue(t, a)
+			if !strings.HasPrefix(a.statusMsg, tt.want) {
+				t.Errorf("status = %q, want %q", a.statusMsg, tt.want)
+			}
+		})
+	}
+}
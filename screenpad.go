@@ -0,0 +1,92 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// ScreenPad — the second screen of Zenbook Duo / ScreenPad models, exposed by
+// asus-wmi as a backlight device. Brightness is written to sysfs when the
+// node is writable, else through asusd (asusctl 6+), which runs as root.
+// Power has no asusctl command, so it needs the writable bl_power node.
+// ═══════════════════════════════════════════════════════════════════════════════
+
+const screenpadDir = "/sys/class/backlight/asus_screenpad"
+
+// bl_power values (the kernel's FB_BLANK_*)
+const (
+	blPowerOn   = "0"
+	blPowerDown = "4"
+)
+
+// ScreenPadState describes the ScreenPad, if this laptop has one.
+type ScreenPadState struct {
+	Present    bool
+	On         bool
+	Brightness int
+	Max        int
+	Writable   bool // sysfs brightness and bl_power can be written
+}
+
+func readSysfsInt(path string) (int, bool) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, false
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	return n, err == nil
+}
+
+func readScreenPad() ScreenPadState {
+	cur, ok := readSysfsInt(filepath.Join(screenpadDir, "brightness"))
+	if !ok {
+		return ScreenPadState{}
+	}
+	s := ScreenPadState{Present: true, On: true, Brightness: cur, Max: 255}
+	if n, ok := readSysfsInt(filepath.Join(screenpadDir, "max_brightness")); ok && n > 0 {
+		s.Max = n
+	}
+	if n, ok := readSysfsInt(filepath.Join(screenpadDir, "bl_power")); ok {
+		s.On = n == 0
+	}
+	s.Writable = syscall.Access(filepath.Join(screenpadDir, "brightness"), 2) == nil // W_OK
+	return s
+}
+
+func (b *ExecBackend) GetScreenPad() ScreenPadState { return readScreenPad() }
+
+func (b *ExecBackend) SetScreenPadBrightness(level int) (bool, string) {
+	sp := readScreenPad()
+	if !sp.Present {
+		return false, "no ScreenPad is supported on this laptop"
+	}
+	level = clamp(level, 0, sp.Max)
+	if sp.Writable {
+		if err := os.WriteFile(filepath.Join(screenpadDir, "brightness"), []byte(strconv.Itoa(level)), 0); err != nil {
+			return false, err.Error()
+		}
+		return true, ""
+	}
+	if b.major > 0 && b.major < 6 {
+		return false, "ScreenPad brightness is read-only here (needs asusctl 6, root or a udev rule)"
+	}
+	return b.runOp("screenpad.brightness", strconv.Itoa(level))
+}
+
+func (b *ExecBackend) SetScreenPadPower(on bool) (bool, string) {
+	if !readScreenPad().Present {
+		return false, "no ScreenPad is supported on this laptop"
+	}
+	val := blPowerDown
+	if on {
+		val = blPowerOn
+	}
+	if err := os.WriteFile(filepath.Join(screenpadDir, "bl_power"), []byte(val), 0); err != nil {
+		return false, err.Error()
+	}
+	return true, ""
+}
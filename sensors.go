@@ -0,0 +1,142 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// Sensors — live temperatures and fan speeds from /sys/class/hwmon
+// A background poller keeps App.sensors current for every tab that shows
+// live readings. asus-nb-wmi registers the fans as hwmon "asus" with labels
+// cpu_fan, gpu_fan and mid_fan.
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// sensorSampleEvery is the default refresh_interval.
+const sensorSampleEvery = 2 * time.Second
+
+type SensorControl interface {
+	ReadSensors() SensorReading
+}
+
+// FanReading is one fan tachometer.
+type FanReading struct {
+	Label string // "cpu_fan", "gpu_fan", … or "<driver> fanN"
+	RPM   int
+}
+
+// SensorReading is one poll of the hwmon sensors; zero means not available.
+type SensorReading struct {
+	CPUTempC float64
+	GPUTempC float64
+	Fans     []FanReading
+	DGPU     GpuTelemetry // NVIDIA only, see nvidia.go
+}
+
+// FanRPM returns the speed of the fan whose label names fan ("cpu", "gpu",
+// "mid").
+func (r SensorReading) FanRPM(fan string) (int, bool) {
+	for _, f := range r.Fans {
+		if strings.HasPrefix(f.Label, fan) {
+			return f.RPM, true
+		}
+	}
+	return 0, false
+}
+
+// gpuTempSensors are hwmon drivers of a discrete GPU. The proprietary
+// NVIDIA driver registers none.
+var gpuTempSensors = []string{"amdgpu", "nouveau"}
+
+func readHwmonTemp(names []string) float64 {
+	hwmons, _ := filepath.Glob("/sys/class/hwmon/hwmon*")
+	for _, want := range names {
+		for _, h := range hwmons {
+			if readSysfsString(filepath.Join(h, "name")) != want {
+				continue
+			}
+			if v, ok := readSysfsMicro(filepath.Join(h, "temp1_input")); ok {
+				return v * 1000 // millidegrees
+			}
+		}
+	}
+	return 0
+}
+
+func readFanSpeeds() []FanReading {
+	var fans []FanReading
+	inputs, _ := filepath.Glob("/sys/class/hwmon/hwmon*/fan[0-9]*_input")
+	for _, in := range inputs {
+		rpm, err := strconv.Atoi(readSysfsString(in))
+		if err != nil {
+			continue
+		}
+		label := readSysfsString(strings.TrimSuffix(in, "_input") + "_label")
+		if label == "" {
+			dir := filepath.Dir(in)
+			label = readSysfsString(filepath.Join(dir, "name")) + " " + strings.TrimSuffix(filepath.Base(in), "_input")
+		}
+		fans = append(fans, FanReading{Label: label, RPM: rpm})
+	}
+	sort.Slice(fans, func(i, j int) bool { return fans[i].Label < fans[j].Label })
+	return fans
+}
+
+func (b *ExecBackend) ReadSensors() SensorReading {
+	r := SensorReading{
+		CPUTempC: readCPUTemp(),
+		GPUTempC: readHwmonTemp(gpuTempSensors),
+		Fans:     readFanSpeeds(),
+		DGPU:     b.readNvidia(),
+	}
+	if r.GPUTempC == 0 {
+		r.GPUTempC = r.DGPU.TempC
+	}
+	return r
+}
+
+// startSensors polls the sensors in the background until stopSensors.
+func (a *App) startSensors() {
+	a.sensorStop = make(chan struct{})
+	stop := a.sensorStop
+	every := a.cfg.RefreshEvery()
+	go func() {
+		tick := time.NewTicker(every)
+		defer tick.Stop()
+		for {
+			r := a.backend.ReadSensors()
+			a.Post(func() {
+				a.sensors = r
+				if a.activeTab == TabDashboard || a.activeTab == TabProfile && a.cfg.ProfileLayout == "compact" {
+					a.refreshDashboard()
+				}
+			})
+			select {
+			case <-stop:
+				return
+			case <-tick.C:
+			}
+		}
+	}()
+}
+
+func (a *App) stopSensors() {
+	if a.sensorStop != nil {
+		close(a.sensorStop)
+		a.sensorStop = nil
+	}
+}
+
+// formatFanLive is the live reading for one fan, e.g. "2400 rpm · 62°C".
+func (a *App) formatFanLive(fan string) string {
+	temp := a.fanTempC(fan)
+	rpm := "— rpm"
+	if v, ok := a.sensors.FanRPM(fan); ok {
+		rpm = fmt.Sprintf("%d rpm", v)
+	}
+	return rpm + " · " + formatTemp(temp)
+}
@@ -0,0 +1,40 @@
+package main
+
+import "strings"
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// Service — asusd health via systemctl
+// Every asusctl call goes through asusd, so a stopped or crashed daemon makes
+// the whole UI fail. The status is shown in the header and the System tab
+// can restart it.
+// ═══════════════════════════════════════════════════════════════════════════════
+
+const asusdUnit = "asusd"
+
+type ServiceControl interface {
+	// DaemonStatus is `systemctl is-active asusd` ("active", "inactive",
+	// "failed", …), or "" when systemd is not available.
+	DaemonStatus() string
+	RestartDaemon() (bool, string)
+}
+
+// daemonDown reports whether status means asusd needs starting.
+func daemonDown(status string) bool {
+	return status == "inactive" || status == "failed"
+}
+
+func (b *ExecBackend) DaemonStatus() string {
+	if _, err := hostLookPath("systemctl"); err != nil {
+		return ""
+	}
+	// is-active exits non-zero for anything but "active"; the output is
+	// what matters
+	_, out := execWithTimeout(hostCommand("systemctl", "is-active", asusdUnit), b.policy.Timeout)
+	return strings.TrimSpace(strings.SplitN(out, "\n", 2)[0])
+}
+
+func (b *ExecBackend) RestartDaemon() (bool, string) {
+	// --no-ask-password: a polkit prompt would fight the TUI for the
+	// terminal; a refusal is offered again through pkexec instead
+	return b.runBin("systemctl", "--no-ask-password", "restart", asusdUnit)
+}
@@ -0,0 +1,142 @@
+package main
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// Page: Settings — the TUI's own options from config.toml, changed in place.
+// Each change takes effect at once and is written back with Persist; the
+// hardware settings and automation sections stay in the file.
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// settingRow is one option: a toggle when flag is set, else a choice that
+// ←→ steps through.
+type settingRow struct {
+	label, desc string
+	flag        func(c *Config) *bool
+	choice      func(c *Config) *string
+	options     []string
+	apply       func(a *App) // makes a change visible; nil when Render does
+}
+
+// refreshChoices are the refresh_interval values offered on the tab.
+var refreshChoices = []string{"1s", "2s", "5s", "10s"}
+
+var settingRows = []settingRow{
+	{label: "Theme", desc: "Colours of the background, panels and text",
+		choice: func(c *Config) *string { return &c.Theme }, options: themeNames(),
+		apply: func(a *App) { applyTheme(a.cfg.Theme) }},
+	{label: "Density", desc: "Margins and spacing; compact fits short terminals",
+		choice: func(c *Config) *string { return &c.Density }, options: densities},
+	{label: "Focus style", desc: "How the focused item is marked",
+		choice: func(c *Config) *string { return &c.FocusStyle }, options: focusStyles},
+	{label: "ASCII mode", desc: "Plain ASCII instead of box drawing and symbols",
+		flag:  func(c *Config) *bool { return &c.ASCIIMode },
+		apply: func(a *App) { a.term.SetASCII(a.cfg.ASCIIMode) }},
+	{label: "Refresh interval", desc: "How often temperatures, fans and battery draw update",
+		choice: func(c *Config) *string { return &c.RefreshInterval }, options: refreshChoices,
+		apply: func(a *App) {
+			if a.sensorStop != nil {
+				a.stopSensors()
+				a.startSensors()
+			}
+		}},
+	{label: "Temperature unit", desc: "Sensors and fan curve axis",
+		choice: func(c *Config) *string { return &c.TemperatureUnit }, options: []string{"celsius", "fahrenheit"},
+		apply: func(a *App) { fahrenheit = a.cfg.TemperatureUnit == "fahrenheit" }},
+	{label: "Profile layout", desc: "Cards, or one line per profile with live readings",
+		choice: func(c *Config) *string { return &c.ProfileLayout }, options: []string{"cards", "compact"}},
+	{label: "Confirmation prompts", desc: "Ask before overwriting scenes and favourites or reverting",
+		flag: func(c *Config) *bool { return &c.ConfirmPrompts }},
+	{label: "Apply on select", desc: "Moving the selection on Profile, Keyboard and Aura applies it",
+		flag: func(c *Config) *bool { return &c.ApplyOnSelect }},
+	{label: "Completion alert", desc: "Bell and header flash when a slow write finishes on another tab",
+		flag: func(c *Config) *bool { return &c.CompletionAlert }},
+	{label: "Animations", desc: "Ease toggles and bars to their new value, play the Aura preview",
+		flag: func(c *Config) *bool { return &c.Animations },
+		apply: func(a *App) {
+			if a.cfg.Animations {
+				a.term.SetAnimator(NewAnimator())
+				if a.activeTab == TabAura {
+					a.startAuraPreview()
+				}
+			} else {
+				a.term.SetAnimator(nil)
+				a.stopAuraPreview()
+			}
+		}},
+	{label: "Tab accents", desc: "Aura, Battery and Fans get their own accent colour",
+		flag: func(c *Config) *bool { return &c.TabAccents }},
+	{label: "Show commands", desc: "The asusctl command for each action under the footer",
+		flag: func(c *Config) *bool { return &c.ShowCommands }},
+}
+
+func (a *App) renderSettings(y, h int) {
+	t := a.term
+	cx := a.padX()
+
+	t.TextBold(cx, y+1, ColText, "Settings")
+	t.Text(cx, y+2, ColTextDim, "How this app looks and behaves; saved to "+orDash(a.cfg.path))
+
+	row := y + 4
+	for i, s := range settingRows {
+		if a.focusIdx == i {
+			a.focusText(cx, row, "▸ "+s.label)
+		} else {
+			t.Text(cx, row, ColTextDim, "  "+s.label)
+		}
+		if s.flag != nil {
+			t.DrawToggle(cx+26, row, *s.flag(a.cfg))
+		} else {
+			val := *s.choice(a.cfg)
+			if a.focusIdx == i {
+				t.TextBold(cx+26, row, a.accent(), "◂ "+val+" ▸")
+			} else {
+				t.Text(cx+28, row, ColText, val)
+			}
+		}
+		row++
+	}
+	if a.focusIdx < len(settingRows) {
+		t.Text(cx+2, row+1, ColTextMut, settingRows[a.focusIdx].desc)
+	}
+	t.Text(cx, row+3, ColTextMut, "↑↓ select  ←→ change  Enter toggle")
+}
+
+func (a *App) handleSettings(key KeyEvent) {
+	n := len(settingRows)
+	switch key.Type {
+	case KeyUp:
+		a.focusIdx = (a.focusIdx + n - 1) % n
+	case KeyDown:
+		a.focusIdx = (a.focusIdx + 1) % n
+	case KeyLeft:
+		a.changeSetting(a.focusIdx, -1)
+	case KeyRight, KeyEnter:
+		a.changeSetting(a.focusIdx, 1)
+	}
+}
+
+// changeSetting flips toggle i or steps choice i by dir, then saves.
+func (a *App) changeSetting(i, dir int) {
+	s := settingRows[i]
+	var val string
+	if s.flag != nil {
+		p := s.flag(a.cfg)
+		*p = !*p
+		val = "off"
+		if *p {
+			val = "on"
+		}
+	} else {
+		p := s.choice(a.cfg)
+		n := len(s.options)
+		*p = s.options[(max(indexFold(s.options, *p), 0)+dir+n)%n]
+		val = *p
+	}
+	if s.apply != nil {
+		s.apply(a)
+	}
+	if err := a.cfg.Persist(); err != nil {
+		a.SetStatus(s.label+" → "+val+" for this session only: "+err.Error(), false)
+		return
+	}
+	a.SetStatus(s.label+" → "+val, true)
+}
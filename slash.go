@@ -0,0 +1,93 @@
+package main
+
+import (
+	"os"
+	"strconv"
+	"strings"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// Slash — the LED bar on the lid of 2024+ models, through `asusctl slash`
+// asusctl has no getter, so the current settings come from asusd's slash.ron.
+// ═══════════════════════════════════════════════════════════════════════════════
+
+const slashRon = "/etc/asusd/slash.ron"
+
+// Slash brightness is a byte, the interval asusctl's 0-5 animation delay.
+const (
+	slashBrightnessMax = 255
+	slashIntervalMax   = 5
+)
+
+// defaultSlashModes is used when `asusctl slash --help` lists no modes.
+var defaultSlashModes = []string{
+	"Bounce", "Slash", "Loading", "BitStream", "Transmission", "Flow", "Flux",
+	"Phantom", "Spectrum", "Hazard", "Interfacing", "Ramp", "GameOver",
+	"Start", "Buzzer",
+}
+
+type SlashState struct {
+	Present    bool
+	Enabled    bool
+	Brightness int
+	Interval   int
+	Mode       string
+}
+
+// parseSlashState reads the settings asusd saves in slash.ron.
+func parseSlashState(ron string) SlashState {
+	st := SlashState{Present: true, Brightness: slashBrightnessMax}
+	st.Enabled = parseRonField(ron, "slash_enabled") != "false"
+	if v, err := strconv.Atoi(parseRonField(ron, "slash_brightness")); err == nil {
+		st.Brightness = clamp(v, 0, slashBrightnessMax)
+	}
+	if v, err := strconv.Atoi(parseRonField(ron, "slash_interval")); err == nil {
+		st.Interval = clamp(v, 0, slashIntervalMax)
+	}
+	st.Mode = parseRonField(ron, "slash_mode")
+	return st
+}
+
+// parseSlashModes extracts the mode names from `asusctl slash --help`,
+// which prints them as "[possible values: Bounce, Slash, …]" after --mode.
+// Returns nil when the help lists none.
+func parseSlashModes(help string) []string {
+	_, rest, ok := strings.Cut(help, "possible values:")
+	if !ok {
+		return nil
+	}
+	if end := strings.IndexAny(rest, "]\n"); end >= 0 {
+		rest = rest[:end]
+	}
+	var modes []string
+	for _, f := range strings.Split(rest, ",") {
+		if f = strings.TrimSpace(f); f != "" {
+			modes = append(modes, f)
+		}
+	}
+	return modes
+}
+
+func (b *ExecBackend) GetSlashState() SlashState {
+	data, err := os.ReadFile(slashRon)
+	if err != nil {
+		return SlashState{}
+	}
+	return parseSlashState(string(data))
+}
+
+func (b *ExecBackend) SetSlashBrightness(level int) (bool, string) {
+	return b.run("slash", "--brightness", strconv.Itoa(clamp(level, 0, slashBrightnessMax)))
+}
+
+func (b *ExecBackend) SetSlashInterval(interval int) (bool, string) {
+	return b.run("slash", "--interval", strconv.Itoa(clamp(interval, 0, slashIntervalMax)))
+}
+
+func (b *ExecBackend) SetSlashMode(mode string) (bool, string) {
+	return b.run("slash", "--mode", mode)
+}
+
+func (b *ExecBackend) SlashHelp() (bool, string) {
+	return b.run("slash", "--help")
+}
@@ -0,0 +1,251 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// Page: Slash
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// slashView is what the Slash tab shows; read at startup and on tab entry.
+type slashView struct {
+	SlashState
+	modes []string // from `asusctl slash --help`, else defaultSlashModes
+}
+
+func readSlashView(b Backend) slashView {
+	v := slashView{SlashState: b.GetSlashState()}
+	if !v.Present {
+		return v
+	}
+	if ok, out := b.SlashHelp(); ok {
+		v.modes = parseSlashModes(out)
+	}
+	if len(v.modes) == 0 {
+		v.modes = defaultSlashModes
+	}
+	return v
+}
+
+// Rows above the mode grid; focusIdx slashRowModes+i is mode i.
+const (
+	slashRowEnable = iota
+	slashRowBrightness
+	slashRowInterval
+	slashRowModes
+)
+
+// slashBrightnessStep is one ←/→ press on the brightness slider.
+const slashBrightnessStep = 15
+
+func (a *App) slashCols() int {
+	if a.term.Width() > 80 {
+		return 4
+	}
+	return 3
+}
+
+func (a *App) renderSlash(y, h int) {
+	t := a.term
+	W := t.Width()
+	cx := a.padX()
+	s := &a.slash
+
+	t.TextBold(cx, y+1, ColText, "Slash Lighting")
+	t.Text(cx, y+2, ColTextDim, "The LED bar on the lid of 2024 and later models")
+
+	if !s.Present {
+		t.Text(cx, y+4, ColTextMut, "No Slash bar found ("+slashRon+" does not exist)")
+		t.Text(cx, y+5, ColTextMut, "asusd creates it on laptops that have one")
+		return
+	}
+
+	label := func(row, idx int, text string) {
+		if a.focusIdx == idx {
+			a.focusText(cx, row, "▸ "+text)
+		} else {
+			t.Text(cx, row, ColTextDim, "  "+text)
+		}
+	}
+
+	row := y + 4
+	label(row, slashRowEnable, "Enabled")
+	t.DrawToggle(cx+20, row, s.Enabled)
+
+	row += 2
+	label(row, slashRowBrightness, "Brightness")
+	barW := min(W-34, 30)
+	t.DrawBar(cx+20, row, barW, float64(s.Brightness)/slashBrightnessMax, a.accent(), ColInput)
+	t.Text(cx+21+barW, row, ColText, fmt.Sprintf("%d%%", s.Brightness*100/slashBrightnessMax))
+
+	row += 2
+	label(row, slashRowInterval, "Interval")
+	for i := 0; i <= slashIntervalMax; i++ {
+		col := ColTextMut
+		if i == s.Interval {
+			col = a.accent()
+		}
+		t.Text(cx+20+i*3, row, col, strconv.Itoa(i))
+	}
+	t.Text(cx+21+(slashIntervalMax+1)*3, row, ColTextMut, "(animation delay)")
+
+	row += 2
+	t.TextBold(cx, row, a.accent(), "Mode")
+	cols := a.slashCols()
+	for i, m := range s.modes {
+		px := cx + 2 + (i%cols)*16
+		py := row + 2 + (i/cols)*2
+		marker := "○"
+		if m == s.Mode {
+			marker = "●"
+		}
+		switch {
+		case a.focusIdx == slashRowModes+i:
+			t.ResetStyle()
+			t.Bold()
+			t.Fg(ColText)
+			t.MoveTo(px, py)
+			a.writeFocused("▸" + marker + " " + m)
+		case m == s.Mode:
+			t.TextBold(px, py, a.accent(), " "+marker+" "+m)
+		default:
+			t.Text(px, py, ColTextDim, " "+marker+" "+m)
+		}
+	}
+
+	row += 2 + ((len(s.modes)-1)/cols+1)*2
+	t.Text(cx, row, ColTextMut, "↑↓ select  ←→ adjust / move  Enter toggle / set mode  r refresh")
+}
+
+func (a *App) handleSlash(key KeyEvent) {
+	s := &a.slash
+	if !s.Present {
+		if key.Type == KeyChar && key.Char == 'r' {
+			a.slash = readSlashView(a.backend)
+		}
+		return
+	}
+	cols := a.slashCols()
+	last := slashRowModes + len(s.modes) - 1
+	switch key.Type {
+	case KeyUp:
+		switch {
+		case a.focusIdx >= slashRowModes+cols:
+			a.focusIdx -= cols
+		case a.focusIdx >= slashRowModes:
+			a.focusIdx = slashRowInterval
+		case a.focusIdx > 0:
+			a.focusIdx--
+		}
+	case KeyDown:
+		switch {
+		case a.focusIdx < slashRowModes:
+			a.focusIdx++
+		case a.focusIdx+cols <= last:
+			a.focusIdx += cols
+		}
+	case KeyLeft, KeyRight:
+		dir := 1
+		if key.Type == KeyLeft {
+			dir = -1
+		}
+		switch a.focusIdx {
+		case slashRowBrightness:
+			a.setSlashBrightness(clamp(s.Brightness+dir*slashBrightnessStep, 0, slashBrightnessMax))
+		case slashRowInterval:
+			a.setSlashInterval(clamp(s.Interval+dir, 0, slashIntervalMax))
+		case slashRowEnable:
+		default:
+			a.focusIdx = clamp(a.focusIdx+dir, slashRowModes, last)
+		}
+	case KeyEnter:
+		switch {
+		case a.focusIdx == slashRowEnable:
+			a.setSlashEnabled(!s.Enabled)
+		case a.focusIdx >= slashRowModes:
+			a.setSlashMode(s.modes[a.focusIdx-slashRowModes])
+		}
+	case KeyChar:
+		if key.Char == 'r' {
+			a.slash = readSlashView(a.backend)
+			a.focusIdx = clamp(a.focusIdx, 0, slashRowModes+len(a.slash.modes)-1)
+			a.SetStatus("Slash state refreshed", true)
+		}
+	}
+}
+
+func (a *App) setSlashEnabled(on bool) {
+	if a.locked("aura") {
+		return
+	}
+	a.submit("slash_enable", func(b Backend) (bool, string) {
+		return b.SetSlashEnable(on)
+	}, func(ok bool, out string, argv []string) {
+		a.logCommand(argv, out, ok)
+		if !ok {
+			a.SetError(out)
+			return
+		}
+		a.slash.Enabled = on
+		st := "OFF"
+		if on {
+			st = "ON"
+		}
+		a.SetStatus("Slash → "+st, true)
+	})
+}
+
+// setSlashBrightness and setSlashInterval go through the queue, since
+// holding ←/→ sends one write per step.
+func (a *App) setSlashBrightness(level int) {
+	if a.locked("aura") {
+		return
+	}
+	a.slash.Brightness = level
+	a.submit("slash_brightness", func(b Backend) (bool, string) {
+		return b.SetSlashBrightness(level)
+	}, func(ok bool, out string, argv []string) {
+		if ok {
+			a.SetStatus(fmt.Sprintf("Slash brightness → %d%%", level*100/slashBrightnessMax), true)
+		} else {
+			a.SetError(out)
+		}
+		a.logCommand(argv, out, ok)
+	})
+}
+
+func (a *App) setSlashInterval(interval int) {
+	if a.locked("aura") {
+		return
+	}
+	a.slash.Interval = interval
+	a.submit("slash_interval", func(b Backend) (bool, string) {
+		return b.SetSlashInterval(interval)
+	}, func(ok bool, out string, argv []string) {
+		if ok {
+			a.SetStatus(fmt.Sprintf("Slash interval → %d", interval), true)
+		} else {
+			a.SetError(out)
+		}
+		a.logCommand(argv, out, ok)
+	})
+}
+
+func (a *App) setSlashMode(mode string) {
+	if a.locked("aura") {
+		return
+	}
+	a.submit("slash_mode", func(b Backend) (bool, string) {
+		return b.SetSlashMode(mode)
+	}, func(ok bool, out string, argv []string) {
+		a.logCommand(argv, out, ok)
+		if !ok {
+			a.SetError(out)
+			return
+		}
+		a.slash.Mode = mode
+		a.SetStatus("Slash mode → "+mode, true)
+	})
+}
@@ -0,0 +1,194 @@
+package main
+
+import "fmt"
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// Page: System
+// ═══════════════════════════════════════════════════════════════════════════════
+
+func (a *App) renderSystem(y, h int) {
+	t := a.term
+	cx := a.padX()
+
+	t.TextBold(cx, y+1, ColText, "System")
+	t.Text(cx, y+2, ColTextDim, "Platform state that asusctl does not manage")
+
+	// Service panel
+	t.TextBold(cx, y+4, a.accent(), "Service")
+	label := fmt.Sprintf("%-22s", "asusd")
+	if a.focusIdx == 0 {
+		a.focusText(cx, y+6, "▸ "+label)
+	} else {
+		t.Text(cx, y+6, ColTextDim, "  "+label)
+	}
+	st := a.daemonStatus
+	if st == "" {
+		st = "unknown (no systemd)"
+	}
+	t.Text(cx+29, y+6, daemonStatusColor(a.daemonStatus), "● "+st)
+
+	// Platform panel
+	t.TextBold(cx, y+8, a.accent(), "Platform")
+	if len(a.platformLeds) == 0 {
+		t.Text(cx+2, y+10, ColTextMut, "No camera or mic indicators exposed by asus-wmi on this machine")
+		t.Text(cx, y+12, ColTextMut, "Enter restart asusd  r refresh")
+		return
+	}
+	pitch := a.spread(2)
+	for i, led := range a.platformLeds {
+		row := y + 10 + i*pitch
+		label := fmt.Sprintf("%-22s", led.Label)
+		if a.focusIdx == i+1 {
+			a.focusText(cx, row, "▸ "+label)
+		} else {
+			t.Text(cx, row, ColTextDim, "  "+label)
+		}
+		if led.Writable {
+			t.DrawToggle(cx+28, row, led.On)
+		} else {
+			st, col := "○ OFF", ColTextMut
+			if led.On {
+				st, col = "● ON", ColSuccess
+			}
+			t.Text(cx+29, row, col, st)
+			t.Text(cx+37, row, ColTextMut, "(read-only)")
+		}
+	}
+	help := "↑↓ select  Enter toggle  r refresh"
+	if a.focusIdx == 0 {
+		help = "↑↓ select  Enter restart asusd  r refresh"
+	}
+	t.Text(cx, y+11+len(a.platformLeds)*pitch, ColTextMut, help)
+}
+
+// daemonStatusColor colours a systemctl is-active state.
+func daemonStatusColor(status string) Color {
+	switch {
+	case status == "active":
+		return ColSuccess
+	case daemonDown(status):
+		return ColError
+	case status == "":
+		return ColTextMut
+	}
+	return ColWarning // activating, deactivating, reloading
+}
+
+func (a *App) handleSystem(key KeyEvent) {
+	switch key.Type {
+	case KeyUp:
+		if a.focusIdx > 0 {
+			a.focusIdx--
+		}
+	case KeyDown:
+		if a.focusIdx < len(a.platformLeds) {
+			a.focusIdx++
+		}
+	case KeyEnter:
+		if a.focusIdx == 0 {
+			a.confirmDaemonRestart()
+		} else {
+			a.togglePlatformLed()
+		}
+	case KeyChar:
+		if key.Char == 'r' {
+			a.daemonStatus = a.backend.DaemonStatus()
+			a.platformLeds = a.backend.GetPlatformLeds()
+			a.focusIdx = clamp(a.focusIdx, 0, len(a.platformLeds))
+			a.SetStatus("System state refreshed (asusd "+orDash(a.daemonStatus)+")", true)
+		}
+	}
+}
+
+// ─── asusd service ───────────────────────────────────────────────────────────
+
+// offerDaemonRestart is shown at startup when asusd is stopped or crashed.
+func (a *App) offerDaemonRestart() {
+	if a.cfg.Lockdown.ActionLocked("daemon_restart") {
+		return
+	}
+	a.confirm = &Confirm{
+		Title: "asusd is " + a.daemonStatus,
+		Lines: []string{
+			"The asusd daemon is not running, so most controls will fail.",
+			"",
+			"Restart it now with `systemctl restart asusd`?",
+		},
+		OnYes: a.restartDaemon,
+	}
+}
+
+func (a *App) confirmDaemonRestart() {
+	if daemonDown(a.daemonStatus) {
+		a.restartDaemon()
+		return
+	}
+	// restarting reapplies asusd's saved settings over the current ones,
+	// so this asks even with confirm_prompts off
+	a.confirm = &Confirm{
+		Title: "Restart asusd",
+		Lines: []string{
+			"asusd is " + orDash(a.daemonStatus) + ".",
+			"Restarting reapplies its saved settings.",
+			"",
+			"Restart it now?",
+		},
+		OnYes: a.restartDaemon,
+	}
+}
+
+func (a *App) restartDaemon() {
+	if a.locked("daemon_restart") {
+		return
+	}
+	ok, out := a.backend.RestartDaemon()
+	a.logAction(out, ok)
+	if !ok {
+		a.SetError(out)
+		a.offerElevation(out, a.daemonRestarted)
+		return
+	}
+	a.daemonRestarted()
+}
+
+// daemonRestarted re-reads what asusd may have reset on startup.
+func (a *App) daemonRestarted() {
+	a.daemonStatus = a.backend.DaemonStatus()
+	for _, area := range []ChangeArea{ChangeProfile, ChangeKeyboard, ChangeAura, ChangeFans} {
+		a.syncArea(area)
+	}
+	a.SetStatus("asusd restarted ("+orDash(a.daemonStatus)+")", true)
+}
+
+func (a *App) togglePlatformLed() {
+	i := a.focusIdx - 1
+	if i < 0 || i >= len(a.platformLeds) {
+		return
+	}
+	led := a.platformLeds[i]
+	if !led.Writable {
+		a.SetStatus(led.Label+" is read-only here (needs root or a udev rule)", false)
+		return
+	}
+	on := !led.On
+	st := "OFF"
+	if on {
+		st = "ON"
+	}
+	a.submit("platform_led:"+led.ID, func(b Backend) (bool, string) {
+		return b.SetPlatformLed(led.ID, on)
+	}, func(ok bool, out string, _ []string) {
+		a.addLog("sysfs "+led.ID+" → "+st, out, ok)
+		if !ok {
+			a.SetError(out)
+			return
+		}
+		// the list may have been re-read meanwhile
+		for i := range a.platformLeds {
+			if a.platformLeds[i].ID == led.ID {
+				a.platformLeds[i].On = on
+			}
+		}
+		a.SetStatus(led.Label+" → "+st, true)
+	})
+}
@@ -3,10 +3,12 @@ package main
 import (
 	"bufio"
 	"fmt"
+	"io"
 	"os"
 	"strings"
 	"sync"
 	"syscall"
+	"time"
 	"unsafe"
 )
 
@@ -22,6 +24,11 @@ type Terminal struct {
 	buf         strings.Builder
 	mu          sync.Mutex
 	inRaw       bool
+	out         io.Writer // frame destination, os.Stdout unless headless
+	fixedSize   bool      // headless terminals keep their size, no ioctl
+	accent      Color     // accent for widgets, zero means ColAccent
+	anim        *Animator // nil draws every widget at its final value
+	ascii       bool      // ascii_mode: Write transliterates with asciiGlyphs
 }
 
 // termios ioctl constants
@@ -36,12 +43,21 @@ type winsize struct {
 }
 
 func NewTerminal() *Terminal {
-	t := &Terminal{}
+	t := &Terminal{out: os.Stdout}
 	t.updateSize()
 	return t
 }
 
+// NewFakeTerminal returns a headless terminal of a fixed size that renders
+// frames into out instead of stdout. Used for benchmarks and tests.
+func NewFakeTerminal(w, h int, out io.Writer) *Terminal {
+	return &Terminal{width: w, height: h, out: out, fixedSize: true}
+}
+
 func (t *Terminal) updateSize() {
+	if t.fixedSize {
+		return
+	}
 	ws := &winsize{}
 	_, _, _ = syscall.Syscall(syscall.SYS_IOCTL,
 		uintptr(syscall.Stdout),
@@ -93,8 +109,8 @@ func (t *Terminal) EnterRaw() error {
 	}
 	t.inRaw = true
 
-	// Hide cursor, enable alternate screen buffer, enable mouse (for potential future use)
-	fmt.Fprint(os.Stdout, "\033[?1049h\033[?25l")
+	// Hide cursor, enable alternate screen buffer and bracketed paste
+	fmt.Fprint(os.Stdout, "\033[?1049h\033[?25l\033[?2004h")
 	return nil
 }
 
@@ -102,8 +118,8 @@ func (t *Terminal) ExitRaw() {
 	if !t.inRaw {
 		return
 	}
-	// Show cursor, restore main screen buffer
-	fmt.Fprint(os.Stdout, "\033[?25h\033[?1049l")
+	// Show cursor, restore main screen buffer, end bracketed paste
+	fmt.Fprint(os.Stdout, "\033[?2004l\033[?25h\033[?1049l")
 	syscall.Syscall(syscall.SYS_IOCTL,
 		uintptr(syscall.Stdin),
 		uintptr(ioctlSetTermios),
@@ -111,10 +127,26 @@ func (t *Terminal) ExitRaw() {
 	t.inRaw = false
 }
 
+// Suspend leaves raw mode and the alternate screen, runs fn with the
+// terminal as the shell had it (for an editor), then takes it back. A fake
+// terminal just runs fn.
+func (t *Terminal) Suspend(fn func()) error {
+	if !t.inRaw {
+		fn()
+		return nil
+	}
+	t.ExitRaw()
+	fn()
+	err := t.EnterRaw()
+	t.updateSize()
+	return err
+}
+
 // ─── Buffered ANSI output ────────────────────────────────────────────────────
 
 func (t *Terminal) Clear() {
 	t.buf.Reset()
+	t.anim.beginFrame()
 }
 
 func (t *Terminal) MoveTo(x, y int) {
@@ -149,10 +181,50 @@ func (t *Terminal) Reverse() {
 	t.buf.WriteString("\033[7m")
 }
 
+func (t *Terminal) ReverseOff() {
+	t.buf.WriteString("\033[27m")
+}
+
+func (t *Terminal) Blink() {
+	t.buf.WriteString("\033[5m")
+}
+
+func (t *Terminal) BlinkOff() {
+	t.buf.WriteString("\033[25m")
+}
+
+// Bell rings the terminal bell straight away, outside the frame.
+func (t *Terminal) Bell() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.out.Write([]byte("\a"))
+}
+
 func (t *Terminal) Write(s string) {
+	if t.ascii {
+		asciiGlyphs.WriteString(&t.buf, s)
+		return
+	}
 	t.buf.WriteString(s)
 }
 
+// SetASCII draws every glyph the UI uses with plain ASCII, for consoles and
+// fonts without them.
+func (t *Terminal) SetASCII(on bool) { t.ascii = on }
+
+// asciiGlyphs keeps each glyph's width: one character for one column, two
+// for the wide emoji.
+var asciiGlyphs = strings.NewReplacer(
+	"█", "#", "▀", "#", "▄", "#", "▗", "#", "▖", "#", "▝", "#", "▘", "#", "░", ".",
+	"─", "-", "┄", "-", "┊", "|", "│", "|", "‖", "|", "┌", "+", "┐", "+", "└", "+", "┘", "+",
+	"→", ">", "←", "<", "↑", "^", "↓", "v", "↳", ">",
+	"▸", ">", "◂", "<", "‹", "<", "›", ">", "❯", ">",
+	"●", "*", "◉", "*", "◆", "*", "○", "o", "★", "*",
+	"—", "-", "–", "-", "·", ".", "…", ".", "°", " ", "≤", "<",
+	"✓", "+", "✗", "x", "⚠", "!", "⟳", "~",
+	"⚡", "!!", "⚖", "=", "🔇", "~~", "🔒", "# ",
+)
+
 func (t *Terminal) Flush() {
 	t.mu.Lock()
 	defer t.mu.Unlock()
@@ -160,19 +232,28 @@ func (t *Terminal) Flush() {
 	// The terminal holds all rendering until the end marker, then
 	// paints the entire frame at once. Supported by all modern terminals;
 	// unsupported terminals silently ignore the sequences.
-	w := bufio.NewWriterSize(os.Stdout, t.buf.Len()+64)
+	w := bufio.NewWriterSize(t.out, t.buf.Len()+64)
 	w.WriteString("\033[?2026h") // begin synchronized update
 	w.WriteString("\033[H")      // home cursor
 	w.WriteString(t.buf.String())
 	w.WriteString("\033[?2026l") // end synchronized update — terminal renders now
 	w.Flush()
+	t.anim.endFrame()
 }
 
+// SetAnimator turns on eased transitions for toggles and bars.
+func (t *Terminal) SetAnimator(an *Animator) { t.anim = an }
+
+// Animating reports whether the next frames differ even without input.
+func (t *Terminal) Animating() bool { return t.anim.Active() }
+
 // ─── Input ───────────────────────────────────────────────────────────────────
 
 type KeyEvent struct {
 	Type KeyType
 	Char rune
+	Alt  bool   // KeyChar typed with Alt (sent as ESC + char)
+	Text string // KeyPaste: the pasted text, unsanitized
 }
 
 type KeyType int
@@ -196,8 +277,23 @@ const (
 	KeyCtrlQ
 	KeyCtrlS
 	KeyCtrlR
+	KeyCtrlO
+	KeyCtrlP
+	KeyCtrlU
+	KeyCtrlW
+	KeyPaste
 )
 
+// WaitInput waits up to d for stdin to become readable. Used between
+// animation frames, where ReadKey's 100ms timeout would be too coarse.
+func WaitInput(d time.Duration) bool {
+	var fds syscall.FdSet
+	fds.Bits[0] |= 1 << uint(syscall.Stdin)
+	tv := syscall.NsecToTimeval(d.Nanoseconds())
+	n, err := syscall.Select(syscall.Stdin+1, &fds, nil, nil, &tv)
+	return err == nil && n > 0
+}
+
 func ReadKey() KeyEvent {
 	reader := bufio.NewReader(os.Stdin)
 	b, err := reader.ReadByte()
@@ -212,10 +308,18 @@ func ReadKey() KeyEvent {
 		return KeyEvent{Type: KeyCtrlC}
 	case 17: // Ctrl-Q
 		return KeyEvent{Type: KeyCtrlQ}
+	case 15: // Ctrl-O
+		return KeyEvent{Type: KeyCtrlO}
+	case 16: // Ctrl-P
+		return KeyEvent{Type: KeyCtrlP}
 	case 18: // Ctrl-R
 		return KeyEvent{Type: KeyCtrlR}
 	case 19: // Ctrl-S
 		return KeyEvent{Type: KeyCtrlS}
+	case 21: // Ctrl-U
+		return KeyEvent{Type: KeyCtrlU}
+	case 23: // Ctrl-W
+		return KeyEvent{Type: KeyCtrlW}
 	case 9: // Tab
 		return KeyEvent{Type: KeyTab}
 	case 10, 13: // Enter
@@ -243,6 +347,33 @@ func ReadKey() KeyEvent {
 				return KeyEvent{Type: KeyHome}
 			case 'F':
 				return KeyEvent{Type: KeyEnd}
+			case '1':
+				// ESC[1~ is Home (tmux); ESC[1;5C / ESC[1;3D are
+				// Ctrl/Alt+arrows, which move by word like Alt+F / Alt+B
+				if b4, _ := reader.ReadByte(); b4 == ';' {
+					reader.ReadByte() // modifier
+					switch b5, _ := reader.ReadByte(); b5 {
+					case 'C':
+						return KeyEvent{Type: KeyChar, Char: 'f', Alt: true}
+					case 'D':
+						return KeyEvent{Type: KeyChar, Char: 'b', Alt: true}
+					}
+					return KeyEvent{Type: KeyEscape}
+				}
+				return KeyEvent{Type: KeyHome}
+			case '7': // Home on rxvt
+				reader.ReadByte()
+				return KeyEvent{Type: KeyHome}
+			case '4', '8':
+				reader.ReadByte()
+				return KeyEvent{Type: KeyEnd}
+			case '2': // ESC[200~ starts a bracketed paste
+				if b4, _ := reader.Peek(3); string(b4) == "00~" {
+					reader.Discard(3)
+					return KeyEvent{Type: KeyPaste, Text: readPaste(reader)}
+				}
+				reader.ReadByte()
+				return KeyEvent{Type: KeyEscape}
 			case '3':
 				reader.ReadByte() // consume ~
 				return KeyEvent{Type: KeyDelete}
@@ -255,14 +386,61 @@ func ReadKey() KeyEvent {
 			}
 			return KeyEvent{Type: KeyEscape}
 		}
+		if b2 == 'O' { // Home/End in application cursor mode
+			b3, _ := reader.ReadByte()
+			switch b3 {
+			case 'H':
+				return KeyEvent{Type: KeyHome}
+			case 'F':
+				return KeyEvent{Type: KeyEnd}
+			}
+			return KeyEvent{Type: KeyEscape}
+		}
+		if b2 >= 32 && b2 < 127 { // Alt+key
+			return KeyEvent{Type: KeyChar, Char: rune(b2), Alt: true}
+		}
 		return KeyEvent{Type: KeyEscape}
-	case 127: // Backspace
+	case 8, 127: // Backspace (Ctrl-H on some terminals)
 		return KeyEvent{Type: KeyBackspace}
 	default:
 		return KeyEvent{Type: KeyChar, Char: rune(b)}
 	}
 }
 
+const (
+	pasteEnd = "\033[201~"
+	pasteMax = 64 << 10
+)
+
+// readPaste collects a bracketed paste up to its end marker. Reads time
+// out after 100ms in raw mode, so a paste that stops arriving for a second
+// is cut off rather than hanging the UI. Past pasteMax bytes the rest is
+// read and dropped up to the marker, so it is never taken for keys.
+func readPaste(reader *bufio.Reader) string {
+	var buf, last []byte // last: the latest bytes, to spot the marker
+	n, idle := 0, 0
+	for idle < 10 {
+		b, err := reader.ReadByte()
+		if err != nil {
+			idle++
+			continue
+		}
+		idle = 0
+		n++
+		if len(buf) < pasteMax {
+			buf = append(buf, b)
+		}
+		last = append(last, b)
+		if len(last) > len(pasteEnd) {
+			last = last[1:]
+		}
+		if string(last) == pasteEnd {
+			return string(buf[:min(len(buf), n-len(pasteEnd))])
+		}
+	}
+	return string(buf)
+}
+
 // ─── Drawing Helpers ─────────────────────────────────────────────────────────
 
 // Pad or truncate string to exact width
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"bufio"
+	"strings"
+	"testing"
+)
+
+func TestReadPaste(t *testing.T) {
+	long := strings.Repeat("x", pasteMax+100)
+	tests := []struct {
+		name, in, want, rest string
+	}{
+		{"short", "hello" + pasteEnd + "q", "hello", "q"},
+		{"empty", pasteEnd, "", ""},
+		{"oversized", long + "qqq" + pasteEnd + "j", long[:pasteMax], "j"},
+		{"marker past the limit", long[:pasteMax-2] + pasteEnd + "j", long[:pasteMax-2], "j"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := bufio.NewReader(strings.NewReader(tt.in))
+			if got := readPaste(r); got != tt.want {
+				t.Errorf("paste is %d bytes, want %d", len(got), len(tt.want))
+			}
+			rest := make([]byte, 16)
+			k, _ := r.Read(rest)
+			if string(rest[:k]) != tt.rest {
+				t.Errorf("left %q to read as keys, want %q", rest[:k], tt.rest)
+			}
+		})
+	}
+}
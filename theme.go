@@ -1,5 +1,7 @@
 package main
 
+import "strings"
+
 // ═══════════════════════════════════════════════════════════════════════════════
 // Theme — colors and box-drawing primitives
 // ═══════════════════════════════════════════════════════════════════════════════
@@ -25,11 +27,61 @@ var (
 	ColBal      = Color{59, 130, 246}
 	ColQuiet    = Color{34, 197, 94}
 	ColAura     = Color{168, 85, 247}
+	ColBattery  = Color{34, 197, 94}
+	ColFans     = Color{249, 115, 22}
 )
 
+// themes are the palettes the theme setting picks from. Only the neutrals
+// change; the accents and status colours read on all of them.
+var themes = []struct {
+	name                                                 string
+	bg, panel, card, input, border, text, textDim, muted Color
+}{
+	{"dark", Color{10, 10, 12}, Color{20, 20, 24}, Color{28, 28, 32}, Color{38, 38, 44},
+		Color{50, 50, 58}, Color{228, 228, 231}, Color{113, 113, 122}, Color{63, 63, 70}},
+	{"high-contrast", Color{0, 0, 0}, Color{0, 0, 0}, Color{18, 18, 18}, Color{44, 44, 44},
+		Color{200, 200, 200}, Color{255, 255, 255}, Color{225, 225, 225}, Color{175, 175, 175}},
+	{"light", Color{250, 250, 250}, Color{235, 235, 238}, Color{225, 225, 230}, Color{210, 210, 216},
+		Color{180, 180, 188}, Color{24, 24, 27}, Color{82, 82, 91}, Color{140, 140, 150}},
+}
+
+// themeNames lists the themes in settings order.
+func themeNames() []string {
+	var names []string
+	for _, th := range themes {
+		names = append(names, th.name)
+	}
+	return names
+}
+
+// applyTheme sets the neutral colours; an unknown name is the dark theme.
+func applyTheme(name string) {
+	th := themes[max(indexFold(themeNames(), name), 0)]
+	ColBg, ColPanel, ColCard, ColInput = th.bg, th.panel, th.card, th.input
+	ColBorder, ColText, ColTextDim, ColTextMut = th.border, th.text, th.textDim, th.muted
+}
+
+// tabAccents replaces ColAccent on these tabs when tab_accents is enabled,
+// so the current section is recognisable at a glance.
+var tabAccents = map[Tab]Color{
+	TabAura:    ColAura,
+	TabBattery: ColBattery,
+	TabFans:    ColFans,
+}
+
 func (t *Terminal) Fg(c Color) { t.SetFg(c.R, c.G, c.B) }
 func (t *Terminal) Bg(c Color) { t.SetBg(c.R, c.G, c.B) }
 
+// SetAccent sets the colour widgets such as DrawToggle use for "on".
+func (t *Terminal) SetAccent(c Color) { t.accent = c }
+
+func (t *Terminal) Accent() Color {
+	if t.accent == (Color{}) {
+		return ColAccent
+	}
+	return t.accent
+}
+
 // ─── Box Drawing ─────────────────────────────────────────────────────────────
 
 // Draw a box with single-line Unicode characters
@@ -97,6 +149,7 @@ func (t *Terminal) TextBold(x, y int, fg Color, s string) {
 
 // Draw a horizontal progress bar
 func (t *Terminal) DrawBar(x, y, w int, pct float64, fg, bg Color) {
+	pct = t.anim.value(animKey{'b', x, y}, pct)
 	filled := int(pct * float64(w))
 	filled = clamp(filled, 0, w)
 
@@ -131,20 +184,101 @@ func (t *Terminal) DrawButton(x, y int, label string, selected bool, accent Colo
 	_ = w
 }
 
-// Draw a toggle switch
+// Draw a toggle switch. The label flips at once; the colour fades across
+// when an animator is set.
 func (t *Terminal) DrawToggle(x, y int, on bool) {
+	target := 0.0
+	if on {
+		target = 1
+	}
+	p := t.anim.value(animKey{'t', x, y}, target)
+	t.ResetStyle()
+	t.Bg(lerpColor(ColInput, t.Accent(), p))
+	t.Fg(lerpColor(ColTextDim, Color{255, 255, 255}, p))
+	t.MoveTo(x, y)
 	if on {
-		t.ResetStyle()
-		t.Bg(ColAccent)
-		t.Fg(Color{255, 255, 255})
-		t.MoveTo(x, y)
 		t.Write(" ◉ ON  ")
 	} else {
-		t.ResetStyle()
-		t.Bg(ColInput)
-		t.Fg(ColTextDim)
-		t.MoveTo(x, y)
 		t.Write(" ○ OFF ")
 	}
 	t.ResetStyle()
 }
+
+// ─── Big digits ──────────────────────────────────────────────────────────────
+
+// bigGlyphs is a 3-row block font for values meant to be read from across
+// the room. Every row of a glyph has the same width.
+var bigGlyphs = map[rune][3]string{
+	'0': {"█▀█", "█ █", "▀▀▀"},
+	'1': {"▀█ ", " █ ", "▀▀▀"},
+	'2': {"▀▀█", "█▀▀", "▀▀▀"},
+	'3': {"▀▀█", " ▀█", "▀▀▀"},
+	'4': {"█ █", "▀▀█", "  ▀"},
+	'5': {"█▀▀", "▀▀█", "▀▀▀"},
+	'6': {"█▀▀", "█▀█", "▀▀▀"},
+	'7': {"▀▀█", "  █", "  ▀"},
+	'8': {"█▀█", "█▀█", "▀▀▀"},
+	'9': {"█▀█", "▀▀█", "▀▀▀"},
+	'-': {"   ", "▀▀▀", "   "},
+	'.': {" ", " ", "▀"},
+	':': {"▄", "▄", " "},
+	'%': {"▀ █", " █ ", "█ ▄"},
+	'°': {"▗▖", "▝▘", "  "},
+	'C': {"█▀▀", "█  ", "▀▀▀"},
+	'F': {"█▀▀", "█▀ ", "▀  "},
+	' ': {"  ", "  ", "  "},
+}
+
+// BigTextWidth returns how many columns BigText needs for s.
+func BigTextWidth(s string) int {
+	w := 0
+	for i, r := range []rune(s) {
+		g, ok := bigGlyphs[r]
+		if !ok {
+			g = bigGlyphs[' ']
+		}
+		if i > 0 {
+			w++
+		}
+		w += len([]rune(g[0]))
+	}
+	return w
+}
+
+// BigText draws s three rows tall at x,y. Characters without a glyph are
+// drawn as blanks. Returns the width used.
+func (t *Terminal) BigText(x, y int, fg Color, s string) int {
+	var rows [3]strings.Builder
+	for i, r := range []rune(s) {
+		g, ok := bigGlyphs[r]
+		if !ok {
+			g = bigGlyphs[' ']
+		}
+		for row := range rows {
+			if i > 0 {
+				rows[row].WriteByte(' ')
+			}
+			rows[row].WriteString(g[row])
+		}
+	}
+	t.ResetStyle()
+	t.Fg(fg)
+	for row := range rows {
+		t.MoveTo(x, y+row)
+		t.Write(rows[row].String())
+	}
+	t.ResetStyle()
+	return BigTextWidth(s)
+}
+
+// DrawBigGauge shows value in big digits centred in a w-wide column, with a
+// dim label underneath (4 rows in total). Falls back to a single bold line
+// when the value does not fit.
+func (t *Terminal) DrawBigGauge(x, y, w int, value, label string, fg Color) {
+	if bw := BigTextWidth(value); bw <= w {
+		t.BigText(x+(w-bw)/2, y, fg, value)
+	} else {
+		t.TextBold(x, y+1, fg, center(value, w))
+	}
+	t.Text(x, y+3, ColTextDim, center(label, w))
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// Thermal — CPU throttle counters and package temperature from sysfs
+// Intel CPUs count throttling per core and per package under
+// /sys/devices/system/cpu/cpu*/thermal_throttle; a rising counter is a
+// throttling event. AMD exposes no such counters.
+// ═══════════════════════════════════════════════════════════════════════════════
+
+type ThermalControl interface {
+	GetThermalState() ThermalState
+}
+
+// ThermalState is one reading of the throttle counters.
+type ThermalState struct {
+	Supported bool    // throttle counters exist on this CPU
+	Core      uint64  // core throttle events, summed over all cores
+	Package   uint64  // package throttle events
+	TempC     float64 // CPU package temperature, 0 if unknown
+}
+
+const cpuSysfsDir = "/sys/devices/system/cpu"
+
+func readThrottleCounters() (core, pkg uint64, ok bool) {
+	dirs, _ := filepath.Glob(filepath.Join(cpuSysfsDir, "cpu[0-9]*", "thermal_throttle"))
+	for _, d := range dirs {
+		if n, err := strconv.ParseUint(readSysfsString(filepath.Join(d, "core_throttle_count")), 10, 64); err == nil {
+			core += n
+			ok = true
+		}
+	}
+	// every core of a package reports the same package count; one is enough
+	if len(dirs) > 0 {
+		pkg, _ = strconv.ParseUint(readSysfsString(filepath.Join(dirs[0], "package_throttle_count")), 10, 64)
+	}
+	return core, pkg, ok
+}
+
+// cpuTempSensors are hwmon drivers whose temp1 is the CPU package.
+var cpuTempSensors = []string{"coretemp", "k10temp", "zenpower"}
+
+// readCPUTemp returns the CPU package temperature in °C, or 0.
+func readCPUTemp() float64 {
+	if t := readHwmonTemp(cpuTempSensors); t > 0 {
+		return t
+	}
+	// fall back to the x86_pkg_temp thermal zone
+	zones, _ := filepath.Glob("/sys/class/thermal/thermal_zone*")
+	for _, z := range zones {
+		if strings.HasPrefix(readSysfsString(filepath.Join(z, "type")), "x86_pkg_temp") {
+			if v, ok := readSysfsMicro(filepath.Join(z, "temp")); ok {
+				return v * 1000
+			}
+		}
+	}
+	return 0
+}
+
+func (b *ExecBackend) GetThermalState() ThermalState {
+	core, pkg, ok := readThrottleCounters()
+	return ThermalState{Supported: ok, Core: core, Package: pkg, TempC: readCPUTemp()}
+}
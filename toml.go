@@ -0,0 +1,564 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// TOML — the subset the config file needs, stdlib only.
+// Supports: key = value, [table], [a.b], [[array-of-tables]], strings
+// (basic + literal), integers, floats, booleans and arrays of those.
+// Values decode into structs via `toml:"name"` field tags.
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// ─── Decoding ────────────────────────────────────────────────────────────────
+
+type tomlTable = map[string]any
+
+// parseToml parses a document into nested maps. Arrays of tables are []any
+// of tomlTable.
+func parseToml(src string) (tomlTable, error) {
+	root := tomlTable{}
+	cur := root
+	lines := strings.Split(src, "\n")
+	for i := 0; i < len(lines); i++ {
+		lineNo := i + 1
+		line := strings.TrimSpace(stripTomlComment(lines[i]))
+		if line == "" {
+			continue
+		}
+		switch {
+		case strings.HasPrefix(line, "[["):
+			if !strings.HasSuffix(line, "]]") {
+				return nil, fmt.Errorf("line %d: unterminated [[table]]", lineNo)
+			}
+			path := splitTomlKey(line[2 : len(line)-2])
+			parent, err := tomlDescend(root, path[:len(path)-1], lineNo)
+			if err != nil {
+				return nil, err
+			}
+			last := path[len(path)-1]
+			arr, _ := parent[last].([]any)
+			t := tomlTable{}
+			parent[last] = append(arr, t)
+			cur = t
+		case strings.HasPrefix(line, "["):
+			if !strings.HasSuffix(line, "]") {
+				return nil, fmt.Errorf("line %d: unterminated [table]", lineNo)
+			}
+			t, err := tomlDescend(root, splitTomlKey(line[1:len(line)-1]), lineNo)
+			if err != nil {
+				return nil, err
+			}
+			cur = t
+		default:
+			eq := strings.Index(line, "=")
+			if eq < 0 {
+				return nil, fmt.Errorf("line %d: expected key = value", lineNo)
+			}
+			key := splitTomlKey(line[:eq])
+			raw := strings.TrimSpace(line[eq+1:])
+			// Multi-line arrays: keep reading until the brackets balance
+			for strings.HasPrefix(raw, "[") && !tomlBalanced(raw) && i+1 < len(lines) {
+				i++
+				raw += " " + strings.TrimSpace(stripTomlComment(lines[i]))
+			}
+			val, rest, err := parseTomlValue(raw)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %v", lineNo, err)
+			}
+			if strings.TrimSpace(rest) != "" {
+				return nil, fmt.Errorf("line %d: unexpected %q after value", lineNo, rest)
+			}
+			t, err := tomlDescend(cur, key[:len(key)-1], lineNo)
+			if err != nil {
+				return nil, err
+			}
+			t[key[len(key)-1]] = val
+		}
+	}
+	return root, nil
+}
+
+// tomlDescend walks/creates nested tables; for arrays of tables it enters
+// the last element, as TOML specifies.
+func tomlDescend(t tomlTable, path []string, lineNo int) (tomlTable, error) {
+	for _, k := range path {
+		switch v := t[k].(type) {
+		case nil:
+			n := tomlTable{}
+			t[k] = n
+			t = n
+		case tomlTable:
+			t = v
+		case []any:
+			if len(v) == 0 {
+				return nil, fmt.Errorf("line %d: %q is not a table", lineNo, k)
+			}
+			last, ok := v[len(v)-1].(tomlTable)
+			if !ok {
+				return nil, fmt.Errorf("line %d: %q is not a table", lineNo, k)
+			}
+			t = last
+		default:
+			return nil, fmt.Errorf("line %d: %q is not a table", lineNo, k)
+		}
+	}
+	return t, nil
+}
+
+// splitTomlKey splits a dotted key; dots and spaces inside quotes belong
+// to the key.
+func splitTomlKey(s string) []string {
+	var parts []string
+	var cur strings.Builder
+	var quote byte
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote != 0 && c == quote:
+			quote = 0
+		case quote != 0:
+			cur.WriteByte(c)
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '.':
+			parts = append(parts, cur.String())
+			cur.Reset()
+		case c != ' ' && c != '\t':
+			cur.WriteByte(c)
+		}
+	}
+	return append(parts, cur.String())
+}
+
+// stripTomlComment removes a trailing # comment that is not inside a string.
+func stripTomlComment(line string) string {
+	var quote byte
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case quote != 0:
+			if c == '\\' && quote == '"' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '#':
+			return line[:i]
+		}
+	}
+	return line
+}
+
+func tomlBalanced(s string) bool {
+	depth := 0
+	var quote byte
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote != 0:
+			if c == '\\' && quote == '"' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '[':
+			depth++
+		case c == ']':
+			depth--
+		}
+	}
+	return depth == 0
+}
+
+// parseTomlValue parses one value from the start of s and returns the rest.
+func parseTomlValue(s string) (any, string, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, "", fmt.Errorf("missing value")
+	}
+	switch s[0] {
+	case '"':
+		var sb strings.Builder
+		for i := 1; i < len(s); i++ {
+			c := s[i]
+			if c == '"' {
+				return sb.String(), s[i+1:], nil
+			}
+			if c != '\\' {
+				sb.WriteByte(c)
+				continue
+			}
+			i++
+			if i >= len(s) {
+				break
+			}
+			switch s[i] {
+			case 'n':
+				sb.WriteByte('\n')
+			case 't':
+				sb.WriteByte('\t')
+			case 'r':
+				sb.WriteByte('\r')
+			case '"', '\\':
+				sb.WriteByte(s[i])
+			case 'u':
+				if i+4 >= len(s) {
+					return nil, "", fmt.Errorf("bad \\u escape")
+				}
+				r, err := strconv.ParseUint(s[i+1:i+5], 16, 32)
+				if err != nil {
+					return nil, "", fmt.Errorf("bad \\u escape")
+				}
+				sb.WriteRune(rune(r))
+				i += 4
+			default:
+				return nil, "", fmt.Errorf("unknown escape \\%c", s[i])
+			}
+		}
+		return nil, "", fmt.Errorf("unterminated string")
+	case '\'':
+		end := strings.IndexByte(s[1:], '\'')
+		if end < 0 {
+			return nil, "", fmt.Errorf("unterminated string")
+		}
+		return s[1 : end+1], s[end+2:], nil
+	case '[':
+		var arr []any
+		rest := strings.TrimSpace(s[1:])
+		for {
+			if strings.HasPrefix(rest, "]") {
+				return arr, rest[1:], nil
+			}
+			v, r, err := parseTomlValue(rest)
+			if err != nil {
+				return nil, "", err
+			}
+			arr = append(arr, v)
+			rest = strings.TrimSpace(r)
+			if strings.HasPrefix(rest, ",") {
+				rest = strings.TrimSpace(rest[1:])
+			} else if !strings.HasPrefix(rest, "]") {
+				return nil, "", fmt.Errorf("expected , or ] in array")
+			}
+		}
+	}
+	end := strings.IndexAny(s, ",]")
+	if end < 0 {
+		end = len(s)
+	}
+	tok := strings.TrimSpace(s[:end])
+	rest := s[end:]
+	switch tok {
+	case "true":
+		return true, rest, nil
+	case "false":
+		return false, rest, nil
+	}
+	clean := strings.ReplaceAll(tok, "_", "")
+	if n, err := strconv.ParseInt(clean, 10, 64); err == nil {
+		return n, rest, nil
+	}
+	if f, err := strconv.ParseFloat(clean, 64); err == nil {
+		return f, rest, nil
+	}
+	return nil, "", fmt.Errorf("invalid value %q", tok)
+}
+
+// decodeToml parses src into the struct pointed to by v. Unknown keys are
+// ignored so older binaries can read newer configs.
+func decodeToml(src string, v any) error {
+	doc, err := parseToml(src)
+	if err != nil {
+		return err
+	}
+	return assignToml(reflect.ValueOf(v).Elem(), doc, "")
+}
+
+func assignToml(dst reflect.Value, val any, path string) error {
+	switch dst.Kind() {
+	case reflect.Struct:
+		t, ok := val.(tomlTable)
+		if !ok {
+			return fmt.Errorf("%s: expected a table", path)
+		}
+		typ := dst.Type()
+		for i := 0; i < typ.NumField(); i++ {
+			name := tomlFieldName(typ.Field(i))
+			if name == "" {
+				continue
+			}
+			if fv, ok := t[name]; ok {
+				if err := assignToml(dst.Field(i), fv, joinTomlPath(path, name)); err != nil {
+					return err
+				}
+			}
+		}
+	case reflect.Map:
+		t, ok := val.(tomlTable)
+		if !ok {
+			return fmt.Errorf("%s: expected a table", path)
+		}
+		if dst.IsNil() {
+			dst.Set(reflect.MakeMap(dst.Type()))
+		}
+		for k, fv := range t {
+			ev := reflect.New(dst.Type().Elem()).Elem()
+			if err := assignToml(ev, fv, joinTomlPath(path, k)); err != nil {
+				return err
+			}
+			dst.SetMapIndex(reflect.ValueOf(k), ev)
+		}
+	case reflect.Slice:
+		arr, ok := val.([]any)
+		if !ok {
+			return fmt.Errorf("%s: expected an array", path)
+		}
+		s := reflect.MakeSlice(dst.Type(), len(arr), len(arr))
+		for i, ev := range arr {
+			if err := assignToml(s.Index(i), ev, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+		dst.Set(s)
+	case reflect.Array:
+		arr, ok := val.([]any)
+		if !ok || len(arr) != dst.Len() {
+			return fmt.Errorf("%s: expected an array of %d values", path, dst.Len())
+		}
+		for i, ev := range arr {
+			if err := assignToml(dst.Index(i), ev, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+	case reflect.String:
+		s, ok := val.(string)
+		if !ok {
+			return fmt.Errorf("%s: expected a string", path)
+		}
+		dst.SetString(s)
+	case reflect.Bool:
+		b, ok := val.(bool)
+		if !ok {
+			return fmt.Errorf("%s: expected true or false", path)
+		}
+		dst.SetBool(b)
+	case reflect.Int, reflect.Int64, reflect.Int32:
+		n, ok := val.(int64)
+		if !ok {
+			return fmt.Errorf("%s: expected an integer", path)
+		}
+		dst.SetInt(n)
+	case reflect.Float64:
+		switch n := val.(type) {
+		case float64:
+			dst.SetFloat(n)
+		case int64:
+			dst.SetFloat(float64(n))
+		default:
+			return fmt.Errorf("%s: expected a number", path)
+		}
+	default:
+		return fmt.Errorf("%s: unsupported field type %s", path, dst.Type())
+	}
+	return nil
+}
+
+func tomlFieldName(f reflect.StructField) string {
+	if !f.IsExported() {
+		return ""
+	}
+	tag := f.Tag.Get("toml")
+	if tag == "-" {
+		return ""
+	}
+	if tag != "" {
+		return tag
+	}
+	return strings.ToLower(f.Name)
+}
+
+func joinTomlPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// ─── Encoding ────────────────────────────────────────────────────────────────
+
+// encodeToml renders a struct as TOML: scalars first, then [tables] and
+// [[arrays of tables]]. Map keys are sorted for stable output.
+func encodeToml(v any) string {
+	return encodeTomlOver(v, nil)
+}
+
+// encodeTomlOver is encodeToml but leaves out values equal to those in base,
+// so a file layered over base only records what differs. base may be nil.
+func encodeTomlOver(v, base any) string {
+	var sb strings.Builder
+	var bv reflect.Value
+	if base != nil {
+		bv = reflect.ValueOf(base)
+	}
+	writeTomlTable(&sb, reflect.ValueOf(v), bv, "")
+	return strings.TrimLeft(sb.String(), "\n")
+}
+
+func isTomlTable(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct || (t.Kind() == reflect.Map && t.Key().Kind() == reflect.String)
+}
+
+func isTomlTableArray(t reflect.Type) bool {
+	return t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Struct
+}
+
+type tomlEntry struct {
+	name string
+	val  reflect.Value
+}
+
+func tomlEntries(v reflect.Value) []tomlEntry {
+	var out []tomlEntry
+	if v.Kind() == reflect.Map {
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool { return keys[i].String() < keys[j].String() })
+		for _, k := range keys {
+			out = append(out, tomlEntry{k.String(), v.MapIndex(k)})
+		}
+		return out
+	}
+	typ := v.Type()
+	for i := 0; i < typ.NumField(); i++ {
+		if name := tomlFieldName(typ.Field(i)); name != "" {
+			out = append(out, tomlEntry{name, v.Field(i)})
+		}
+	}
+	return out
+}
+
+// tomlEntryMap indexes v's entries by name; nil for an invalid v.
+func tomlEntryMap(v reflect.Value) map[string]reflect.Value {
+	if !v.IsValid() {
+		return nil
+	}
+	m := map[string]reflect.Value{}
+	for _, e := range tomlEntries(v) {
+		m[e.name] = e.val
+	}
+	return m
+}
+
+// sameTomlValue reports whether v equals the base value b (if there is one).
+func sameTomlValue(v, b reflect.Value) bool {
+	return b.IsValid() && reflect.DeepEqual(v.Interface(), b.Interface())
+}
+
+func writeTomlTable(sb *strings.Builder, v, base reflect.Value, path string) {
+	entries := tomlEntries(v)
+	baseVals := tomlEntryMap(base)
+	for _, e := range entries {
+		if isTomlTable(e.val.Type()) || isTomlTableArray(e.val.Type()) {
+			continue
+		}
+		if sameTomlValue(e.val, baseVals[e.name]) {
+			continue
+		}
+		fmt.Fprintf(sb, "%s = %s\n", tomlKey(e.name), tomlValue(e.val))
+	}
+	for _, e := range entries {
+		p := joinTomlPath(path, tomlKey(e.name))
+		switch {
+		case isTomlTable(e.val.Type()):
+			if e.val.Kind() == reflect.Map && e.val.Len() == 0 {
+				continue
+			}
+			var sub strings.Builder
+			writeTomlTable(&sub, e.val, baseVals[e.name], p)
+			// Skip the header when the table only holds sub-tables
+			if sub.Len() > 0 && !strings.HasPrefix(sub.String(), "\n") {
+				fmt.Fprintf(sb, "\n[%s]\n", p)
+			}
+			sb.WriteString(sub.String())
+		case isTomlTableArray(e.val.Type()):
+			if sameTomlValue(e.val, baseVals[e.name]) {
+				continue
+			}
+			for i := 0; i < e.val.Len(); i++ {
+				fmt.Fprintf(sb, "\n[[%s]]\n", p)
+				writeTomlTable(sb, e.val.Index(i), reflect.Value{}, p)
+			}
+		}
+	}
+}
+
+func tomlKey(k string) string {
+	for _, r := range k {
+		if !(r == '_' || r == '-' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9') {
+			return tomlString(k)
+		}
+	}
+	if k == "" {
+		return `""`
+	}
+	return k
+}
+
+func tomlValue(v reflect.Value) string {
+	switch v.Kind() {
+	case reflect.String:
+		return tomlString(v.String())
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool())
+	case reflect.Int, reflect.Int64, reflect.Int32:
+		return strconv.FormatInt(v.Int(), 10)
+	case reflect.Float64:
+		s := strconv.FormatFloat(v.Float(), 'f', -1, 64)
+		if !strings.ContainsAny(s, ".e") {
+			s += ".0"
+		}
+		return s
+	case reflect.Slice, reflect.Array:
+		parts := make([]string, v.Len())
+		for i := range parts {
+			parts[i] = tomlValue(v.Index(i))
+		}
+		return "[" + strings.Join(parts, ", ") + "]"
+	}
+	return `""`
+}
+
+// tomlString quotes s as a TOML basic string. strconv.Quote is close, but
+// its \x, \a and \v escapes are not TOML.
+func tomlString(s string) string {
+	var sb strings.Builder
+	sb.WriteByte('"')
+	for _, r := range s {
+		switch {
+		case r == '"' || r == '\\':
+			sb.WriteByte('\\')
+			sb.WriteRune(r)
+		case r == '\n':
+			sb.WriteString(`\n`)
+		case r == '\t':
+			sb.WriteString(`\t`)
+		case r == '\r':
+			sb.WriteString(`\r`)
+		case r < 0x20 || r == 0x7f:
+			fmt.Fprintf(&sb, `\u%04x`, r)
+		default:
+			sb.WriteRune(r)
+		}
+	}
+	sb.WriteByte('"')
+	return sb.String()
+}
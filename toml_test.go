@@ -0,0 +1,132 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type tomlTestInner struct {
+	Name  string `toml:"name"`
+	Level int    `toml:"level"`
+}
+
+type tomlTestDoc struct {
+	Title   string            `toml:"title"`
+	Enabled bool              `toml:"enabled"`
+	Count   int               `toml:"count"`
+	Ratio   float64           `toml:"ratio"`
+	Tags    []string          `toml:"tags"`
+	Points  [3]int            `toml:"points"`
+	Labels  map[string]string `toml:"labels"`
+	Inner   tomlTestInner     `toml:"inner"`
+	Items   []tomlTestInner   `toml:"items"`
+	Skipped string            `toml:"-"`
+}
+
+func TestTomlRoundTrip(t *testing.T) {
+	in := tomlTestDoc{
+		Title:   "say \"hi\"\\ →\nnext\tline\x01",
+		Enabled: true,
+		Count:   -42,
+		Ratio:   2,
+		Tags:    []string{"a", "b c", ""},
+		Points:  [3]int{30, 40, 50},
+		Labels:  map[string]string{"Balanced": "x", "Rainbow Cycle": "y", "a.b": "z"},
+		Inner:   tomlTestInner{Name: "in", Level: 3},
+		Items:   []tomlTestInner{{Name: "one", Level: 1}, {Name: "two"}},
+		Skipped: "not written",
+	}
+	src := encodeToml(in)
+	var out tomlTestDoc
+	if err := decodeToml(src, &out); err != nil {
+		t.Fatalf("%v\n%s", err, src)
+	}
+	in.Skipped = ""
+	if !reflect.DeepEqual(in, out) {
+		t.Errorf("round trip changed the document:\n got %+v\nwant %+v\n%s", out, in, src)
+	}
+}
+
+// TestTomlEncodeOver checks values equal to the base are left out.
+func TestTomlEncodeOver(t *testing.T) {
+	base := tomlTestDoc{Title: "base", Count: 1, Tags: []string{"a"}}
+	doc := base
+	doc.Count = 2
+	got := encodeTomlOver(doc, base)
+	if !strings.Contains(got, "count = 2") || strings.Contains(got, "title") || strings.Contains(got, "tags") {
+		t.Errorf("encodeTomlOver wrote:\n%s", got)
+	}
+}
+
+func TestTomlDecode(t *testing.T) {
+	src := `
+# comment
+title = 'literal \n'   # trailing comment
+count = 1_000
+ratio = 5
+tags = [
+  "a",  # first
+  "b#c",
+]
+points = [1, 2, 3]
+
+[inner]
+name = "x"
+
+[labels]
+"quoted key" = "v"
+
+[[items]]
+name = "one"
+[[items]]
+name = "two"
+level = 2
+`
+	var d tomlTestDoc
+	if err := decodeToml(src, &d); err != nil {
+		t.Fatal(err)
+	}
+	want := tomlTestDoc{
+		Title: `literal \n`, Count: 1000, Ratio: 5,
+		Tags: []string{"a", "b#c"}, Points: [3]int{1, 2, 3},
+		Inner:  tomlTestInner{Name: "x"},
+		Labels: map[string]string{"quoted key": "v"},
+		Items:  []tomlTestInner{{Name: "one"}, {Name: "two", Level: 2}},
+	}
+	if !reflect.DeepEqual(d, want) {
+		t.Errorf("got  %+v\nwant %+v", d, want)
+	}
+}
+
+func TestTomlErrors(t *testing.T) {
+	tests := []struct {
+		name, src, want string
+	}{
+		{"no value", "title =", "line 1: missing value"},
+		{"no equals", "title", "line 1: expected key = value"},
+		{"unterminated string", `title = "abc`, "line 1: unterminated string"},
+		{"bad escape", `title = "a\qb"`, `line 1: unknown escape \q`},
+		{"bad value", "count = twelve", `line 1: invalid value "twelve"`},
+		{"after value", `title = "a" "b"`, "line 1: unexpected"},
+		{"unterminated table", "\n[inner", "line 2: unterminated [table]"},
+		{"unterminated array table", "[[items]", "line 1: unterminated [[table]]"},
+		{"array separator", "tags = [\"a\" \"b\"]", "expected , or ]"},
+		{"not a table", "title = \"x\"\n[title]", `line 2: "title" is not a table`},
+		{"wrong type", `count = "1"`, "count: expected an integer"},
+		{"wrong bool", `enabled = 1`, "enabled: expected true or false"},
+		{"wrong array length", `points = [1, 2]`, "points: expected an array of 3 values"},
+		{"wrong element", `tags = ["a", 1]`, "tags[1]: expected a string"},
+		{"table expected", `inner = 1`, "inner: expected a table"},
+		{"nested path", "[[items]]\nlevel = true", "items[0].level: expected an integer"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var d tomlTestDoc
+			err := decodeToml(tt.src, &d)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("err = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
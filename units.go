@@ -0,0 +1,59 @@
+package main
+
+import (
+	"fmt"
+	"math"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// Units — temperatures are read and stored in °C everywhere (sysfs, asusd's
+// fan curves) and converted only here, when formatted for display.
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// fahrenheit is set from temperature_unit in the config.
+var fahrenheit bool
+
+// displayTemp converts a °C reading to the display unit.
+func displayTemp(c float64) float64 {
+	if fahrenheit {
+		return c*9/5 + 32
+	}
+	return c
+}
+
+// tempUnit is the display unit's symbol, "°C" or "°F".
+func tempUnit() string {
+	if fahrenheit {
+		return "°F"
+	}
+	return "°C"
+}
+
+// formatTemp formats a °C reading, e.g. "62°C" or "144°F"; "—" when there is
+// no reading.
+func formatTemp(c float64) string {
+	if c <= 0 {
+		return "—"
+	}
+	return fmt.Sprintf("%.0f%s", displayTemp(c), tempUnit())
+}
+
+// formatTempLimit formats a whole °C value with the unit, 0 included, for
+// ranges and messages such as "must not be below 32°F".
+func formatTempLimit(c int) string {
+	return fmt.Sprintf("%.0f%s", displayTemp(float64(c)), tempUnit())
+}
+
+// formatTempDeg formats a whole °C value without the unit letter, for axes
+// and tables that name the unit once, e.g. "144°".
+func formatTempDeg(c int) string {
+	return fmt.Sprintf("%.0f°", displayTemp(float64(c)))
+}
+
+// displayTemps converts a fan curve's °C points to the display unit.
+func displayTemps(c [8]int) [8]int {
+	for i, v := range c {
+		c[i] = int(math.Round(displayTemp(float64(v))))
+	}
+	return c
+}
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestTemperaturesFollowUnit checks ranges and messages use the configured
+// unit, not °C.
+func TestTemperaturesFollowUnit(t *testing.T) {
+	fahrenheit = true
+	t.Cleanup(func() { fahrenheit = false })
+
+	at := ArmouryAttr{Name: "nv_temp_target", Min: 75, Max: 87}
+	if got, want := armouryRange(at), "167°–189°F"; got != want {
+		t.Errorf("armouryRange = %q, want %q", got, want)
+	}
+	if got, want := armouryUnit(at.Name, "87"), "189°F"; got != want {
+		t.Errorf("armouryUnit = %q, want %q", got, want)
+	}
+	_, _, err := ParseFanCurveData("30c:0%,40c:5%,50c:10%,45c:20%,70c:35%,80c:55%,90c:65%,100c:65%")
+	if err == nil || !strings.Contains(err.Error(), "122°F") {
+		t.Errorf("err = %v, want the limit in °F", err)
+	}
+	if _, err := curveTempC(-5, "c"); err == nil || !strings.Contains(err.Error(), "32°F") {
+		t.Errorf("err = %v, want the limit in °F", err)
+	}
+}
@@ -0,0 +1,190 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// Workspaces — [[workspaces]] in the config bundle a scene and the [[rules]]
+// tagged with their name for one place ("home", "office", "travel").
+// Switching to one from the command palette applies its scene, and from
+// then on only untagged rules and its own fire; the choice is kept in the
+// state directory, not the config, so editing or reloading the config
+// leaves it alone. With ssids set, --daemon switches by itself when the Wi-Fi joins
+// one of those networks, read from NetworkManager with nmcli.
+// ═══════════════════════════════════════════════════════════════════════════════
+
+type NetworkControl interface {
+	// GetWifiSSID is the network the Wi-Fi is connected to; ok is false
+	// while it is not connected or NetworkManager is unavailable.
+	GetWifiSSID() (ssid string, ok bool)
+}
+
+func (w Workspace) Validate() error {
+	if strings.TrimSpace(w.Name) == "" {
+		return errors.New("name: must not be empty")
+	}
+	for _, s := range w.SSIDs {
+		if s == "" {
+			return errors.New("ssids: must not contain an empty name")
+		}
+	}
+	return nil
+}
+
+// workspaceIndex is the index of the named workspace, or -1.
+func (c *Config) workspaceIndex(name string) int {
+	for i, w := range c.Workspaces {
+		if strings.EqualFold(w.Name, name) {
+			return i
+		}
+	}
+	return -1
+}
+
+// checkWorkspaces validates the workspaces and the names that refer to
+// them or from them.
+func (c *Config) checkWorkspaces() error {
+	for i, w := range c.Workspaces {
+		if err := w.Validate(); err != nil {
+			return fmt.Errorf("workspaces[%d]: %w", i, err)
+		}
+		if c.workspaceIndex(w.Name) != i {
+			return fmt.Errorf("workspaces[%d]: name: %q is used twice", i, w.Name)
+		}
+		found := w.Scene == ""
+		for _, sc := range c.Scenes {
+			found = found || strings.EqualFold(sc.Name, w.Scene)
+		}
+		if !found {
+			return fmt.Errorf("workspaces[%d]: scene: no scene named %q", i, w.Scene)
+		}
+	}
+	for i, r := range c.Rules {
+		if r.Workspace != "" && c.workspaceIndex(r.Workspace) < 0 {
+			return fmt.Errorf("rules[%d]: workspace: no workspace named %q", i, r.Workspace)
+		}
+	}
+	return nil
+}
+
+// hasWifiWorkspaces reports whether any workspace is picked by network.
+func (c *Config) hasWifiWorkspaces() bool {
+	for _, w := range c.Workspaces {
+		if len(w.SSIDs) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func workspacePath() string {
+	dir := stateDir()
+	if dir == "" {
+		return ""
+	}
+	return filepath.Join(dir, "workspace")
+}
+
+// loadWorkspace reads the workspace switched to last. One the config no
+// longer has counts as none. The demo keeps its choice for the session.
+func (a *App) loadWorkspace() string {
+	if _, demo := a.backend.(*MockBackend); demo {
+		return ""
+	}
+	data, err := os.ReadFile(workspacePath())
+	if err != nil {
+		return ""
+	}
+	name := strings.TrimSpace(string(data))
+	if a.cfg.workspaceIndex(name) < 0 {
+		return ""
+	}
+	return name
+}
+
+func (a *App) saveWorkspace() error {
+	if _, demo := a.backend.(*MockBackend); demo {
+		return nil
+	}
+	path := workspacePath()
+	if path == "" {
+		return errors.New("no state directory")
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(path+".tmp", []byte(a.workspace+"\n"), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(path+".tmp", path)
+}
+
+// ruleActive reports whether r fires in the active workspace.
+func (a *App) ruleActive(r Rule) bool {
+	return r.Workspace == "" || strings.EqualFold(r.Workspace, a.workspace)
+}
+
+// switchWorkspace makes w the active workspace and applies its scene, which
+// reports its own result.
+func (a *App) switchWorkspace(w Workspace) {
+	a.workspace = w.Name
+	if err := a.saveWorkspace(); err != nil {
+		a.SetStatus("Workspace "+w.Name+" for this session only: "+err.Error(), false)
+	} else {
+		a.SetStatus("Workspace "+w.Name, true)
+	}
+	if i := a.sceneIndex(w.Scene); i >= 0 {
+		a.applyScene(a.cfg.Scenes[i], nil)
+	}
+}
+
+// checkWifi switches to the workspace of network ssid, read by the
+// automation ticker, when that changes. Only --daemon sets wifiAuto; in the
+// UI the palette decides.
+func (a *App) checkWifi(ssid string) {
+	au := &a.automation
+	if ssid == au.ssid {
+		return
+	}
+	au.ssid = ssid
+	for _, w := range a.cfg.Workspaces {
+		for _, s := range w.SSIDs {
+			if s != ssid {
+				continue
+			}
+			if !strings.EqualFold(w.Name, a.workspace) {
+				a.automationLog("Wi-Fi "+ssid+": workspace "+w.Name, "", true)
+				a.switchWorkspace(w)
+			}
+			return
+		}
+	}
+}
+
+func (b *ExecBackend) GetWifiSSID() (string, bool) {
+	if _, err := hostLookPath("nmcli"); err != nil {
+		return "", false
+	}
+	ok, out := execWithTimeout(hostCommand("nmcli", "-t", "-f", "active,ssid",
+		"dev", "wifi", "list", "--rescan", "no"), b.policy.Timeout)
+	if !ok {
+		return "", false
+	}
+	return parseNmcliSSID(out)
+}
+
+// parseNmcliSSID picks the connected network from `nmcli -t -f active,ssid
+// dev wifi list`: one "yes:Name" or "no:Name" per line, ':' escaped as "\:".
+func parseNmcliSSID(out string) (string, bool) {
+	for _, line := range strings.Split(out, "\n") {
+		if ssid, ok := strings.CutPrefix(strings.TrimRight(line, "\r"), "yes:"); ok && ssid != "" {
+			return strings.NewReplacer(`\:`, ":", `\\`, `\`).Replace(ssid), true
+		}
+	}
+	return "", false
+}